@@ -0,0 +1,87 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	errABIType  = errors.New("uint256: unsupported abi type")
+	errABIRange = errors.New("uint256: value out of range for abi type")
+)
+
+// parseABIType splits an ABI elementary type name into its kind ("uint",
+// "int" or "bytes") and size: in bits, a multiple of 8 up to 256, for uintN
+// and intN, and in bytes, from 1 to 32, for bytesN. The bare "uint" and "int"
+// are aliases for uint256 and int256.
+func parseABIType(typ string) (kind string, size int, err error) {
+	for _, kind = range []string{"uint", "int", "bytes"} {
+		if !strings.HasPrefix(typ, kind) {
+			continue
+		}
+		digits := typ[len(kind):]
+		if digits == "" && kind != "bytes" {
+			return kind, 256, nil
+		}
+		// Reject signs and leading zeros, which Atoi would accept.
+		if digits == "" || digits[0] < '1' || digits[0] > '9' {
+			break
+		}
+		size, err := strconv.Atoi(digits)
+		if err != nil {
+			break
+		}
+		if kind == "bytes" {
+			if size <= 32 {
+				return kind, size, nil
+			}
+		} else if size%8 == 0 && size <= 256 {
+			return kind, size, nil
+		}
+		break
+	}
+	return "", 0, errABIType
+}
+
+// EncodeTyped returns the 32-byte ABI encoding of z as the elementary type
+// typ, as used in EIP-712 typed-data hashing, after checking that z is in
+// range for it:
+//
+//   - uintN: z must fit in N bits, and is encoded as a big-endian word.
+//   - intN: z is interpreted as a 256-bit two's complement number, which must
+//     fit in N bits, and is encoded sign-extended.
+//   - bytesN: z must fit in N bytes, and is encoded as those N big-endian
+//     bytes, left-aligned and padded with zeros on the right.
+func (z *Int) EncodeTyped(typ string) ([]byte, error) {
+	kind, size, err := parseABIType(typ)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 32)
+	switch kind {
+	case "uint":
+		if z.BitLen() > size {
+			return nil, errABIRange
+		}
+		z.WriteToSlice(out)
+	case "int":
+		// All bits from the sign bit up must be equal.
+		top := *z
+		if top.Srsh(&top, uint(size-1)); !top.IsZero() && !top.Eq(new(Int).SetAllOne()) {
+			return nil, errABIRange
+		}
+		z.WriteToSlice(out)
+	case "bytes":
+		if z.ByteLen() > size {
+			return nil, errABIRange
+		}
+		z.WriteToSlice(out[:size])
+	}
+	return out, nil
+}
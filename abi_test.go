@@ -0,0 +1,65 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEncodeTyped(t *testing.T) {
+	minusOne := new(Int).SetAllOne()
+	// -2**47, the minimum int48.
+	minInt48 := new(Int).Lsh(minusOne, 47)
+	for _, tc := range []struct {
+		x   *Int
+		typ string
+		exp string // hex of the 32-byte word, empty for errABIRange
+	}{
+		{new(Int).SetUint64(0xffffffffffff), "uint48", "0000000000000000000000000000000000000000000000000000ffffffffffff"},
+		{new(Int).SetUint64(0x1000000000000), "uint48", ""},
+		{new(Int).SetUint64(1), "uint", "0000000000000000000000000000000000000000000000000000000000000001"},
+		{minusOne, "uint256", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{minusOne, "uint248", ""},
+		{minusOne, "int8", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{new(Int).SetUint64(127), "int8", "000000000000000000000000000000000000000000000000000000000000007f"},
+		{new(Int).SetUint64(128), "int8", ""},
+		{minInt48, "int48", "ffffffffffffffffffffffffffffffffffffffffffffffffffff800000000000"},
+		{new(Int).Sub(minInt48, new(Int).SetUint64(1)), "int48", ""},
+		{minusOne, "int", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{new(Int).SetUint64(0xabcd), "bytes2", "abcd000000000000000000000000000000000000000000000000000000000000"},
+		{new(Int).SetUint64(0xcd), "bytes2", "00cd000000000000000000000000000000000000000000000000000000000000"},
+		{new(Int).SetUint64(0x1abcd), "bytes2", ""},
+		{minusOne, "bytes32", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	} {
+		got, err := tc.x.EncodeTyped(tc.typ)
+		if tc.exp == "" {
+			if err != errABIRange {
+				t.Errorf("%v as %s: got err %v, exp %v", tc.x.Hex(), tc.typ, err, errABIRange)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v as %s: %v", tc.x.Hex(), tc.typ, err)
+			continue
+		}
+		if !bytes.Equal(got, hex2Bytes(tc.exp)) {
+			t.Errorf("%v as %s: got %x, exp %s", tc.x.Hex(), tc.typ, got, tc.exp)
+		}
+	}
+}
+
+func TestEncodeTypedInvalidType(t *testing.T) {
+	x := new(Int).SetUint64(1)
+	for _, typ := range []string{
+		"", "uint0", "uint7", "uint264", "uint08", "uint+8", "int-8", "int 8",
+		"bytes", "bytes0", "bytes33", "bytes01", "address", "bool", "uintx",
+	} {
+		if _, err := x.EncodeTyped(typ); err != errABIType {
+			t.Errorf("%q: got err %v, exp %v", typ, err, errABIType)
+		}
+	}
+}
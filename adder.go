@@ -0,0 +1,180 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// adderShard is one of the partial sums of an Adder, padded to its own cache
+// lines so that shards updated on different CPUs do not contend.
+//
+// It is a carry-save sum, updated with atomic additions only: words[j]
+// accumulates the j-th 32-bit digits of the addends, and the shard holds the
+// sum of words[j] * 2**(32*j). The upper half of a word is thus a carry into
+// the next one, which is folded into it before the word can overflow. Words 6
+// and 7 are not folded, as their overflow is a multiple of 2**256.
+type adderShard struct {
+	words   [8]uint64
+	started uint64 // Folds and resets started, and
+	done    uint64 // those done, so that loads can tell one in progress.
+	_       [48]byte
+}
+
+// resetting is set in the started count of a shard while it is reset.
+const resetting = 1 << 63
+
+// foldAt is the value of a word at which its carry is folded, leaving room for
+// 2**31 digits to be added before it is.
+const foldAt = 1 << 63
+
+// add adds x to s.
+func (s *adderShard) add(x *Int) {
+	for j := range s.words {
+		d := x[j/2] >> uint(32*(j%2)) & 0xffffffff
+		if d == 0 {
+			continue
+		}
+		if atomic.AddUint64(&s.words[j], d) >= foldAt && j < 6 {
+			s.fold(j)
+		}
+	}
+}
+
+// fold moves the carry in words[j], and in the words it overflows into, into
+// the next word, which does not change the sum.
+func (s *adderShard) fold(j int) {
+	for {
+		started := atomic.LoadUint64(&s.started)
+		if started&resetting == 0 && atomic.CompareAndSwapUint64(&s.started, started, started+1) {
+			break
+		}
+		runtime.Gosched()
+	}
+	for ; j < 6; j++ {
+		w := atomic.LoadUint64(&s.words[j])
+		for w >= foldAt && !atomic.CompareAndSwapUint64(&s.words[j], w, w&0xffffffff) {
+			w = atomic.LoadUint64(&s.words[j])
+		}
+		// Another goroutine may have folded the word first.
+		if w < foldAt || atomic.AddUint64(&s.words[j+1], w>>32) < foldAt {
+			break
+		}
+	}
+	atomic.AddUint64(&s.done, 1)
+}
+
+// reset clears s. It waits for the folds in progress to finish, and holds off
+// new ones until it is done, so that no carry from before the reset is added
+// to the cleared words.
+func (s *adderShard) reset() {
+	var started uint64
+	for {
+		started = atomic.LoadUint64(&s.started)
+		if started&resetting == 0 && atomic.LoadUint64(&s.done) == started &&
+			atomic.CompareAndSwapUint64(&s.started, started, started|resetting) {
+			break
+		}
+		runtime.Gosched()
+	}
+	for j := range s.words {
+		atomic.StoreUint64(&s.words[j], 0)
+	}
+	atomic.StoreUint64(&s.started, started+1)
+	atomic.AddUint64(&s.done, 1)
+}
+
+// load returns the sum in s. Adds to s concurrent with load may be included
+// in part, but folds and resets are not.
+func (s *adderShard) load() Int {
+	var words [8]uint64
+	for {
+		started := atomic.LoadUint64(&s.started)
+		if atomic.LoadUint64(&s.done) == started {
+			for j := range words {
+				words[j] = atomic.LoadUint64(&s.words[j])
+			}
+			if atomic.LoadUint64(&s.started) == started {
+				break
+			}
+		}
+		runtime.Gosched()
+	}
+	var sum, w Int
+	for j := range words {
+		w.SetUint64(words[j])
+		sum.Add(&sum, w.Lsh(&w, uint(32*j)))
+	}
+	return sum
+}
+
+// Adder is a sum of Ints for high-throughput accumulation from many
+// goroutines, such as metrics of 256-bit quantities. It is split into shards,
+// and goroutines running on different CPUs mostly update different ones. Add
+// is lock-free: it adds the non-zero 32-bit digits of its operand to a shard
+// with atomic additions, one for values below 2**32, and rarely a
+// compare-and-swap to propagate a carry. Sum is comparatively expensive. The
+// zero value is an empty sum. An Adder must not be copied after first use.
+type Adder struct {
+	once   sync.Once
+	shards []adderShard
+	cache  sync.Pool // Shards recently used on the current P.
+	next   uint32    // Round-robin shard choice on cache misses.
+}
+
+// init allocates the shards, a power of two at least GOMAXPROCS.
+func (a *Adder) init() {
+	n := 1
+	for n < runtime.GOMAXPROCS(0) {
+		n *= 2
+	}
+	a.shards = make([]adderShard, n)
+}
+
+// Add adds x to the sum, modulo 2**256.
+func (a *Adder) Add(x *Int) {
+	a.once.Do(a.init)
+	// sync.Pool keeps a per-P cache, which gives goroutines running on the
+	// same P the same shard most of the time.
+	s, _ := a.cache.Get().(*adderShard)
+	if s == nil {
+		i := atomic.AddUint32(&a.next, 1)
+		s = &a.shards[i&uint32(len(a.shards)-1)]
+	}
+	s.add(x)
+	a.cache.Put(s)
+}
+
+// AddUint64 adds x to the sum, modulo 2**256.
+func (a *Adder) AddUint64(x uint64) {
+	a.Add(&Int{x})
+}
+
+// Sum returns the sum, modulo 2**256. Adds concurrent with Sum may be
+// included in whole, in part, or not at all: Sum is at least the sum of the
+// Adds which completed before it started, and at most the sum of those which
+// started before it returned, if neither wraps around.
+func (a *Adder) Sum() Int {
+	a.once.Do(a.init)
+	var sum Int
+	for i := range a.shards {
+		v := a.shards[i].load()
+		sum.Add(&sum, &v)
+	}
+	return sum
+}
+
+// Reset sets the sum to zero. Adds concurrent with Reset may be included in
+// the sum afterwards in whole, in part, or not at all, and a Sum concurrent
+// with Reset may include the Adds before it in part.
+func (a *Adder) Reset() {
+	a.once.Do(a.init)
+	for i := range a.shards {
+		a.shards[i].reset()
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAdder(t *testing.T) {
+	var a Adder
+	if v := a.Sum(); !v.IsZero() {
+		t.Fatalf("zero value: got %v", &v)
+	}
+	const (
+		goroutines = 16
+		adds       = 2000
+	)
+	delta := &Int{^uint64(0), ^uint64(0), 1}
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				a.Add(delta)
+				a.AddUint64(1)
+			}
+		}()
+	}
+	wg.Wait()
+	exp := new(Int).Add(delta, NewInt().SetOne())
+	exp.Mul(exp, NewInt().SetUint64(goroutines*adds))
+	if v := a.Sum(); !v.Eq(exp) {
+		t.Errorf("got %v, exp %v", &v, exp)
+	}
+	a.Reset()
+	if v := a.Sum(); !v.IsZero() {
+		t.Errorf("after Reset: got %v, exp 0", &v)
+	}
+	// The sum wraps like Add.
+	a.Add(new(Int).SetAllOne())
+	a.AddUint64(2)
+	if v := a.Sum(); !v.IsOne() {
+		t.Errorf("got %v, exp 1", &v)
+	}
+}
+
+// TestAdderCarries checks the folding of carries between the words of the
+// shards, starting from words just below the fold.
+func TestAdderCarries(t *testing.T) {
+	var a Adder
+	a.once.Do(a.init)
+	exp := new(Int)
+	for i := range a.shards {
+		for j := range a.shards[i].words {
+			a.shards[i].words[j] = foldAt - 1 - uint64(j)
+		}
+		v := a.shards[i].load()
+		exp.Add(exp, &v)
+	}
+	const (
+		goroutines = 8
+		adds       = 1000
+	)
+	xs := make([]Int, goroutines)
+	for g := range xs {
+		_, x, _ := randNums()
+		xs[g] = *x
+	}
+	xs[0].SetAllOne()
+	var wg sync.WaitGroup
+	for g := range xs {
+		wg.Add(1)
+		go func(x *Int) {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				a.Add(x)
+			}
+		}(&xs[g])
+		exp.Add(exp, new(Int).Mul(&xs[g], NewInt().SetUint64(adds)))
+	}
+	wg.Wait()
+	if v := a.Sum(); !v.Eq(exp) {
+		t.Errorf("got %v, exp %v", &v, exp)
+	}
+	for i := range a.shards {
+		for j, w := range a.shards[i].words[:6] {
+			if w >= foldAt {
+				t.Errorf("shard %d word %d not folded: %#x", i, j, w)
+			}
+		}
+	}
+}
+
+// TestAdderReset checks that Reset waits for the folds in progress, and that
+// Adds, Sums and Resets can run concurrently.
+func TestAdderReset(t *testing.T) {
+	var a Adder
+	a.once.Do(a.init)
+	s := &a.shards[0]
+	s.words[1] = 5
+	s.started = 1 // A fold in progress.
+	reset := make(chan struct{})
+	go func() {
+		a.Reset()
+		close(reset)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-reset:
+		t.Fatal("Reset did not wait for the fold in progress")
+	default:
+	}
+	atomic.AddUint64(&s.words[2], 1) // The carry of the fold.
+	atomic.AddUint64(&s.done, 1)
+	<-reset
+	if v := a.Sum(); !v.IsZero() {
+		t.Fatalf("after Reset: got %v, exp 0", &v)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			x := new(Int).SetAllOne()
+			for i := 0; i < 1000; i++ {
+				a.Add(x)
+				if i%100 == 0 {
+					a.Reset()
+					a.Sum()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	a.Reset()
+	if v := a.Sum(); !v.IsZero() {
+		t.Errorf("after Reset: got %v, exp 0", &v)
+	}
+	a.AddUint64(1)
+	if v := a.Sum(); !v.IsOne() {
+		t.Errorf("got %v, exp 1", &v)
+	}
+}
+
+func BenchmarkAdder(b *testing.B) {
+	x := NewInt().SetUint64(21000)
+	b.Run("Adder", func(b *testing.B) {
+		var a Adder
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				a.Add(x)
+			}
+		})
+	})
+	b.Run("Atomic", func(b *testing.B) {
+		var a Atomic
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				a.Add(x)
+			}
+		})
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "encoding/hex"
+
+// ToChecksumAddress returns the low 160 bits of z as a 0x-prefixed, EIP-55
+// mixed-case checksummed address. The checksum is computed using Keccak256.
+func (z *Int) ToChecksumAddress() string {
+	addr := z.Bytes20()
+	var buf [2 + 40]byte
+	buf[0], buf[1] = '0', 'x'
+	hex.Encode(buf[2:], addr[:])
+
+	hash := Keccak256(buf[2:])
+	for i := 2; i < len(buf); i++ {
+		nibble := hash[(i-2)/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if buf[i] > '9' && nibble&0xf > 7 {
+			buf[i] -= 'a' - 'A'
+		}
+	}
+	return string(buf[:])
+}
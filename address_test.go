@@ -0,0 +1,83 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestKeccak256(t *testing.T) {
+	var tests = []struct {
+		input string
+		hash  string
+	}{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	}
+	for _, tt := range tests {
+		h := keccak256([]byte(tt.input))
+		if got := hex.EncodeToString(h[:]); got != tt.hash {
+			t.Errorf("keccak256(%q): got %s exp %s", tt.input, got, tt.hash)
+		}
+	}
+	// The sponge is shared with SHA3-256, which has multi-block vectors.
+	tests = []struct {
+		input string
+		hash  string
+	}{
+		{"", "a7ffc6f8bf1ed76651c14756a061d662f580ff4de43b49fa82d80a4b80f8434a"},
+		{strings.Repeat("a", 135), "8094bb53c44cfb1e67b7c30447f9a1c33696d2463ecc1d9c92538913392843c9"},
+		{strings.Repeat("a", 136), "3fc5559f14db8e453a0a3091edbd2bc25e11528d81c66fa570a4efdcc2695ee1"},
+		{strings.Repeat("a", 300), "8a5720b2ca0cae7b89ad399c5daab22c29f5c72bcf30ab81e807d9bda95b4580"},
+	}
+	for _, tt := range tests {
+		h := keccakSponge256([]byte(tt.input), 0x06)
+		if got := hex.EncodeToString(h[:]); got != tt.hash {
+			t.Errorf("sha3-256(%q): got %s exp %s", tt.input, got, tt.hash)
+		}
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	// Test vectors from EIP-55.
+	for _, exp := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+		"0x52908400098527886E0F7030069857D2E4169EE7",
+		"0xde709f2102306220921060314715629080e2fb77",
+	} {
+		// Bits above 160 are ignored.
+		z := new(Int).SetBytes(hex2Bytes("ff" + strings.Repeat("00", 11) + exp[2:]))
+		if got := z.ToChecksumAddress(); got != exp {
+			t.Errorf("got %s exp %s", got, exp)
+		}
+	}
+}
+
+func TestSetKeccak256(t *testing.T) {
+	const exp = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	z := new(Int).SetBytes(hex2Bytes(exp[2:]))
+	if old := SetKeccak256(func([]byte) [32]byte { return [32]byte{} }); old != nil {
+		t.Errorf("default registered as non-nil")
+	}
+	// A zero hash leaves every letter lowercase.
+	if got := z.ToChecksumAddress(); got != strings.ToLower(exp) {
+		t.Errorf("registered: got %s exp %s", got, strings.ToLower(exp))
+	}
+	if old := SetKeccak256(nil); old == nil {
+		t.Errorf("registered function not returned")
+	}
+	if got := z.ToChecksumAddress(); got != exp {
+		t.Errorf("restored: got %s exp %s", got, exp)
+	}
+	if Keccak256([]byte("abc")) != keccak256([]byte("abc")) {
+		t.Errorf("restored: Keccak256 differs from the built-in one")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// The functions in this file implement multiplication with eight 32-bit
+// limbs instead of four 64-bit ones. On 32-bit platforms, bits.Mul64 is not
+// an intrinsic, but a product of two 32-bit limbs is a single widening
+// multiplication, and the limbs of an Int are split into halves for free, as
+// its 64-bit limbs live in register pairs anyway. The partial products are
+// summed column by column (product scanning), keeping the low and high
+// halves in separate 64-bit accumulators, which cannot overflow with at most
+// eight products per column. The backend is selected by the limb32 constant.
+// Addition and subtraction keep the 64-bit limbs, which measured as fast or
+// faster than 32-bit ones on 386.
+
+// mac32 adds the product a * b to the column accumulators lo and hi.
+func mac32(lo, hi uint64, a, b uint32) (uint64, uint64) {
+	p := uint64(a) * uint64(b)
+	return lo + uint64(uint32(p)), hi + p>>32
+}
+
+// split32 returns the 32-bit limbs of x, least significant first.
+func split32(x *Int) (x0, x1, x2, x3, x4, x5, x6, x7 uint32) {
+	return uint32(x[0]), uint32(x[0] >> 32), uint32(x[1]), uint32(x[1] >> 32),
+		uint32(x[2]), uint32(x[2] >> 32), uint32(x[3]), uint32(x[3] >> 32)
+}
+
+// mul32 implements Mul with 32-bit limbs.
+func (z *Int) mul32(x, y *Int) *Int {
+	x0, x1, x2, x3, x4, x5, x6, x7 := split32(x)
+	y0, y1, y2, y3, y4, y5, y6, y7 := split32(y)
+	var lo, hi, r0, r1, r2, r3 uint64
+	lo, hi = mac32(lo, hi, x0, y0)
+	r0 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y1)
+	lo, hi = mac32(lo, hi, x1, y0)
+	r0 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y2)
+	lo, hi = mac32(lo, hi, x1, y1)
+	lo, hi = mac32(lo, hi, x2, y0)
+	r1 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y3)
+	lo, hi = mac32(lo, hi, x1, y2)
+	lo, hi = mac32(lo, hi, x2, y1)
+	lo, hi = mac32(lo, hi, x3, y0)
+	r1 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y4)
+	lo, hi = mac32(lo, hi, x1, y3)
+	lo, hi = mac32(lo, hi, x2, y2)
+	lo, hi = mac32(lo, hi, x3, y1)
+	lo, hi = mac32(lo, hi, x4, y0)
+	r2 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y5)
+	lo, hi = mac32(lo, hi, x1, y4)
+	lo, hi = mac32(lo, hi, x2, y3)
+	lo, hi = mac32(lo, hi, x3, y2)
+	lo, hi = mac32(lo, hi, x4, y1)
+	lo, hi = mac32(lo, hi, x5, y0)
+	r2 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y6)
+	lo, hi = mac32(lo, hi, x1, y5)
+	lo, hi = mac32(lo, hi, x2, y4)
+	lo, hi = mac32(lo, hi, x3, y3)
+	lo, hi = mac32(lo, hi, x4, y2)
+	lo, hi = mac32(lo, hi, x5, y1)
+	lo, hi = mac32(lo, hi, x6, y0)
+	r3 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	// The top column only contributes its low 32 bits.
+	lo += uint64(x0*y7 + x1*y6 + x2*y5 + x3*y4 + x4*y3 + x5*y2 + x6*y1 + x7*y0)
+	r3 |= lo << 32
+	z[0], z[1], z[2], z[3] = r0, r1, r2, r3
+	return z
+}
+
+// umul32 implements umul with 32-bit limbs.
+func umul32(x, y *Int) [8]uint64 {
+	x0, x1, x2, x3, x4, x5, x6, x7 := split32(x)
+	y0, y1, y2, y3, y4, y5, y6, y7 := split32(y)
+	var lo, hi, r0, r1, r2, r3, r4, r5, r6, r7 uint64
+	lo, hi = mac32(lo, hi, x0, y0)
+	r0 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y1)
+	lo, hi = mac32(lo, hi, x1, y0)
+	r0 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y2)
+	lo, hi = mac32(lo, hi, x1, y1)
+	lo, hi = mac32(lo, hi, x2, y0)
+	r1 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y3)
+	lo, hi = mac32(lo, hi, x1, y2)
+	lo, hi = mac32(lo, hi, x2, y1)
+	lo, hi = mac32(lo, hi, x3, y0)
+	r1 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y4)
+	lo, hi = mac32(lo, hi, x1, y3)
+	lo, hi = mac32(lo, hi, x2, y2)
+	lo, hi = mac32(lo, hi, x3, y1)
+	lo, hi = mac32(lo, hi, x4, y0)
+	r2 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y5)
+	lo, hi = mac32(lo, hi, x1, y4)
+	lo, hi = mac32(lo, hi, x2, y3)
+	lo, hi = mac32(lo, hi, x3, y2)
+	lo, hi = mac32(lo, hi, x4, y1)
+	lo, hi = mac32(lo, hi, x5, y0)
+	r2 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y6)
+	lo, hi = mac32(lo, hi, x1, y5)
+	lo, hi = mac32(lo, hi, x2, y4)
+	lo, hi = mac32(lo, hi, x3, y3)
+	lo, hi = mac32(lo, hi, x4, y2)
+	lo, hi = mac32(lo, hi, x5, y1)
+	lo, hi = mac32(lo, hi, x6, y0)
+	r3 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x0, y7)
+	lo, hi = mac32(lo, hi, x1, y6)
+	lo, hi = mac32(lo, hi, x2, y5)
+	lo, hi = mac32(lo, hi, x3, y4)
+	lo, hi = mac32(lo, hi, x4, y3)
+	lo, hi = mac32(lo, hi, x5, y2)
+	lo, hi = mac32(lo, hi, x6, y1)
+	lo, hi = mac32(lo, hi, x7, y0)
+	r3 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x1, y7)
+	lo, hi = mac32(lo, hi, x2, y6)
+	lo, hi = mac32(lo, hi, x3, y5)
+	lo, hi = mac32(lo, hi, x4, y4)
+	lo, hi = mac32(lo, hi, x5, y3)
+	lo, hi = mac32(lo, hi, x6, y2)
+	lo, hi = mac32(lo, hi, x7, y1)
+	r4 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x2, y7)
+	lo, hi = mac32(lo, hi, x3, y6)
+	lo, hi = mac32(lo, hi, x4, y5)
+	lo, hi = mac32(lo, hi, x5, y4)
+	lo, hi = mac32(lo, hi, x6, y3)
+	lo, hi = mac32(lo, hi, x7, y2)
+	r4 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x3, y7)
+	lo, hi = mac32(lo, hi, x4, y6)
+	lo, hi = mac32(lo, hi, x5, y5)
+	lo, hi = mac32(lo, hi, x6, y4)
+	lo, hi = mac32(lo, hi, x7, y3)
+	r5 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x4, y7)
+	lo, hi = mac32(lo, hi, x5, y6)
+	lo, hi = mac32(lo, hi, x6, y5)
+	lo, hi = mac32(lo, hi, x7, y4)
+	r5 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x5, y7)
+	lo, hi = mac32(lo, hi, x6, y6)
+	lo, hi = mac32(lo, hi, x7, y5)
+	r6 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x6, y7)
+	lo, hi = mac32(lo, hi, x7, y6)
+	r6 |= lo << 32
+	lo, hi = lo>>32+hi, 0
+	lo, hi = mac32(lo, hi, x7, y7)
+	r7 = lo & 0xffffffff
+	lo, hi = lo>>32+hi, 0
+	r7 |= lo << 32
+	return [8]uint64{r0, r1, r2, r3, r4, r5, r6, r7}
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestLimb32 checks both multiplication backends on every platform, since
+// only one of them is used by Mul.
+func TestLimb32(t *testing.T) {
+	check := func(x, y *Int) {
+		t.Helper()
+		want := new(big.Int).Mul(x.ToBig(), y.ToBig())
+		for _, wide := range [][8]uint64{umul32(x, y), umul64(x, y)} {
+			have := new(big.Int)
+			for i := len(wide) - 1; i >= 0; i-- {
+				have.Lsh(have, 64).Or(have, new(big.Int).SetUint64(wide[i]))
+			}
+			if have.Cmp(want) != 0 {
+				t.Fatalf("umul %v * %v: have %#x, want %#x", x.Hex(), y.Hex(), have, want)
+			}
+		}
+		U256(want)
+		for _, have := range []*Int{new(Int).mul32(x, y), new(Int).mul64(x, y)} {
+			if !checkEq(want, have) {
+				t.Fatalf("mul %v * %v: have %v, want %#x", x.Hex(), y.Hex(), have.Hex(), want)
+			}
+		}
+		if x == y && !checkEq(want, new(Int).square64(x)) {
+			t.Fatalf("square %v: have %v, want %#x", x.Hex(), new(Int).square64(x).Hex(), want)
+		}
+	}
+	max := MaxUint256()
+	check(max, max)
+	check(max, new(Int))
+	check(&Int{0xffffffff, 0xffffffff00000000}, max)
+	for i := 0; i < 10000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randHighNums()
+		check(x, y)
+		check(y, y)
+	}
+}
+
+// BenchmarkLimb32 compares the 32-bit and 64-bit limb backends, e.g. with
+// GOARCH=386 go test -bench Limb32.
+func BenchmarkLimb32(b *testing.B) {
+	x, y := &int256Samples[0], &int256Samples[1]
+	var z Int
+	var wide [8]uint64
+	b.Run("Mul/limb64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.mul64(x, y)
+		}
+	})
+	b.Run("Mul/limb32", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.mul32(x, y)
+		}
+	})
+	b.Run("Square/limb64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.square64(x)
+		}
+	})
+	b.Run("Square/limb32", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			z.mul32(x, x)
+		}
+	})
+	b.Run("umul/limb64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			wide = umul64(x, y)
+		}
+	})
+	b.Run("umul/limb32", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			wide = umul32(x, y)
+		}
+	})
+	_ = wide
+}
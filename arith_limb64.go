@@ -0,0 +1,14 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build !386 && !arm
+// +build !386,!arm
+
+package uint256
+
+// limb32 reports whether multiplications use the 32-bit limb backend in
+// arith32.go. On 64-bit platforms, bits.Mul64 is a single instruction, and
+// the 64-bit limbs are used throughout.
+const limb32 = false
@@ -0,0 +1,13 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build !386 && !arm && !mips && !mipsle && !wasm && !tinygo
+// +build !386,!arm,!mips,!mipsle,!wasm,!tinygo
+
+package uint256
+
+// fastDiv64 reports whether bits.Div64 is fast enough to divide by a word
+// one limb at a time, as it is on 64-bit architectures with the gc compiler.
+const fastDiv64 = true
@@ -0,0 +1,17 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build 386 || arm || mips || mipsle || wasm || tinygo
+// +build 386 arm mips mipsle wasm tinygo
+
+package uint256
+
+// fastDiv64 reports whether bits.Div64 is fast enough to divide by a word
+// one limb at a time. On 32-bit architectures and WebAssembly, and under
+// TinyGo, it is a software loop, which on 32-bit targets also calls into the
+// runtime for its own 64-bit divisions. There, reciprocals are computed by
+// table lookup and Newton iteration, and words are divided by multiplying
+// with them.
+const fastDiv64 = false
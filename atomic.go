@@ -0,0 +1,104 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Atomic holds an Int which can be read and updated by many goroutines
+// without external locking, like the types of sync/atomic. The zero value
+// holds zero. An Atomic must not be copied after first use.
+//
+// It is a sequence lock: loads do not block or write shared memory, and only
+// retry while a write is in progress, while writes are serialized by a
+// mutex. On 32-bit platforms, an Atomic must be 64-bit aligned, as required
+// by the 64-bit sync/atomic functions, e.g. by allocating it or placing it
+// first in a struct.
+type Atomic struct {
+	words [4]uint64 // The value, accessed with sync/atomic.
+	seq   uint64    // Incremented before and after each write, so odd during one.
+	mu    sync.Mutex
+}
+
+// Load returns the value of a.
+func (a *Atomic) Load() Int {
+	var v Int
+	for {
+		seq := atomic.LoadUint64(&a.seq)
+		if seq&1 == 0 {
+			for i := range v {
+				v[i] = atomic.LoadUint64(&a.words[i])
+			}
+			if atomic.LoadUint64(&a.seq) == seq {
+				return v
+			}
+		}
+		runtime.Gosched()
+	}
+}
+
+// value returns the value of a, while a.mu is held.
+func (a *Atomic) value() Int {
+	var v Int
+	for i := range v {
+		v[i] = atomic.LoadUint64(&a.words[i])
+	}
+	return v
+}
+
+// write sets the value of a to v, while a.mu is held.
+func (a *Atomic) write(v *Int) {
+	atomic.AddUint64(&a.seq, 1)
+	for i := range v {
+		atomic.StoreUint64(&a.words[i], v[i])
+	}
+	atomic.AddUint64(&a.seq, 1)
+}
+
+// Store sets the value of a to v.
+func (a *Atomic) Store(v *Int) {
+	val := *v
+	a.mu.Lock()
+	a.write(&val)
+	a.mu.Unlock()
+}
+
+// Swap sets the value of a to v, and returns the previous value.
+func (a *Atomic) Swap(v *Int) Int {
+	val := *v
+	a.mu.Lock()
+	old := a.value()
+	a.write(&val)
+	a.mu.Unlock()
+	return old
+}
+
+// CompareAndSwap sets the value of a to new if it is old, and reports
+// whether it did.
+func (a *Atomic) CompareAndSwap(old, new *Int) bool {
+	o, n := *old, *new
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.value() != o {
+		return false
+	}
+	a.write(&n)
+	return true
+}
+
+// Add adds delta to the value of a, modulo 2**256, and returns the new value.
+func (a *Atomic) Add(delta *Int) Int {
+	d := *delta
+	a.mu.Lock()
+	v := a.value()
+	v.Add(&v, &d)
+	a.write(&v)
+	a.mu.Unlock()
+	return v
+}
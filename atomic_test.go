@@ -0,0 +1,106 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomic(t *testing.T) {
+	a := new(Atomic)
+	if v := a.Load(); !v.IsZero() {
+		t.Fatalf("zero value: got %v", &v)
+	}
+	x := new(Int).SetAllOne()
+	a.Store(x)
+	if v := a.Load(); !v.Eq(x) {
+		t.Errorf("Load: got %v, exp %v", &v, x)
+	}
+	if v := a.Add(NewInt().SetOne()); !v.IsZero() {
+		t.Errorf("Add: got %v, exp 0 (wrapped)", &v)
+	}
+	if old := a.Swap(x); !old.IsZero() {
+		t.Errorf("Swap: got old %v, exp 0", &old)
+	}
+	if a.CompareAndSwap(new(Int), NewInt().SetOne()) {
+		t.Errorf("CompareAndSwap succeeded with wrong old value")
+	}
+	if !a.CompareAndSwap(x, NewInt().SetOne()) {
+		t.Errorf("CompareAndSwap failed with right old value")
+	}
+	if v := a.Load(); !v.IsOne() {
+		t.Errorf("got %v, exp 1", &v)
+	}
+}
+
+func TestAtomicConcurrentAdd(t *testing.T) {
+	const (
+		goroutines = 8
+		adds       = 1000
+	)
+	var (
+		a     Atomic
+		wg    sync.WaitGroup
+		delta = &Int{1 << 63, 1 << 63} // Carries across limbs.
+	)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < adds; i++ {
+				a.Add(delta)
+			}
+		}()
+	}
+	wg.Wait()
+	exp := new(Int).Mul(delta, NewInt().SetUint64(goroutines*adds))
+	if v := a.Load(); !v.Eq(exp) {
+		t.Errorf("got %v, exp %v", &v, exp)
+	}
+}
+
+// TestAtomicNoTearing checks that loads never see a mix of two stores.
+func TestAtomicNoTearing(t *testing.T) {
+	var (
+		a    Atomic
+		wg   sync.WaitGroup
+		done = make(chan struct{})
+	)
+	for w := uint64(1); w <= 2; w++ {
+		wg.Add(1)
+		go func(w uint64) {
+			defer wg.Done()
+			for i := uint64(0); ; i++ {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				v := i*2 + w
+				a.Store(&Int{v, v, v, v})
+			}
+		}(w)
+	}
+	for i := 0; i < 100000; i++ {
+		v := a.Load()
+		if v[0] != v[1] || v[1] != v[2] || v[2] != v[3] {
+			t.Fatalf("torn load: %v", v)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkAtomicLoad(b *testing.B) {
+	a := new(Atomic)
+	a.Store(new(Int).SetAllOne())
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = a.Load()
+		}
+	})
+}
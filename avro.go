@@ -0,0 +1,89 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// AvroFixedSchema is the Avro schema of the fixed(32) encoding of an Int:
+	// the raw 32-byte big-endian value.
+	AvroFixedSchema = `{"type":"fixed","name":"uint256","size":32}`
+
+	// AvroDecimalSchema is the Avro schema of the decimal encoding of an Int:
+	// bytes holding the big-endian two's complement unscaled value, with
+	// enough precision for any 256-bit value.
+	AvroDecimalSchema = `{"type":"bytes","logicalType":"decimal","precision":78,"scale":0}`
+)
+
+var (
+	errAvroFixed    = errors.New("uint256: avro fixed value must be 32 bytes")
+	errAvroBytes    = errors.New("uint256: invalid avro bytes value")
+	errAvroNegative = errors.New("uint256: negative avro decimal")
+)
+
+// AppendAvroFixed appends the Avro binary encoding of z as a fixed(32) value,
+// per AvroFixedSchema, to dst.
+func (z *Int) AppendAvroFixed(dst []byte) []byte {
+	b := z.Bytes32()
+	return append(dst, b[:]...)
+}
+
+// SetFromAvroFixed sets z from a fixed(32) value, per AvroFixedSchema.
+func (z *Int) SetFromAvroFixed(b []byte) error {
+	if z == nil {
+		panic(nilReceiver("SetFromAvroFixed"))
+	}
+	if len(b) != 32 {
+		return errAvroFixed
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// AppendAvroDecimal appends the Avro binary encoding of z as a decimal value,
+// per AvroDecimalSchema, to dst: the zigzag varint length followed by the
+// minimal big-endian two's complement form of z, which is one byte longer
+// than z.Bytes() if the top bit is set.
+func (z *Int) AppendAvroDecimal(dst []byte) []byte {
+	b := z.Bytes32()
+	payload := b[32-z.ByteLen():]
+	if len(payload) == 0 || payload[0]&0x80 != 0 {
+		// Zero is a single 0 byte, and a leading sign byte keeps the value
+		// positive.
+		payload = append([]byte{0}, payload...)
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	dst = append(dst, lenBuf[:binary.PutVarint(lenBuf[:], int64(len(payload)))]...)
+	return append(dst, payload...)
+}
+
+// SetFromAvroDecimal decodes an Avro decimal value, per AvroDecimalSchema,
+// from the start of buf, sets z to it, and returns the number of bytes
+// consumed. Negative values are rejected.
+func (z *Int) SetFromAvroDecimal(buf []byte) (int, error) {
+	if z == nil {
+		panic(nilReceiver("SetFromAvroDecimal"))
+	}
+	length, n := binary.Varint(buf)
+	if n <= 0 || length < 0 || length > int64(len(buf)-n) {
+		return 0, errAvroBytes
+	}
+	payload := buf[n : n+int(length)]
+	if len(payload) > 0 && payload[0]&0x80 != 0 {
+		return 0, errAvroNegative
+	}
+	for len(payload) > 0 && payload[0] == 0 {
+		payload = payload[1:]
+	}
+	if len(payload) > 32 {
+		return 0, errAvroBytes
+	}
+	z.SetBytes(payload)
+	return n + int(length), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAvroDecimalVectors(t *testing.T) {
+	for _, tc := range []struct {
+		x   *Int
+		exp []byte
+	}{
+		{new(Int), []byte{0x02, 0x00}},
+		{new(Int).SetUint64(1), []byte{0x02, 0x01}},
+		{new(Int).SetUint64(0x7f), []byte{0x02, 0x7f}},
+		{new(Int).SetUint64(0x80), []byte{0x04, 0x00, 0x80}},
+		{new(Int).SetUint64(0x1234), []byte{0x04, 0x12, 0x34}},
+		{new(Int).Lsh(&Int{1}, 255), append([]byte{0x42, 0x00, 0x80}, make([]byte, 31)...)},
+	} {
+		got := tc.x.AppendAvroDecimal(nil)
+		if !bytes.Equal(got, tc.exp) {
+			t.Errorf("%v: got %x, exp %x", tc.x.Hex(), got, tc.exp)
+		}
+	}
+}
+
+func TestAvroRoundTrip(t *testing.T) {
+	var stream []byte
+	var values []*Int
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		values = append(values, x)
+		stream = x.AppendAvroDecimal(stream)
+		stream = x.AppendAvroFixed(stream)
+	}
+	for _, x := range values {
+		var z Int
+		n, err := z.SetFromAvroDecimal(stream)
+		if err != nil || !z.Eq(x) {
+			t.Fatalf("decimal %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+		stream = stream[n:]
+		if err := z.SetFromAvroFixed(stream[:32]); err != nil || !z.Eq(x) {
+			t.Fatalf("fixed %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+		stream = stream[32:]
+	}
+	if len(stream) != 0 {
+		t.Fatalf("%d bytes left over", len(stream))
+	}
+}
+
+func TestAvroErrors(t *testing.T) {
+	var z Int
+	for _, b := range [][]byte{nil, {0x01}, {0x04, 0x01}, append([]byte{0x44, 0x01}, make([]byte, 33)...)} {
+		if _, err := z.SetFromAvroDecimal(b); err != errAvroBytes {
+			t.Errorf("%x: expected errAvroBytes, got %v", b, err)
+		}
+	}
+	if _, err := z.SetFromAvroDecimal([]byte{0x02, 0xff}); err != errAvroNegative {
+		t.Errorf("expected errAvroNegative, got %v", err)
+	}
+	if err := z.SetFromAvroFixed(make([]byte, 16)); err != errAvroFixed {
+		t.Errorf("expected errAvroFixed, got %v", err)
+	}
+}
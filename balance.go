@@ -0,0 +1,180 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	errBalanceOverflow     = errors.New("uint256: balance overflows 256 bits")
+	errInsufficientBalance = errors.New("uint256: insufficient balance")
+)
+
+// balanceChange is the state of a key before a change to it.
+type balanceChange struct {
+	key     string
+	old     Int
+	existed bool
+}
+
+// BalanceJournal maps keys, e.g. account addresses as string(addr[:]), to
+// balances, with nested checkpoints: changes since the latest checkpoint can
+// be rolled back, or committed into the enclosing one, in time proportional
+// to their number. The zero value is an empty BalanceJournal.
+type BalanceJournal struct {
+	balances    map[string]*Int
+	changes     []balanceChange
+	checkpoints []int // Lengths of changes when each checkpoint was taken.
+	taken       int   // Checkpoints ever taken, the version of the latest.
+}
+
+// record saves the state of key before a change, if a checkpoint is open.
+func (b *BalanceJournal) record(key string) {
+	if len(b.checkpoints) == 0 {
+		return
+	}
+	c := balanceChange{key: key}
+	if v, ok := b.balances[key]; ok {
+		c.old, c.existed = *v, true
+	}
+	b.changes = append(b.changes, c)
+}
+
+// put sets the balance of key to v.
+func (b *BalanceJournal) put(key string, v *Int) {
+	if b.balances == nil {
+		b.balances = make(map[string]*Int)
+	}
+	if p, ok := b.balances[key]; ok {
+		p.Copy(v)
+	} else {
+		b.balances[key] = v.Clone()
+	}
+}
+
+// Get returns the balance of key, and whether it is present.
+func (b *BalanceJournal) Get(key string) (Int, bool) {
+	if v, ok := b.balances[key]; ok {
+		return *v, true
+	}
+	return Int{}, false
+}
+
+// Set sets the balance of key to v.
+func (b *BalanceJournal) Set(key string, v *Int) {
+	b.record(key)
+	b.put(key, v)
+}
+
+// Add adds v to the balance of key, which is zero if absent. If the sum
+// overflows, it returns an error and leaves the balance unchanged.
+func (b *BalanceJournal) Add(key string, v *Int) error {
+	old, _ := b.Get(key)
+	var sum Int
+	if sum.AddOverflow(&old, v) {
+		return errBalanceOverflow
+	}
+	b.Set(key, &sum)
+	return nil
+}
+
+// Sub subtracts v from the balance of key, which is zero if absent. If the
+// balance is less than v, it returns an error and leaves it unchanged.
+func (b *BalanceJournal) Sub(key string, v *Int) error {
+	old, _ := b.Get(key)
+	var diff Int
+	if diff.SubOverflow(&old, v) {
+		return errInsufficientBalance
+	}
+	b.Set(key, &diff)
+	return nil
+}
+
+// Delete removes key.
+func (b *BalanceJournal) Delete(key string) {
+	if _, ok := b.balances[key]; !ok {
+		return
+	}
+	b.record(key)
+	delete(b.balances, key)
+}
+
+// Len returns the number of keys present.
+func (b *BalanceJournal) Len() int {
+	return len(b.balances)
+}
+
+// Checkpoint opens a checkpoint, and returns its version, which increases
+// with every checkpoint taken and is never reused, even after a rollback.
+// Depth gives the number of open checkpoints instead.
+func (b *BalanceJournal) Checkpoint() int {
+	b.checkpoints = append(b.checkpoints, len(b.changes))
+	b.taken++
+	return b.taken
+}
+
+// Depth returns the number of open checkpoints.
+func (b *BalanceJournal) Depth() int {
+	return len(b.checkpoints)
+}
+
+// Rollback reverts all changes since the latest checkpoint, and closes it.
+// It panics if there is no open checkpoint.
+func (b *BalanceJournal) Rollback() {
+	n := len(b.checkpoints)
+	if n == 0 {
+		panic("uint256: rollback without checkpoint")
+	}
+	start := b.checkpoints[n-1]
+	for i := len(b.changes) - 1; i >= start; i-- {
+		c := &b.changes[i]
+		if c.existed {
+			b.put(c.key, &c.old)
+		} else {
+			delete(b.balances, c.key)
+		}
+	}
+	b.changes = b.changes[:start]
+	b.checkpoints = b.checkpoints[:n-1]
+}
+
+// Commit closes the latest checkpoint, keeping its changes, which a rollback
+// of the enclosing checkpoint still reverts. It panics if there is no open
+// checkpoint.
+func (b *BalanceJournal) Commit() {
+	n := len(b.checkpoints)
+	if n == 0 {
+		panic("uint256: commit without checkpoint")
+	}
+	b.checkpoints = b.checkpoints[:n-1]
+	if n == 1 {
+		// Nothing can be rolled back any more.
+		b.changes = b.changes[:0]
+	}
+}
+
+// Keys returns the keys present, in ascending order.
+func (b *BalanceJournal) Keys() []string {
+	keys := make([]string, 0, len(b.balances))
+	for k := range b.balances {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Range calls f for each key present and its balance, in ascending order of
+// keys, until f returns false. f must not modify the BalanceJournal.
+func (b *BalanceJournal) Range(f func(key string, balance *Int) bool) {
+	for _, k := range b.Keys() {
+		v := *b.balances[k]
+		if !f(k, &v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// balances returns the contents of b, in Range order.
+func balances(b *BalanceJournal) map[string]uint64 {
+	m := make(map[string]uint64)
+	b.Range(func(key string, balance *Int) bool {
+		m[key] = balance.Uint64()
+		return true
+	})
+	return m
+}
+
+func TestBalanceJournal(t *testing.T) {
+	var b BalanceJournal
+	b.Set("alice", NewInt().SetUint64(100))
+	b.Set("bob", NewInt().SetUint64(50))
+
+	if v := b.Checkpoint(); v != 1 {
+		t.Fatalf("Checkpoint: have %d, want 1", v)
+	}
+	if err := b.Sub("alice", NewInt().SetUint64(30)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Add("carol", NewInt().SetUint64(30)); err != nil {
+		t.Fatal(err)
+	}
+
+	b.Checkpoint()
+	b.Delete("bob")
+	b.Set("alice", new(Int))
+	if have, want := balances(&b), map[string]uint64{"alice": 0, "carol": 30}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+	b.Rollback()
+	want := map[string]uint64{"alice": 70, "bob": 50, "carol": 30}
+	if have := balances(&b); !reflect.DeepEqual(have, want) {
+		t.Errorf("after inner rollback: have %v, want %v", have, want)
+	}
+
+	// A committed inner checkpoint is still reverted by the outer rollback.
+	// Its version is not that of the rolled back one.
+	if v := b.Checkpoint(); v != 3 {
+		t.Fatalf("Checkpoint after rollback: have %d, want 3", v)
+	}
+	b.Set("dave", NewInt().SetOne())
+	b.Commit()
+	if have := b.Depth(); have != 1 {
+		t.Errorf("Depth: have %d, want 1", have)
+	}
+	b.Rollback()
+	want = map[string]uint64{"alice": 100, "bob": 50}
+	if have := balances(&b); !reflect.DeepEqual(have, want) {
+		t.Errorf("after outer rollback: have %v, want %v", have, want)
+	}
+
+	if have, want := b.Keys(), []string{"alice", "bob"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("Keys: have %v, want %v", have, want)
+	}
+	if _, ok := b.Get("carol"); ok {
+		t.Errorf("carol present after rollback")
+	}
+}
+
+func TestBalanceJournalErrors(t *testing.T) {
+	var b BalanceJournal
+	b.Set("a", new(Int).SetAllOne())
+	if err := b.Add("a", NewInt().SetOne()); !errors.Is(err, errBalanceOverflow) {
+		t.Errorf("Add: have %v, want %v", err, errBalanceOverflow)
+	}
+	if err := b.Sub("b", NewInt().SetOne()); !errors.Is(err, errInsufficientBalance) {
+		t.Errorf("Sub: have %v, want %v", err, errInsufficientBalance)
+	}
+	if v, _ := b.Get("a"); !v.Eq(new(Int).SetAllOne()) {
+		t.Errorf("balance changed by failed Add: %v", &v)
+	}
+	if _, ok := b.Get("b"); ok {
+		t.Errorf("failed Sub created key")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Rollback without checkpoint: no panic")
+		}
+	}()
+	b.Rollback()
+}
+
+func TestBalanceJournalRangeOrder(t *testing.T) {
+	var b BalanceJournal
+	for _, k := range []string{"d", "b", "a", "c"} {
+		b.Set(k, new(Int))
+	}
+	var keys []string
+	b.Range(func(key string, _ *Int) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("have %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	errInvalidBase58   = errors.New("uint256: invalid base58 character")
+	errBase58Overflow  = errors.New("uint256: base58 value too large")
+	errBase58Checksum  = errors.New("uint256: invalid base58 checksum")
+	errBase58CheckSize = errors.New("uint256: invalid base58check payload size")
+
+	base58Index = func() [256]int8 {
+		var idx [256]int8
+		for i := range idx {
+			idx[i] = -1
+		}
+		for i := 0; i < len(base58Alphabet); i++ {
+			idx[base58Alphabet[i]] = int8(i)
+		}
+		return idx
+	}()
+)
+
+// base58Encode encodes b in base58, representing each leading zero byte as '1'.
+func base58Encode(b []byte) string {
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	// log(256) / log(58) < 1.37, so this is an upper bound on the length.
+	digits := make([]byte, 0, (len(b)-zeros)*137/100+1)
+	for _, c := range b[zeros:] {
+		carry := uint32(c)
+		for i := range digits {
+			carry += uint32(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = base58Alphabet[0]
+	}
+	for i, d := range digits {
+		out[len(out)-1-i] = base58Alphabet[d]
+	}
+	return string(out)
+}
+
+// base58Decode decodes s, returning a *ParseError with the expected format if
+// the result would be longer than maxLen bytes.
+func base58Decode(s string, maxLen int, expected string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	// Little-endian accumulator of the numeric value.
+	acc := make([]byte, 0, maxLen)
+	for i := zeros; i < len(s); i++ {
+		v := base58Index[s[i]]
+		if v < 0 {
+			return nil, newParseError(s, i, expected, errInvalidBase58)
+		}
+		carry := uint32(v)
+		for j := range acc {
+			carry += uint32(acc[j]) * 58
+			acc[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			acc = append(acc, byte(carry))
+			carry >>= 8
+		}
+		if zeros+len(acc) > maxLen {
+			return nil, newParseError(s, -1, expected, errBase58Overflow)
+		}
+	}
+	if zeros > maxLen {
+		return nil, newParseError(s, -1, expected, errBase58Overflow)
+	}
+	out := make([]byte, zeros+len(acc))
+	for i, c := range acc {
+		out[len(out)-1-i] = c
+	}
+	return out, nil
+}
+
+// base58Checksum returns the first four bytes of sha256(sha256(b)).
+func base58Checksum(b []byte) [4]byte {
+	h := sha256.Sum256(b)
+	h = sha256.Sum256(h[:])
+	return [4]byte{h[0], h[1], h[2], h[3]}
+}
+
+// ToBase58 returns the base58 encoding of the 32-byte big-endian form of z,
+// as used for rendering hashes and keys. Leading zero bytes are encoded as
+// '1' characters, so the encoding of 0 is 32 '1's.
+func (z *Int) ToBase58() string {
+	b := z.Bytes32()
+	return base58Encode(b[:])
+}
+
+// SetFromBase58 sets z to the value of the base58 string s. The decoded data
+// (including any leading zero bytes) must not exceed 32 bytes.
+func (z *Int) SetFromBase58(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromBase58"))
+	}
+	b, err := base58Decode(s, 32, expectBase58)
+	if err != nil {
+		return err
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// FromBase58 is a convenience-constructor for SetFromBase58.
+func FromBase58(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromBase58(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// ToBase58Check returns the Base58Check encoding of the 32-byte big-endian
+// form of z, prefixed by the given version byte and suffixed with a four-byte
+// double-sha256 checksum.
+func (z *Int) ToBase58Check(version byte) string {
+	var buf [1 + 32 + 4]byte
+	buf[0] = version
+	b := z.Bytes32()
+	copy(buf[1:33], b[:])
+	sum := base58Checksum(buf[:33])
+	copy(buf[33:], sum[:])
+	return base58Encode(buf[:])
+}
+
+// SetFromBase58Check sets z to the payload of the Base58Check string s, and
+// returns the version byte. The payload must be at most 32 bytes long.
+func (z *Int) SetFromBase58Check(s string) (byte, error) {
+	if z == nil {
+		panic(nilReceiver("SetFromBase58Check"))
+	}
+	b, err := base58Decode(s, 1+32+4, expectCheck)
+	if err != nil {
+		return 0, err
+	}
+	if len(b) < 1+4 {
+		return 0, newParseError(s, -1, expectCheck, errBase58CheckSize)
+	}
+	data, sum := b[:len(b)-4], b[len(b)-4:]
+	if exp := base58Checksum(data); !bytes.Equal(sum, exp[:]) {
+		return 0, newParseError(s, -1, expectCheck, errBase58Checksum)
+	}
+	z.SetBytes(data[1:])
+	return data[0], nil
+}
+
+// FromBase58Check is a convenience-constructor for SetFromBase58Check.
+func FromBase58Check(s string) (*Int, byte, error) {
+	z := new(Int)
+	version, err := z.SetFromBase58Check(s)
+	if err != nil {
+		return nil, 0, err
+	}
+	return z, version, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBase58Vectors(t *testing.T) {
+	// Test vectors from Bitcoin Core's base58_encode_decode.json.
+	var tests = []struct {
+		hex string
+		enc string
+	}{
+		{"", ""},
+		{"61", "2g"},
+		{"626262", "a3gV"},
+		{"636363", "aPEr"},
+		{"73696d706c792061206c6f6e6720737472696e67", "2cFupjhnEsSn59qHXstmK2ffpLv2"},
+		{"00eb15231dfceb60925886b67d065299925915aeb172c06647", "1NS17iag9jJgTHD1VXjvLCEnZuQ3rJDE9L"},
+		{"516b6fcd0f", "ABnLTmg"},
+		{"bf4f89001e670274dd", "3SEo3LWLoPntC"},
+		{"572e4794", "3EFU7m"},
+		{"ecac89cad93923c02321", "EJDM8drfXA6uyA"},
+		{"10c8511e", "Rt5zm"},
+		{"00000000000000000000", "1111111111"},
+	}
+	for _, tt := range tests {
+		if got := base58Encode(hex2Bytes(tt.hex)); got != tt.enc {
+			t.Errorf("encode %s: got %s exp %s", tt.hex, got, tt.enc)
+		}
+		got, err := base58Decode(tt.enc, 32, expectBase58)
+		if err != nil || !bytes.Equal(got, hex2Bytes(tt.hex)) {
+			t.Errorf("decode %s: got %x (err=%v) exp %s", tt.enc, got, err, tt.hex)
+		}
+	}
+}
+
+func TestBase58(t *testing.T) {
+	if got, exp := new(Int).ToBase58(), strings.Repeat("1", 32); got != exp {
+		t.Errorf("got %s exp %s", got, exp)
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		z, err := FromBase58(x.ToBase58())
+		if err != nil || !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v (err=%v)", x.Hex(), z, err)
+		}
+	}
+	for _, s := range []string{"0", "1O", "abcl", strings.Repeat("1", 33), strings.Repeat("z", 44)} {
+		if _, err := FromBase58(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+}
+
+func TestBase58Check(t *testing.T) {
+	// Uncompressed WIF encoding of a private key.
+	wif := "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"
+	key := new(Int).SetBytes(hex2Bytes("0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"))
+	if got := key.ToBase58Check(0x80); got != wif {
+		t.Errorf("got %s exp %s", got, wif)
+	}
+	z, version, err := FromBase58Check(wif)
+	if err != nil || version != 0x80 || !z.Eq(key) {
+		t.Errorf("got %v version %x (err=%v)", z, version, err)
+	}
+	// Corrupt the checksum.
+	if _, _, err := FromBase58Check(wif[:len(wif)-1] + "K"); !errors.Is(err, errBase58Checksum) {
+		t.Errorf("expected checksum error, got %v", err)
+	}
+	if _, _, err := FromBase58Check("1111"); !errors.Is(err, errBase58CheckSize) {
+		t.Errorf("expected size error, got %v", err)
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		z, version, err := FromBase58Check(x.ToBase58Check(byte(i)))
+		if err != nil || version != byte(i) || !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v version %d (err=%v)", x.Hex(), z, version, err)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"errors"
+)
+
+var (
+	errEncodingTooLong = errors.New("uint256: encoded value exceeds 32 bytes")
+	errInvalidBase64   = errors.New("uint256: invalid base64 data")
+	errInvalidBase32   = errors.New("uint256: invalid base32 data")
+)
+
+// ToBase64 returns the standard (RFC 4648, padded) base64 encoding of the
+// 32-byte big-endian form of z.
+func (z *Int) ToBase64() string {
+	b := z.Bytes32()
+	return base64.StdEncoding.EncodeToString(b[:])
+}
+
+// SetFromBase64 sets z to the big-endian value of the standard base64
+// encoded string s, which must decode to at most 32 bytes.
+func (z *Int) SetFromBase64(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromBase64"))
+	}
+	if len(s) > base64.StdEncoding.EncodedLen(32) {
+		return newParseError(s, -1, expectBase64, errEncodingTooLong)
+	}
+	var buf [33]byte
+	n, err := base64.StdEncoding.Decode(buf[:], []byte(s))
+	if offset, ok := err.(base64.CorruptInputError); ok {
+		return newParseError(s, int(offset), expectBase64, errInvalidBase64)
+	}
+	if n > 32 {
+		return newParseError(s, -1, expectBase64, errEncodingTooLong)
+	}
+	z.SetBytes(buf[:n])
+	return nil
+}
+
+// FromBase64 is a convenience-constructor for SetFromBase64.
+func FromBase64(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromBase64(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// ToBase32 returns the standard (RFC 4648, padded) base32 encoding of the
+// 32-byte big-endian form of z.
+func (z *Int) ToBase32() string {
+	b := z.Bytes32()
+	return base32.StdEncoding.EncodeToString(b[:])
+}
+
+// SetFromBase32 sets z to the big-endian value of the standard base32
+// encoded string s, which must decode to at most 32 bytes.
+func (z *Int) SetFromBase32(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromBase32"))
+	}
+	if len(s) > base32.StdEncoding.EncodedLen(32) {
+		return newParseError(s, -1, expectBase32, errEncodingTooLong)
+	}
+	var buf [35]byte
+	n, err := base32.StdEncoding.Decode(buf[:], []byte(s))
+	if offset, ok := err.(base32.CorruptInputError); ok {
+		return newParseError(s, int(offset), expectBase32, errInvalidBase32)
+	}
+	if n > 32 {
+		return newParseError(s, -1, expectBase32, errEncodingTooLong)
+	}
+	z.SetBytes(buf[:n])
+	return nil
+}
+
+// FromBase32 is a convenience-constructor for SetFromBase32.
+func FromBase32(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromBase32(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestBase64(t *testing.T) {
+	x := new(Int).SetUint64(0xff)
+	if got, exp := x.ToBase64(), "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAP8="; got != exp {
+		t.Errorf("got %s exp %s", got, exp)
+	}
+	if got, exp := x.ToBase32(), "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAD7Q===="; got != exp {
+		t.Errorf("got %s exp %s", got, exp)
+	}
+	// Shorter encodings are accepted as big-endian values.
+	if z, err := FromBase64("/w=="); err != nil || !z.Eq(x) {
+		t.Errorf("got %v (err=%v)", z, err)
+	}
+	if z, err := FromBase32("74======"); err != nil || !z.Eq(x) {
+		t.Errorf("got %v (err=%v)", z, err)
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		if z, err := FromBase64(x.ToBase64()); err != nil || !z.Eq(x) {
+			t.Fatalf("base64 round trip %v: got %v (err=%v)", x.Hex(), z, err)
+		}
+		if z, err := FromBase32(x.ToBase32()); err != nil || !z.Eq(x) {
+			t.Fatalf("base32 round trip %v: got %v (err=%v)", x.Hex(), z, err)
+		}
+	}
+	for _, s := range []string{"!!!!", "AAAA", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="} {
+		if _, err := FromBase64(s + "A"); err == nil {
+			t.Errorf("expected error for %q", s+"A")
+		}
+	}
+	if _, err := FromBase32("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"); err == nil {
+		t.Errorf("expected error for oversized base32")
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var errBatchLength = errors.New("uint256: batch slices differ in length")
+
+// BatchProcessor performs element-wise operations over slices of Ints, i.e.
+// dst[i] = op(x[i], y[i]) for every i, with a shared modulus m. The slices
+// must have the same length, and dst may be x or y. As with ExpMod, a zero
+// modulus gives zero results. Implementations backed by GPUs or other
+// accelerators can be registered with SetBatchProcessor, and are then used by
+// BatchMul, BatchAddMod, BatchMulMod and BatchExpMod.
+type BatchProcessor interface {
+	Mul(dst, x, y []Int) error
+	AddMod(dst, x, y []Int, m *Int) error
+	MulMod(dst, x, y []Int, m *Int) error
+	ExpMod(dst, base, exponent []Int, m *Int) error
+}
+
+// CPUBatch is the pure Go BatchProcessor, which is used unless another one is
+// registered. Batches of at least MinParallel elements are split across up
+// to Parallelism goroutines; a Parallelism of zero means GOMAXPROCS.
+type CPUBatch struct {
+	Parallelism int
+	MinParallel int
+}
+
+// defaultMinParallel is the batch size below which CPUBatch does not spawn
+// goroutines, if MinParallel is zero.
+const defaultMinParallel = 4096
+
+// run calls f on consecutive chunks of the indices [0, n).
+func (c CPUBatch) run(n int, f func(lo, hi int)) {
+	workers, min := c.Parallelism, c.MinParallel
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if min <= 0 {
+		min = defaultMinParallel
+	}
+	if workers == 1 || n < min {
+		f(0, n)
+		return
+	}
+	chunk := (n + workers - 1) / workers
+	var wg sync.WaitGroup
+	for lo := 0; lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			f(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
+
+// Mul sets dst[i] = x[i] * y[i] mod 2**256.
+func (c CPUBatch) Mul(dst, x, y []Int) error {
+	if len(x) != len(dst) || len(y) != len(dst) {
+		return errBatchLength
+	}
+	c.run(len(dst), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i].Mul(&x[i], &y[i])
+		}
+	})
+	return nil
+}
+
+// AddMod sets dst[i] = (x[i] + y[i]) mod m.
+func (c CPUBatch) AddMod(dst, x, y []Int, m *Int) error {
+	if len(x) != len(dst) || len(y) != len(dst) {
+		return errBatchLength
+	}
+	if m.IsZero() {
+		clearInts(dst)
+		return nil
+	}
+	mod := *m // m may be an element of dst
+	c.run(len(dst), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i].AddMod(&x[i], &y[i], &mod)
+		}
+	})
+	return nil
+}
+
+// MulMod sets dst[i] = x[i] * y[i] mod m.
+func (c CPUBatch) MulMod(dst, x, y []Int, m *Int) error {
+	if len(x) != len(dst) || len(y) != len(dst) {
+		return errBatchLength
+	}
+	if m.IsZero() {
+		clearInts(dst)
+		return nil
+	}
+	mod := *m
+	c.run(len(dst), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i].MulMod(&x[i], &y[i], &mod)
+		}
+	})
+	return nil
+}
+
+// ExpMod sets dst[i] = base[i]**exponent[i] mod m.
+func (c CPUBatch) ExpMod(dst, base, exponent []Int, m *Int) error {
+	if len(base) != len(dst) || len(exponent) != len(dst) {
+		return errBatchLength
+	}
+	mod := *m
+	c.run(len(dst), func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			dst[i].ExpMod(&base[i], &exponent[i], &mod)
+		}
+	})
+	return nil
+}
+
+// clearInts sets every element of z to zero.
+func clearInts(z []Int) {
+	for i := range z {
+		z[i].Clear()
+	}
+}
+
+// batchBox wraps the registered BatchProcessor, since an atomic.Value cannot
+// hold nil or values of different types.
+type batchBox struct{ p BatchProcessor }
+
+var registeredBatch atomic.Value
+
+// SetBatchProcessor registers p to be used by the Batch functions, or
+// restores CPUBatch if p is nil, and returns the previously registered
+// BatchProcessor, or nil for CPUBatch. It is safe for concurrent use.
+func SetBatchProcessor(p BatchProcessor) BatchProcessor {
+	old, _ := registeredBatch.Load().(batchBox)
+	registeredBatch.Store(batchBox{p})
+	return old.p
+}
+
+// batchProcessor returns the registered BatchProcessor, or CPUBatch.
+func batchProcessor() BatchProcessor {
+	if box, _ := registeredBatch.Load().(batchBox); box.p != nil {
+		return box.p
+	}
+	return CPUBatch{}
+}
+
+// BatchMul sets dst[i] = x[i] * y[i] mod 2**256, using the registered
+// BatchProcessor.
+func BatchMul(dst, x, y []Int) error {
+	return batchProcessor().Mul(dst, x, y)
+}
+
+// BatchAddMod sets dst[i] = (x[i] + y[i]) mod m, using the registered
+// BatchProcessor.
+func BatchAddMod(dst, x, y []Int, m *Int) error {
+	return batchProcessor().AddMod(dst, x, y, m)
+}
+
+// BatchMulMod sets dst[i] = x[i] * y[i] mod m, using the registered
+// BatchProcessor.
+func BatchMulMod(dst, x, y []Int, m *Int) error {
+	return batchProcessor().MulMod(dst, x, y, m)
+}
+
+// BatchExpMod sets dst[i] = base[i]**exponent[i] mod m, using the registered
+// BatchProcessor.
+func BatchExpMod(dst, base, exponent []Int, m *Int) error {
+	return batchProcessor().ExpMod(dst, base, exponent, m)
+}
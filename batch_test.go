@@ -0,0 +1,144 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"testing"
+)
+
+func randSlice(n int) []Int {
+	s := make([]Int, n)
+	for i := range s {
+		_, x, _ := randNums()
+		s[i] = *x
+	}
+	return s
+}
+
+func TestCPUBatch(t *testing.T) {
+	const n = 1000
+	x, y, e := randSlice(n), randSlice(n), randSlice(n)
+	m := new(Int).SetBytes(hex2Bytes("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"))
+	for _, c := range []CPUBatch{{}, {Parallelism: 1}, {Parallelism: 3, MinParallel: 10}} {
+		dst := make([]Int, n)
+		if err := c.Mul(dst, x, y); err != nil {
+			t.Fatal(err)
+		}
+		for i := range dst {
+			if exp := new(Int).Mul(&x[i], &y[i]); !dst[i].Eq(exp) {
+				t.Fatalf("%+v Mul %d: got %v, exp %v", c, i, &dst[i], exp)
+			}
+		}
+		if err := c.AddMod(dst, x, y, m); err != nil {
+			t.Fatal(err)
+		}
+		for i := range dst {
+			if exp := new(Int).AddMod(&x[i], &y[i], m); !dst[i].Eq(exp) {
+				t.Fatalf("%+v AddMod %d: got %v, exp %v", c, i, &dst[i], exp)
+			}
+		}
+		if err := c.MulMod(dst, x, y, m); err != nil {
+			t.Fatal(err)
+		}
+		for i := range dst {
+			if exp := new(Int).MulMod(&x[i], &y[i], m); !dst[i].Eq(exp) {
+				t.Fatalf("%+v MulMod %d: got %v, exp %v", c, i, &dst[i], exp)
+			}
+		}
+		if err := c.ExpMod(dst[:10], x[:10], e[:10], m); err != nil {
+			t.Fatal(err)
+		}
+		for i := range dst[:10] {
+			if exp := new(Int).ExpMod(&x[i], &e[i], m); !dst[i].Eq(exp) {
+				t.Fatalf("%+v ExpMod %d: got %v, exp %v", c, i, &dst[i], exp)
+			}
+		}
+	}
+}
+
+func TestCPUBatchEdgeCases(t *testing.T) {
+	var c CPUBatch
+	x, y := randSlice(4), randSlice(4)
+	if err := c.MulMod(make([]Int, 3), x, y, NewInt().SetOne()); err != errBatchLength {
+		t.Errorf("got err %v, exp %v", err, errBatchLength)
+	}
+	dst := randSlice(4)
+	if err := c.MulMod(dst, x, y, new(Int)); err != nil {
+		t.Fatal(err)
+	}
+	for i := range dst {
+		if !dst[i].IsZero() {
+			t.Errorf("zero modulus: got %v, exp 0", &dst[i])
+		}
+	}
+	// dst aliasing x, and m aliasing an element of dst.
+	dst = append([]Int(nil), x...)
+	m := &dst[0]
+	exp := make([]Int, len(x))
+	for i := range exp {
+		exp[i].AddMod(&x[i], &y[i], &x[0])
+	}
+	if err := c.AddMod(dst, dst, y, m); err != nil {
+		t.Fatal(err)
+	}
+	for i := range dst {
+		if !dst[i].Eq(&exp[i]) {
+			t.Errorf("aliased %d: got %v, exp %v", i, &dst[i], &exp[i])
+		}
+	}
+}
+
+// countingBatch is a BatchProcessor which records calls and delegates to
+// CPUBatch, standing in for an accelerator backend.
+type countingBatch struct {
+	CPUBatch
+	calls int
+}
+
+func (c *countingBatch) MulMod(dst, x, y []Int, m *Int) error {
+	c.calls++
+	return c.CPUBatch.MulMod(dst, x, y, m)
+}
+
+func TestSetBatchProcessor(t *testing.T) {
+	p := new(countingBatch)
+	if old := SetBatchProcessor(p); old != nil {
+		t.Fatalf("got previous %v, exp nil", old)
+	}
+	defer SetBatchProcessor(nil)
+	x, y := randSlice(8), randSlice(8)
+	dst := make([]Int, 8)
+	m := NewInt().SetUint64(1000003)
+	if err := BatchMulMod(dst, x, y, m); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 1 {
+		t.Errorf("got %d calls, exp 1", p.calls)
+	}
+	if old := SetBatchProcessor(nil); old != p {
+		t.Errorf("got previous %v, exp %v", old, p)
+	}
+	if err := BatchMulMod(dst, x, y, m); err != nil || p.calls != 1 {
+		t.Errorf("got %d calls (err %v) after reset, exp 1", p.calls, err)
+	}
+}
+
+func BenchmarkBatchMulMod(b *testing.B) {
+	x, y := randSlice(1<<14), randSlice(1<<14)
+	dst := make([]Int, len(x))
+	m := new(Int).SetBytes(hex2Bytes("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001"))
+	for _, c := range []CPUBatch{{Parallelism: 1}, {}} {
+		name := "serial"
+		if c.Parallelism == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = c.MulMod(dst, x, y, m)
+			}
+		})
+	}
+}
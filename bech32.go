@@ -0,0 +1,267 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+const (
+	bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	bech32Const  = 1          // BIP-173
+	bech32mConst = 0x2bc830a3 // BIP-350
+
+	bech32MaxLen = 90
+)
+
+var (
+	errBech32Hrp      = errors.New("uint256: invalid bech32 human-readable part")
+	errBech32Format   = errors.New("uint256: invalid bech32 string")
+	errBech32Checksum = errors.New("uint256: invalid bech32 checksum")
+	errBech32Program  = errors.New("uint256: invalid witness program")
+)
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// invalidHrp returns the offset of the first invalid byte of hrp, or -1 if
+// hrp is a valid human-readable part.
+func invalidHrp(hrp string) int {
+	if len(hrp) == 0 {
+		return 0
+	}
+	for i := 0; i < len(hrp); i++ {
+		if i == 83 || hrp[i] < 33 || hrp[i] > 126 || (hrp[i] >= 'A' && hrp[i] <= 'Z') {
+			return i
+		}
+	}
+	return -1
+}
+
+// bech32Encode encodes the 5-bit groups in data using the given checksum
+// constant.
+func bech32Encode(hrp string, data []byte, constant uint32) (string, error) {
+	if invalidHrp(hrp) >= 0 || len(hrp)+1+len(data)+6 > bech32MaxLen {
+		return "", errBech32Hrp
+	}
+	values := append(bech32HrpExpand(hrp), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ constant
+	var sb strings.Builder
+	sb.Grow(len(hrp) + 1 + len(data) + 6)
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(bech32Charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// bech32Decode returns the human-readable part, the 5-bit groups of the data
+// part (without checksum), and the checksum constant the string verifies with.
+// Errors are *ParseErrors with the expected format.
+func bech32Decode(s, expected string) (string, []byte, uint32, error) {
+	if len(s) > bech32MaxLen {
+		return "", nil, 0, newParseError(s, bech32MaxLen, expected, errBech32Format)
+	}
+	// The string is lowered byte by byte, so that offsets in lower are
+	// offsets in s. The first letter not in the case of the others is an
+	// error.
+	var (
+		lower              = []byte(s)
+		hasUpper, hasLower bool
+	)
+	for i, c := range lower {
+		switch {
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+			lower[i] = c + 'a' - 'A'
+		default:
+			continue
+		}
+		if hasUpper && hasLower {
+			return "", nil, 0, newParseError(s, i, expected, errBech32Format)
+		}
+	}
+	sep := bytes.LastIndexByte(lower, '1')
+	switch {
+	case sep < 0:
+		return "", nil, 0, newParseError(s, len(s), expected, errBech32Format)
+	case sep == 0:
+		return "", nil, 0, newParseError(s, 0, expected, errBech32Hrp)
+	case sep+7 > len(lower):
+		return "", nil, 0, newParseError(s, len(s), expected, errBech32Format)
+	}
+	hrp := string(lower[:sep])
+	if i := invalidHrp(hrp); i >= 0 {
+		return "", nil, 0, newParseError(s, i, expected, errBech32Hrp)
+	}
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		d := strings.IndexByte(bech32Charset, lower[i])
+		if d < 0 {
+			return "", nil, 0, newParseError(s, i, expected, errBech32Format)
+		}
+		data = append(data, byte(d))
+	}
+	constant := bech32Polymod(append(bech32HrpExpand(hrp), data...))
+	if constant != bech32Const && constant != bech32mConst {
+		return "", nil, 0, newParseError(s, -1, expected, errBech32Checksum)
+	}
+	return hrp, data[:len(data)-6], constant, nil
+}
+
+// bech32Groups returns the 32-byte big-endian form of z as 52 5-bit groups,
+// zero-padding the final group.
+func (z *Int) bech32Groups() []byte {
+	groups := make([]byte, 52)
+	// 52*5 = 260 bits, so the value is shifted up by 4 bits.
+	for i := range groups {
+		pos := 255 - 5*i
+		if pos >= 4 {
+			groups[i] = byte(z.Window(uint(pos-4), 5))
+		} else {
+			groups[i] = byte(z.Window(0, uint(pos+1)) << uint(4-pos))
+		}
+	}
+	return groups
+}
+
+// setBech32Groups sets z from 52 5-bit groups holding a 32-byte value,
+// and reports whether the padding bits were zero.
+func (z *Int) setBech32Groups(groups []byte) bool {
+	if len(groups) != 52 || groups[51]&0x0f != 0 {
+		return false
+	}
+	z.Clear()
+	for _, g := range groups[:51] {
+		z.Lsh(z, 5)
+		z[0] |= uint64(g)
+	}
+	z.Lsh(z, 1)
+	z[0] |= uint64(groups[51] >> 4)
+	return true
+}
+
+// ToBech32 returns the BIP-173 bech32 encoding of the 32-byte big-endian form
+// of z, using the given human-readable part.
+func (z *Int) ToBech32(hrp string) (string, error) {
+	z = orZero(z)
+	return bech32Encode(hrp, z.bech32Groups(), bech32Const)
+}
+
+// SetFromBech32 sets z to the 32-byte payload of the bech32 string s, and
+// returns the human-readable part.
+func (z *Int) SetFromBech32(s string) (string, error) {
+	if z == nil {
+		panic(nilReceiver("SetFromBech32"))
+	}
+	hrp, data, constant, err := bech32Decode(s, expectBech32)
+	if err != nil {
+		return "", err
+	}
+	if constant != bech32Const {
+		return "", newParseError(s, -1, expectBech32, errBech32Checksum)
+	}
+	if !z.setBech32Groups(data) {
+		return "", newParseError(s, -1, expectBech32, errBech32Program)
+	}
+	return hrp, nil
+}
+
+// FromBech32 is a convenience-constructor for SetFromBech32.
+func FromBech32(s string) (*Int, string, error) {
+	z := new(Int)
+	hrp, err := z.SetFromBech32(s)
+	if err != nil {
+		return nil, "", err
+	}
+	return z, hrp, nil
+}
+
+// ToSegwitAddress returns the segregated witness address for the 32-byte
+// witness program z (e.g. P2WSH for version 0, P2TR for version 1), using
+// bech32 for version 0 and bech32m (BIP-350) for later versions.
+func (z *Int) ToSegwitAddress(hrp string, version byte) (string, error) {
+	z = orZero(z)
+	if version > 16 {
+		return "", errBech32Program
+	}
+	constant := uint32(bech32Const)
+	if version > 0 {
+		constant = bech32mConst
+	}
+	return bech32Encode(hrp, append([]byte{version}, z.bech32Groups()...), constant)
+}
+
+// SetFromSegwitAddress sets z to the 32-byte witness program of the segregated
+// witness address addr, which must use the given human-readable part, and
+// returns the witness version.
+func (z *Int) SetFromSegwitAddress(hrp, addr string) (byte, error) {
+	if z == nil {
+		panic(nilReceiver("SetFromSegwitAddress"))
+	}
+	got, data, constant, err := bech32Decode(addr, expectSegwit)
+	if err != nil {
+		return 0, err
+	}
+	if got != hrp {
+		return 0, newParseError(addr, 0, expectSegwit, errBech32Hrp)
+	}
+	// The witness version follows the separator.
+	if len(data) == 0 || data[0] > 16 {
+		return 0, newParseError(addr, len(got)+1, expectSegwit, errBech32Program)
+	}
+	version := data[0]
+	if (version == 0) != (constant == bech32Const) {
+		return 0, newParseError(addr, -1, expectSegwit, errBech32Checksum)
+	}
+	if !z.setBech32Groups(data[1:]) {
+		return 0, newParseError(addr, -1, expectSegwit, errBech32Program)
+	}
+	return version, nil
+}
+
+// FromSegwitAddress is a convenience-constructor for SetFromSegwitAddress.
+func FromSegwitAddress(hrp, addr string) (*Int, byte, error) {
+	z := new(Int)
+	version, err := z.SetFromSegwitAddress(hrp, addr)
+	if err != nil {
+		return nil, 0, err
+	}
+	return z, version, nil
+}
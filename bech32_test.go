@@ -0,0 +1,96 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBech32Checksums(t *testing.T) {
+	// Valid strings from BIP-173 and BIP-350.
+	for _, s := range []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+		"A1LQFN3A",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+	} {
+		if _, _, _, err := bech32Decode(s, expectBech32); err != nil {
+			t.Errorf("%s: %v", s, err)
+		}
+	}
+	for _, s := range []string{
+		"pzry9x0s0muk",
+		"1pzry9x0s0muk",
+		"x1b4n0q5v",
+		"li1dgmt3",
+		"A1G7SGD8",
+		"10a06t8",
+		"1qzzfhee",
+		"a12UEL5L",
+	} {
+		if _, _, _, err := bech32Decode(s, expectBech32); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
+
+func TestSegwitAddress(t *testing.T) {
+	var tests = []struct {
+		hrp     string
+		addr    string
+		version byte
+		program string
+	}{
+		{"tb", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", 0,
+			"1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262"},
+		{"bc", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", 1,
+			"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for _, tt := range tests {
+		program := new(Int).SetBytes(hex2Bytes(tt.program))
+		addr, err := program.ToSegwitAddress(tt.hrp, tt.version)
+		if err != nil || addr != tt.addr {
+			t.Errorf("got %s (err=%v), exp %s", addr, err, tt.addr)
+		}
+		z, version, err := FromSegwitAddress(tt.hrp, strings.ToUpper(tt.addr))
+		if err != nil || version != tt.version || !z.Eq(program) {
+			t.Errorf("%s: got %v version %d (err=%v)", tt.addr, z, version, err)
+		}
+	}
+	// Version 1 encoded with bech32 instead of bech32m.
+	if _, _, err := FromSegwitAddress("bc", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd"); err == nil {
+		t.Errorf("expected error for wrong checksum variant")
+	}
+	if _, _, err := FromSegwitAddress("tb", tests[1].addr); !errors.Is(err, errBech32Hrp) {
+		t.Errorf("expected hrp error, got %v", err)
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		s, err := x.ToBech32("uint")
+		if err != nil {
+			t.Fatal(err)
+		}
+		z, hrp, err := FromBech32(s)
+		if err != nil || hrp != "uint" || !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v hrp %s (err=%v)", x.Hex(), z, hrp, err)
+		}
+	}
+	if _, err := new(Int).ToBech32(""); err == nil {
+		t.Errorf("expected error for empty hrp")
+	}
+	if _, err := new(Int).ToBech32("UPPER"); err == nil {
+		t.Errorf("expected error for uppercase hrp")
+	}
+}
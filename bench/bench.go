@@ -0,0 +1,231 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package bench provides a standard set of benchmarks comparing uint256.Int
+// with math/big, for Add, Mul, Div, Mod, Exp, AddMod and MulMod over operands
+// of 64, 128, 192 and 256 bits, and a report of the results. The benchmarks
+// can be run with go test -bench, or programmatically with Run:
+//
+//	bench.WriteReport(os.Stdout, bench.Run(bench.Benchmarks()))
+//
+// The inputs are pseudo-random but fixed, so results are comparable across
+// machines and versions.
+package bench
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"math/rand"
+	"runtime"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/holiman/uint256"
+)
+
+// numSamples is the number of operand sets cycled through by a benchmark.
+const numSamples = 256
+
+// Sizes are the operand size classes, in bits.
+var Sizes = []int{64, 128, 192, 256}
+
+// Benchmark is a pair of equivalent benchmarks of one operation and size
+// class, for uint256 and math/big.
+type Benchmark struct {
+	Op      string // Operation, e.g. "MulMod".
+	Bits    int    // Size class of the operands.
+	Uint256 func(b *testing.B)
+	Big     func(b *testing.B)
+}
+
+// Name returns the name of bm, e.g. "MulMod/256".
+func (bm Benchmark) Name() string {
+	return fmt.Sprintf("%s/%d", bm.Op, bm.Bits)
+}
+
+// samples is a set of operands, as uint256 and as big.Int.
+type samples struct {
+	x, y, m    [numSamples]uint256.Int
+	bx, by, bm [numSamples]big.Int
+}
+
+// randBits returns a random value of exactly the given bit length.
+func randBits(rng *rand.Rand, bits int) uint256.Int {
+	var z uint256.Int
+	for i := range z {
+		z[i] = rng.Uint64()
+	}
+	z.Rsh(&z, uint(256-bits))
+	z[(bits-1)/64] |= 1 << uint((bits-1)%64)
+	return z
+}
+
+// newSamples returns operands x and y with the bit lengths xBits and yBits,
+// and non-zero moduli m with the bit length mBits.
+func newSamples(seed int64, xBits, yBits, mBits int) *samples {
+	rng := rand.New(rand.NewSource(seed))
+	s := new(samples)
+	for i := 0; i < numSamples; i++ {
+		s.x[i] = randBits(rng, xBits)
+		s.y[i] = randBits(rng, yBits)
+		s.m[i] = randBits(rng, mBits)
+		s.bx[i].Set(s.x[i].ToBig())
+		s.by[i].Set(s.y[i].ToBig())
+		s.bm[i].Set(s.m[i].ToBig())
+	}
+	return s
+}
+
+// op2 returns a benchmark of the binary operation f on x and y.
+func op2(s *samples, f func(z, x, y *uint256.Int), g func(z, x, y *big.Int)) (func(*testing.B), func(*testing.B)) {
+	return func(b *testing.B) {
+			var z uint256.Int
+			for i := 0; i < b.N; i++ {
+				j := i % numSamples
+				f(&z, &s.x[j], &s.y[j])
+			}
+		}, func(b *testing.B) {
+			var z big.Int
+			for i := 0; i < b.N; i++ {
+				j := i % numSamples
+				g(&z, &s.bx[j], &s.by[j])
+			}
+		}
+}
+
+// op3 returns a benchmark of the modular operation f on x, y and m.
+func op3(s *samples, f func(z, x, y, m *uint256.Int), g func(z, x, y, m *big.Int)) (func(*testing.B), func(*testing.B)) {
+	return func(b *testing.B) {
+			var z uint256.Int
+			for i := 0; i < b.N; i++ {
+				j := i % numSamples
+				f(&z, &s.x[j], &s.y[j], &s.m[j])
+			}
+		}, func(b *testing.B) {
+			var z big.Int
+			for i := 0; i < b.N; i++ {
+				j := i % numSamples
+				g(&z, &s.bx[j], &s.by[j], &s.bm[j])
+			}
+		}
+}
+
+// twoTo256 is the modulus of uint256 arithmetic, for the big.Int version of
+// Exp.
+var twoTo256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// Benchmarks returns the standard benchmarks, for every operation and size
+// class. Add, Mul and Exp use operands of the size class; Div and Mod divide
+// 256-bit values by divisors of the size class; AddMod and MulMod reduce
+// 256-bit operands by moduli of the size class. The big.Int versions of Add
+// and Mul do not wrap, and Exp is reduced modulo 2**256 to match uint256.
+func Benchmarks() []Benchmark {
+	type op struct {
+		name string
+		make func(bits int) (func(*testing.B), func(*testing.B))
+	}
+	ops := []op{
+		{"Add", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op2(newSamples(1, bits, bits, bits),
+				func(z, x, y *uint256.Int) { z.Add(x, y) },
+				func(z, x, y *big.Int) { z.Add(x, y) })
+		}},
+		{"Mul", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op2(newSamples(2, bits, bits, bits),
+				func(z, x, y *uint256.Int) { z.Mul(x, y) },
+				func(z, x, y *big.Int) { z.Mul(x, y) })
+		}},
+		{"Div", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op2(newSamples(3, 256, bits, bits),
+				func(z, x, y *uint256.Int) { z.Div(x, y) },
+				func(z, x, y *big.Int) { z.Quo(x, y) })
+		}},
+		{"Mod", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op2(newSamples(4, 256, bits, bits),
+				func(z, x, y *uint256.Int) { z.Mod(x, y) },
+				func(z, x, y *big.Int) { z.Rem(x, y) })
+		}},
+		{"Exp", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op2(newSamples(5, bits, bits, bits),
+				func(z, x, y *uint256.Int) { z.Exp(x, y) },
+				func(z, x, y *big.Int) { z.Exp(x, y, twoTo256) })
+		}},
+		{"AddMod", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op3(newSamples(6, 256, 256, bits),
+				func(z, x, y, m *uint256.Int) { z.AddMod(x, y, m) },
+				func(z, x, y, m *big.Int) { z.Add(x, y); z.Rem(z, m) })
+		}},
+		{"MulMod", func(bits int) (func(*testing.B), func(*testing.B)) {
+			return op3(newSamples(7, 256, 256, bits),
+				func(z, x, y, m *uint256.Int) { z.MulMod(x, y, m) },
+				func(z, x, y, m *big.Int) { z.Mul(x, y); z.Rem(z, m) })
+		}},
+	}
+	var bms []Benchmark
+	for _, o := range ops {
+		for _, bits := range Sizes {
+			u, b := o.make(bits)
+			bms = append(bms, Benchmark{Op: o.name, Bits: bits, Uint256: u, Big: b})
+		}
+	}
+	return bms
+}
+
+// Result holds the outcome of running a Benchmark.
+type Result struct {
+	Op      string
+	Bits    int
+	Uint256 testing.BenchmarkResult
+	Big     testing.BenchmarkResult
+}
+
+// nsPerOp returns the time per operation of r, without the rounding of
+// r.NsPerOp, which would swamp the fastest operations.
+func nsPerOp(r testing.BenchmarkResult) float64 {
+	if r.N == 0 {
+		return 0
+	}
+	return float64(r.T.Nanoseconds()) / float64(r.N)
+}
+
+// Speedup returns how many times faster the uint256 version ran than the
+// math/big one, or 0 if it was not measured.
+func (r Result) Speedup() float64 {
+	u := nsPerOp(r.Uint256)
+	if u == 0 {
+		return 0
+	}
+	return nsPerOp(r.Big) / u
+}
+
+// Run runs the benchmarks with testing.Benchmark, and returns their results.
+func Run(bms []Benchmark) []Result {
+	results := make([]Result, len(bms))
+	for i, bm := range bms {
+		results[i] = Result{
+			Op:      bm.Op,
+			Bits:    bm.Bits,
+			Uint256: testing.Benchmark(bm.Uint256),
+			Big:     testing.Benchmark(bm.Big),
+		}
+	}
+	return results
+}
+
+// WriteReport writes a table of the results to w, headed by the platform they
+// were measured on.
+func WriteReport(w io.Writer, results []Result) error {
+	if _, err := fmt.Fprintf(w, "goos: %s, goarch: %s, cpus: %d, go: %s\n",
+		runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), runtime.Version()); err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "op\tbits\tuint256 ns/op\tbig ns/op\tspeedup\t")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%.1f\t%.2fx\t\n", r.Op, r.Bits, nsPerOp(r.Uint256), nsPerOp(r.Big), r.Speedup())
+	}
+	return tw.Flush()
+}
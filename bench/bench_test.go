@@ -0,0 +1,98 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package bench
+
+import (
+	"bytes"
+	"flag"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+var report = flag.Bool("report", false, "run all benchmarks and print a comparison report")
+
+func TestRandBits(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for bits := 1; bits <= 256; bits++ {
+		if x := randBits(rng, bits); x.BitLen() != bits {
+			t.Fatalf("got %d bits, exp %d", x.BitLen(), bits)
+		}
+	}
+}
+
+func TestSamples(t *testing.T) {
+	s := newSamples(1, 256, 128, 64)
+	for i := 0; i < numSamples; i++ {
+		if s.x[i].BitLen() != 256 || s.y[i].BitLen() != 128 || s.m[i].BitLen() != 64 {
+			t.Fatalf("%d: wrong sizes %d %d %d", i, s.x[i].BitLen(), s.y[i].BitLen(), s.m[i].BitLen())
+		}
+		if s.bx[i].Cmp(s.x[i].ToBig()) != 0 || s.by[i].Cmp(s.y[i].ToBig()) != 0 || s.bm[i].Cmp(s.m[i].ToBig()) != 0 {
+			t.Fatalf("%d: big.Int samples differ", i)
+		}
+	}
+}
+
+// TestBenchmarks runs every benchmark function briefly, without timing.
+func TestBenchmarks(t *testing.T) {
+	bms := Benchmarks()
+	if exp := 7 * len(Sizes); len(bms) != exp {
+		t.Fatalf("got %d benchmarks, exp %d", len(bms), exp)
+	}
+	names := make(map[string]bool)
+	for _, bm := range bms {
+		if names[bm.Name()] {
+			t.Errorf("duplicate benchmark %s", bm.Name())
+		}
+		names[bm.Name()] = true
+		bm.Uint256(&testing.B{N: numSamples})
+		bm.Big(&testing.B{N: numSamples})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	results := []Result{{
+		Op:      "MulMod",
+		Bits:    256,
+		Uint256: testing.BenchmarkResult{N: 1000, T: 50 * time.Microsecond},
+		Big:     testing.BenchmarkResult{N: 1000, T: 200 * time.Microsecond},
+	}}
+	if s := results[0].Speedup(); s != 4 {
+		t.Errorf("got speedup %v, exp 4", s)
+	}
+	var buf bytes.Buffer
+	if err := WriteReport(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "goos: ") {
+		t.Fatalf("unexpected report:\n%s", buf.String())
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "MulMod 256 50.0 200.0 4.00x" {
+		t.Errorf("got row %q", lines[2])
+	}
+}
+
+// TestReport prints the full report when run with -report, e.g.
+//
+//	go test ./bench -run TestReport -report
+func TestReport(t *testing.T) {
+	if !*report {
+		t.Skip("run with -report")
+	}
+	if err := WriteReport(os.Stdout, Run(Benchmarks())); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkAll(b *testing.B) {
+	for _, bm := range Benchmarks() {
+		b.Run(bm.Name()+"/uint256", bm.Uint256)
+		b.Run(bm.Name()+"/big", bm.Big)
+	}
+}
@@ -8,6 +8,7 @@ package uint256
 import (
 	"math/big"
 	"math/rand"
+	"sort"
 	"testing"
 )
 
@@ -317,6 +318,80 @@ func BenchmarkLt(b *testing.B) {
 	b.Run("small/big", func(b *testing.B) { benchmarkBig(b, &big64Samples) })
 }
 
+func BenchmarkIncrement(b *testing.B) {
+	b.Run("AddOne", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.AddOne(&z)
+		}
+	})
+	b.Run("Add", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.Add(&z, &Int{1})
+		}
+	})
+	b.Run("IncOverflow", func(b *testing.B) {
+		var z Int
+		for i := 0; i < b.N; i++ {
+			z.IncOverflow(&z)
+		}
+	})
+}
+
+func BenchmarkCmp(b *testing.B) {
+	benchmarkUint256 := func(b *testing.B, samples *[numSamples]Int, cmp func(x, y *Int) int) (r int) {
+		var x Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				y := samples[i]
+				r = cmp(&x, &y)
+				x = y
+			}
+		}
+		return
+	}
+	benchmarkBig := func(b *testing.B, samples *[numSamples]big.Int) (r int) {
+		var x big.Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				y := samples[i]
+				r = x.Cmp(&y)
+				x = y
+			}
+		}
+		return
+	}
+
+	b.Run("large/uint256", func(b *testing.B) { benchmarkUint256(b, &int256Samples, (*Int).Cmp) })
+	b.Run("large/uint256-signed", func(b *testing.B) { benchmarkUint256(b, &int256Samples, (*Int).Scmp) })
+	b.Run("large/big", func(b *testing.B) { benchmarkBig(b, &big256Samples) })
+	b.Run("small/uint256", func(b *testing.B) { benchmarkUint256(b, &int64Samples, (*Int).Cmp) })
+	b.Run("small/uint256-signed", func(b *testing.B) { benchmarkUint256(b, &int64Samples, (*Int).Scmp) })
+	b.Run("small/big", func(b *testing.B) { benchmarkBig(b, &big64Samples) })
+}
+
+func BenchmarkSortCmp(b *testing.B) {
+	benchmarkUint256 := func(b *testing.B, cmp func(x, y *Int) int) {
+		var xs [numSamples]Int
+		for i := 0; i < b.N; i++ {
+			xs = int256Samples
+			sort.Slice(xs[:], func(i, j int) bool { return cmp(&xs[i], &xs[j]) < 0 })
+		}
+	}
+	b.Run("uint256", func(b *testing.B) { benchmarkUint256(b, (*Int).Cmp) })
+	b.Run("uint256-signed", func(b *testing.B) { benchmarkUint256(b, (*Int).Scmp) })
+	b.Run("big", func(b *testing.B) {
+		var xs [numSamples]*big.Int
+		for i := 0; i < b.N; i++ {
+			for j := range xs {
+				xs[j] = &big256Samples[j]
+			}
+			sort.Slice(xs[:], func(i, j int) bool { return xs[i].Cmp(xs[j]) < 0 })
+		}
+	})
+}
+
 func benchmark_Lsh_Big(n uint, bench *testing.B) {
 	original := big.NewInt(0).SetBytes(hex2Bytes("FBCDEF090807060504030201ffffffffFBCDEF090807060504030201ffffffff"))
 	bench.ResetTimer()
@@ -449,7 +524,7 @@ func benchmark_Exp_Big(bench *testing.B) {
 
 	bench.ResetTimer()
 	for i := 0; i < bench.N; i++ {
-		Exp(base, exp)
+		bigExp(base, exp)
 		base.Set(orig)
 	}
 }
@@ -481,7 +556,7 @@ func benchmark_ExpSmall_Big(bench *testing.B) {
 
 	bench.ResetTimer()
 	for i := 0; i < bench.N; i++ {
-		Exp(base, exp)
+		bigExp(base, exp)
 		base.Set(orig)
 	}
 }
@@ -654,7 +729,7 @@ func benchmark_SdivLarge_Big(bench *testing.B) {
 	for i := 0; i < bench.N; i++ {
 		x.Set(a)
 		y.Set(b)
-		U256(Sdiv(S256(x), S256(y)))
+		U256(bigSdiv(S256(x), S256(y)))
 	}
 }
 
@@ -675,3 +750,40 @@ func Benchmark_SDiv(bench *testing.B) {
 	bench.Run("large/big", benchmark_SdivLarge_Big)
 	bench.Run("large/uint256", benchmark_SdivLarge_Bit)
 }
+
+func BenchmarkMulF(b *testing.B) {
+	var samples [numSamples]Int
+	for i := range samples {
+		samples[i].Mod(&int256Samples[i], &BN254Scalar.modulus)
+	}
+	b.Run("MulF", func(b *testing.B) {
+		var sink, x Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				y := samples[i]
+				sink.MulF(&x, &y, BN254Scalar)
+				x = y
+			}
+		}
+	})
+	b.Run("MulMont", func(b *testing.B) {
+		var sink, x Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				y := samples[i]
+				BN254Scalar.MulMont(&sink, &x, &y)
+				x = y
+			}
+		}
+	})
+	b.Run("MulMod", func(b *testing.B) {
+		var sink, x Int
+		for j := 0; j < b.N; j += numSamples {
+			for i := 0; i < numSamples; i++ {
+				y := samples[i]
+				sink.MulMod(&x, &y, &BN254Scalar.modulus)
+				x = y
+			}
+		}
+	})
+}
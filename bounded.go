@@ -0,0 +1,100 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOutOfRange is returned (wrapped) by Bounded and UintN when a value
+// violates their range or width constraint.
+var ErrOutOfRange = errors.New("uint256: value out of range")
+
+// Bounded is an Int constrained to the inclusive range [min, max], for use in
+// request structs whose validation layer calls Validate, e.g. to require a
+// non-zero amount or one that fits in 128 bits. The zero value only admits
+// zero; create one with NewBounded, NewNonZero or NewBoundedBits.
+type Bounded struct {
+	value    Int
+	min, max Int
+}
+
+// NewBounded returns a Bounded constrained to [min, max], holding min.
+// It panics if min > max.
+func NewBounded(min, max *Int) *Bounded {
+	if min.Gt(max) {
+		panic("uint256: NewBounded with min > max")
+	}
+	return &Bounded{value: *min, min: *min, max: *max}
+}
+
+// NewNonZero returns a Bounded constrained to [1, 2**256 - 1].
+func NewNonZero() *Bounded {
+	return NewBounded(&Int{1}, new(Int).SetAllOne())
+}
+
+// NewBoundedBits returns a Bounded constrained to [0, 2**bits - 1], e.g.
+// NewBoundedBits(128) for values that must fit in a uint128. The number of
+// bits must be in the range [1, 256].
+func NewBoundedBits(bits uint) *Bounded {
+	if bits == 0 || bits > 256 {
+		panic("uint256: NewBoundedBits with bits out of range")
+	}
+	var max Int
+	max.SetAllOne().Rsh(&max, 256-bits)
+	return NewBounded(new(Int), &max)
+}
+
+// Min returns a copy of the lower bound of b.
+func (b *Bounded) Min() *Int { return b.min.Clone() }
+
+// Max returns a copy of the upper bound of b.
+func (b *Bounded) Max() *Int { return b.max.Clone() }
+
+// Value returns a copy of the value held by b.
+func (b *Bounded) Value() *Int { return b.value.Clone() }
+
+// check returns an error if x is outside the bounds of b.
+func (b *Bounded) check(x *Int) error {
+	if x.Lt(&b.min) || x.Gt(&b.max) {
+		return fmt.Errorf("%w: %s not in [%s, %s]", ErrOutOfRange, x.Dec(), b.min.Dec(), b.max.Dec())
+	}
+	return nil
+}
+
+// Set sets the value of b to x, and returns an error (wrapping ErrOutOfRange)
+// if x is out of range, in which case b is left unchanged.
+func (b *Bounded) Set(x *Int) error {
+	if err := b.check(x); err != nil {
+		return err
+	}
+	b.value = *x
+	return nil
+}
+
+// Validate returns an error (wrapping ErrOutOfRange) if the value of b is out
+// of range. Since Set and UnmarshalText already enforce the bounds, this
+// mostly serves validation layers which call Validate on every field.
+func (b *Bounded) Validate() error {
+	return b.check(&b.value)
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the value as a
+// decimal string.
+func (b *Bounded) MarshalText() ([]byte, error) {
+	return []byte(b.value.Dec()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a decimal or
+// 0x-prefixed hex string, and enforcing the bounds.
+func (b *Bounded) UnmarshalText(text []byte) error {
+	var x Int
+	if err := x.setFromDecimalOrHex(string(text)); err != nil {
+		return err
+	}
+	return b.Set(&x)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestBounded(t *testing.T) {
+	b := NewBounded(&Int{10}, &Int{20})
+	if err := b.Validate(); err != nil {
+		t.Fatalf("initial value: %v", err)
+	}
+	if !b.Value().Eq(&Int{10}) {
+		t.Fatalf("initial value %v, want 10", b.Value())
+	}
+	for _, v := range []uint64{10, 15, 20} {
+		if err := b.Set(&Int{v}); err != nil {
+			t.Errorf("Set(%d): %v", v, err)
+		}
+	}
+	for _, v := range []uint64{0, 9, 21} {
+		if err := b.Set(&Int{v}); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Set(%d): have %v, want ErrOutOfRange", v, err)
+		}
+	}
+	if !b.Value().Eq(&Int{20}) {
+		t.Errorf("value changed by failed Set: %v", b.Value())
+	}
+	if !b.Min().Eq(&Int{10}) || !b.Max().Eq(&Int{20}) {
+		t.Errorf("bounds [%v, %v], want [10, 20]", b.Min(), b.Max())
+	}
+}
+
+func TestBoundedPresets(t *testing.T) {
+	nz := NewNonZero()
+	if err := nz.Validate(); err != nil {
+		t.Errorf("NewNonZero: %v", err)
+	}
+	if err := nz.Set(new(Int)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("NewNonZero accepted zero: %v", err)
+	}
+	if err := nz.Set(new(Int).SetAllOne()); err != nil {
+		t.Errorf("NewNonZero rejected max: %v", err)
+	}
+
+	u128 := NewBoundedBits(128)
+	max128 := &Int{^uint64(0), ^uint64(0)}
+	if err := u128.Set(max128); err != nil {
+		t.Errorf("NewBoundedBits(128) rejected 2**128-1: %v", err)
+	}
+	if err := u128.Set(&Int{0, 0, 1}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("NewBoundedBits(128) accepted 2**128: %v", err)
+	}
+	if !NewBoundedBits(256).Max().Eq(new(Int).SetAllOne()) {
+		t.Errorf("NewBoundedBits(256) max is not 2**256-1")
+	}
+	if !NewBoundedBits(1).Max().Eq(&Int{1}) {
+		t.Errorf("NewBoundedBits(1) max is not 1")
+	}
+}
+
+func TestBoundedPanics(t *testing.T) {
+	for name, f := range map[string]func(){
+		"min>max": func() { NewBounded(&Int{2}, &Int{1}) },
+		"bits=0":  func() { NewBoundedBits(0) },
+		"bits>256": func() {
+			NewBoundedBits(257)
+		},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestBoundedJSON(t *testing.T) {
+	type request struct {
+		Amount *Bounded `json:"amount"`
+	}
+	req := request{Amount: NewNonZero()}
+	if err := json.Unmarshal([]byte(`{"amount":"0x2a"}`), &req); err != nil {
+		t.Fatal(err)
+	}
+	if !req.Amount.Value().Eq(&Int{42}) {
+		t.Fatalf("have %v, want 42", req.Amount.Value())
+	}
+	out, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `{"amount":"42"}` {
+		t.Errorf("marshal: have %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"0"}`), &req); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("unmarshal zero: have %v, want ErrOutOfRange", err)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"abc"}`), &req); err == nil {
+		t.Errorf("unmarshal invalid: expected error")
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// BSON element types and the binary subtype used by the BSON encoding of Int.
+const (
+	bsonString     = 0x02
+	bsonBinary     = 0x05
+	bsonInt32      = 0x10
+	bsonInt64      = 0x12
+	bsonDecimal128 = 0x13
+
+	// BSONBinarySubtype is the user-defined binary subtype under which Ints
+	// too large for Decimal128 are stored, as 32 big-endian bytes.
+	BSONBinarySubtype = 0x80
+)
+
+const (
+	// decimal128Digits is the number of decimal digits in the coefficient of
+	// a Decimal128 value.
+	decimal128Digits = 34
+	// decimal128Bias is the exponent bias of a Decimal128 value.
+	decimal128Bias = 6176
+)
+
+var (
+	errBSONType     = errors.New("uint256: unsupported bson type")
+	errBSONValue    = errors.New("uint256: invalid bson value")
+	errBSONNegative = errors.New("uint256: negative bson value")
+	errBSONFraction = errors.New("uint256: fractional bson decimal")
+)
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface of the
+// MongoDB Go driver (v2, whose type tag is a plain byte). Values below
+// 10**34 are stored as a Decimal128 with exponent 0, so that they can be
+// compared and aggregated on the server; larger values are stored as 32-byte
+// big-endian binary with subtype BSONBinarySubtype.
+func (z *Int) MarshalBSONValue() (byte, []byte, error) {
+	z = orZero(z)
+	var limit Int
+	if z.Lt(limit.pow10(decimal128Digits)) {
+		// The coefficient is below 2**113, so the high word holds its top 49
+		// bits, below the biased exponent.
+		out := make([]byte, 16)
+		binary.LittleEndian.PutUint64(out[:8], z[0])
+		binary.LittleEndian.PutUint64(out[8:], decimal128Bias<<49|z[1])
+		return bsonDecimal128, out, nil
+	}
+	out := make([]byte, 5, 5+32)
+	binary.LittleEndian.PutUint32(out, 32)
+	out[4] = BSONBinarySubtype
+	return bsonBinary, z.AppendAvroFixed(out), nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface of the
+// MongoDB Go driver (v2). Besides the forms written by MarshalBSONValue, it
+// accepts any non-negative integral Decimal128, binary values of up to 32
+// bytes, non-negative int32 and int64 values, and decimal strings, so that
+// documents written with stringified integers can be read during migration.
+func (z *Int) UnmarshalBSONValue(typ byte, data []byte) error {
+	if z == nil {
+		panic(nilReceiver("UnmarshalBSONValue"))
+	}
+	switch typ {
+	case bsonDecimal128:
+		if len(data) != 16 {
+			return errBSONValue
+		}
+		return z.setDecimal128(binary.LittleEndian.Uint64(data[:8]), binary.LittleEndian.Uint64(data[8:]))
+	case bsonBinary:
+		if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-5 || len(data)-5 > 32 {
+			return errBSONValue
+		}
+		z.SetBytes(data[5:])
+		return nil
+	case bsonInt32:
+		if len(data) != 4 {
+			return errBSONValue
+		}
+		v := int32(binary.LittleEndian.Uint32(data))
+		if v < 0 {
+			return errBSONNegative
+		}
+		z.SetUint64(uint64(v))
+		return nil
+	case bsonInt64:
+		if len(data) != 8 {
+			return errBSONValue
+		}
+		v := int64(binary.LittleEndian.Uint64(data))
+		if v < 0 {
+			return errBSONNegative
+		}
+		z.SetUint64(uint64(v))
+		return nil
+	case bsonString:
+		// An int32 length including the trailing NUL, the string, and the NUL.
+		if len(data) < 5 || int(binary.LittleEndian.Uint32(data)) != len(data)-4 || data[len(data)-1] != 0 {
+			return errBSONValue
+		}
+		return z.SetFromDecimal(string(data[4 : len(data)-1]))
+	}
+	return errBSONType
+}
+
+// setDecimal128 sets z from the IEEE 754-2008 BID encoded Decimal128 with the
+// given low and high words, which must be a non-negative integer of at most
+// 2**256 - 1.
+func (z *Int) setDecimal128(lo, hi uint64) error {
+	if hi>>61&3 == 3 {
+		// Infinity, NaN, or a coefficient of at least 2**113, which is not
+		// canonical.
+		return errBSONValue
+	}
+	coeff := Int{lo, hi & (1<<49 - 1)}
+	var limit Int
+	if !coeff.Lt(limit.pow10(decimal128Digits)) {
+		return errBSONValue
+	}
+	if coeff.IsZero() {
+		z.Clear()
+		return nil
+	}
+	if hi>>63 != 0 {
+		return errBSONNegative
+	}
+	exp := int(hi>>49&(1<<14-1)) - decimal128Bias
+	switch {
+	case exp < 0:
+		if -exp > decimal128Digits {
+			return errBSONFraction
+		}
+		var q, r Int
+		limit.pow10(-exp)
+		q.Div(&coeff, &limit)
+		if !r.Mod(&coeff, &limit).IsZero() {
+			return errBSONFraction
+		}
+		coeff = q
+	case exp > 0:
+		// coeff * 10**exp must fit in 256 bits, so exp <= 77.
+		if exp > maxDecimalLen-1 {
+			return errOverflow
+		}
+		var max Int
+		limit.pow10(exp)
+		if coeff.Gt(max.Div(max.SetAllOne(), &limit)) {
+			return errOverflow
+		}
+		coeff.Mul(&coeff, &limit)
+	}
+	z.Copy(&coeff)
+	return nil
+}
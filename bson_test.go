@@ -0,0 +1,138 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+func decimal128(lo, hi uint64) []byte {
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint64(out, lo)
+	binary.LittleEndian.PutUint64(out[8:], hi)
+	return out
+}
+
+func TestBSONRoundtrip(t *testing.T) {
+	var max128 Int
+	max128.pow10(decimal128Digits).Sub(&max128, &Int{1})
+	var min256 Int
+	min256.pow10(decimal128Digits)
+	for i, tc := range []struct {
+		x   *Int
+		typ byte
+	}{
+		{new(Int), bsonDecimal128},
+		{&Int{1}, bsonDecimal128},
+		{&max128, bsonDecimal128},
+		{&min256, bsonBinary},
+		{new(Int).SetAllOne(), bsonBinary},
+	} {
+		typ, data, err := tc.x.MarshalBSONValue()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if typ != tc.typ {
+			t.Errorf("test %d: type %#x, want %#x", i, typ, tc.typ)
+		}
+		var z Int
+		if err := z.UnmarshalBSONValue(typ, data); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if !z.Eq(tc.x) {
+			t.Errorf("test %d: have %v, want %v", i, &z, tc.x)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		_, x, _ := randHighNums()
+		typ, data, _ := x.MarshalBSONValue()
+		var z Int
+		if err := z.UnmarshalBSONValue(typ, data); err != nil || !z.Eq(x) {
+			t.Fatalf("roundtrip %v: have %v, %v", x, &z, err)
+		}
+	}
+}
+
+func TestBSONDecimal128Encoding(t *testing.T) {
+	// Decimal128 "1" and "12345678901234567890", per the BSON corpus.
+	_, data, _ := (&Int{1}).MarshalBSONValue()
+	if want := decimal128(1, 0x3040000000000000); string(data) != string(want) {
+		t.Errorf("1: have %x, want %x", data, want)
+	}
+	_, data, _ = MustFromDecimal("12345678901234567890").MarshalBSONValue()
+	if want := decimal128(0xab54a98ceb1f0ad2, 0x3040000000000000); string(data) != string(want) {
+		t.Errorf("12345678901234567890: have %x, want %x", data, want)
+	}
+	_, data, _ = new(Int).SetAllOne().MarshalBSONValue()
+	if len(data) != 37 || data[4] != BSONBinarySubtype || binary.LittleEndian.Uint32(data) != 32 {
+		t.Errorf("binary form: have %x", data)
+	}
+}
+
+func TestBSONUnmarshal(t *testing.T) {
+	str := func(s string) []byte {
+		out := make([]byte, 4, 4+len(s)+1)
+		binary.LittleEndian.PutUint32(out, uint32(len(s)+1))
+		return append(append(out, s...), 0)
+	}
+	le32 := func(v int32) []byte {
+		out := make([]byte, 4)
+		binary.LittleEndian.PutUint32(out, uint32(v))
+		return out
+	}
+	le64 := func(v int64) []byte {
+		out := make([]byte, 8)
+		binary.LittleEndian.PutUint64(out, uint64(v))
+		return out
+	}
+	for i, tc := range []struct {
+		typ  byte
+		data []byte
+		want string // empty for an expected error
+	}{
+		{bsonDecimal128, decimal128(1, 0x3046000000000000), "1000"},                        // 1E+3
+		{bsonDecimal128, decimal128(10, 0x303e000000000000), "1"},                          // 1.0
+		{bsonDecimal128, decimal128(0, 0xb040000000000000), "0"},                           // -0
+		{bsonDecimal128, decimal128(0, 0x3040000000000000), "0"},                           // 0
+		{bsonDecimal128, decimal128(5, 0x303e000000000000), ""},                            // 0.5
+		{bsonDecimal128, decimal128(1, 0xb040000000000000), ""},                            // -1
+		{bsonDecimal128, decimal128(0, 0x7c00000000000000), ""},                            // NaN
+		{bsonDecimal128, decimal128(0, 0x7800000000000000), ""},                            // Inf
+		{bsonDecimal128, decimal128(1, 0x30da000000000000), "1" + strings.Repeat("0", 77)}, // 1E+77
+		{bsonDecimal128, decimal128(2, 0x30da000000000000), ""},                            // 2E+77
+		{bsonDecimal128, decimal128(1, 0x30dc000000000000), ""},                            // 1E+78
+		{bsonDecimal128, decimal128(0x378d8e6400000000, 0x3041ed09bead87c0), ""},           // coefficient 10**34
+		{bsonDecimal128, []byte{1}, ""},
+		{bsonBinary, []byte{1, 0, 0, 0, 0x80, 7}, "7"},
+		{bsonBinary, []byte{2, 0, 0, 0, 0x80, 7}, ""},
+		{bsonInt32, le32(42), "42"},
+		{bsonInt32, le32(-1), ""},
+		{bsonInt64, le64(1 << 40), "1099511627776"},
+		{bsonInt64, le64(-1), ""},
+		{bsonString, str("123"), "123"},
+		{bsonString, str("0x10"), ""},
+		{bsonString, str("1")[:5], ""},
+		{0x01, le64(0), ""},
+	} {
+		var z Int
+		err := z.UnmarshalBSONValue(tc.typ, tc.data)
+		if tc.want == "" {
+			if err == nil {
+				t.Errorf("test %d: expected error, have %v", i, &z)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %d: %v", i, err)
+			continue
+		}
+		if z.Dec() != tc.want {
+			t.Errorf("test %d: have %v, want %v", i, z.Dec(), tc.want)
+		}
+	}
+}
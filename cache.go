@@ -0,0 +1,67 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "errors"
+
+var errCacheValue = errors.New("uint256: invalid cache value")
+
+// MarshalCache returns the fixed 32-byte big-endian form of z, for storing in
+// a key-value cache such as Redis or memcache without the cost of formatting
+// and parsing hex or decimal strings.
+func (z *Int) MarshalCache() []byte {
+	b := z.Bytes32()
+	return b[:]
+}
+
+// MarshalCacheCompact returns the uvarint form of z, which is much shorter
+// than 32 bytes for typical balances. Since values of more than 217 bits do
+// not fit in fewer than 32 uvarint bytes, those are returned in the fixed
+// 32-byte form instead, which keeps the two forms distinguishable by length.
+func (z *Int) MarshalCacheCompact() []byte {
+	z = orZero(z)
+	if z.uvarintLen() >= 32 {
+		return z.MarshalCache()
+	}
+	return z.AppendUvarint(nil)
+}
+
+// UnmarshalCache sets z from the output of either MarshalCache or
+// MarshalCacheCompact: 32-byte input is taken to be the fixed form, and
+// anything shorter the compact form.
+func (z *Int) UnmarshalCache(b []byte) error {
+	if z == nil {
+		panic(nilReceiver("UnmarshalCache"))
+	}
+	if len(b) == 32 {
+		z.SetBytes(b)
+		return nil
+	}
+	if len(b) == 0 || len(b) > 32 || z.SetUvarint(b) != len(b) {
+		z.Clear()
+		return errCacheValue
+	}
+	return nil
+}
+
+// CacheValue is an Int with the compact cache encoding as its binary
+// encoding. Clients such as go-redis store encoding.BinaryMarshaler arguments
+// and scan into encoding.BinaryUnmarshaler destinations, so an *Int can be
+// passed to them after conversion:
+//
+//	rdb.Set(ctx, key, (*uint256.CacheValue)(balance), 0)
+//	err := rdb.Get(ctx, key).Scan((*uint256.CacheValue)(balance))
+type CacheValue Int
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v *CacheValue) MarshalBinary() ([]byte, error) {
+	return (*Int)(v).MarshalCacheCompact(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *CacheValue) UnmarshalBinary(b []byte) error {
+	return (*Int)(v).UnmarshalCache(b)
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding"
+	"fmt"
+	"testing"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = (*CacheValue)(nil)
+	_ encoding.BinaryUnmarshaler = (*CacheValue)(nil)
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	values := []*Int{new(Int), new(Int).SetOne(), new(Int).SetAllOne(), new(Int).Lsh(&Int{1}, 216), new(Int).Lsh(&Int{1}, 217)}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		values = append(values, x)
+	}
+	for _, x := range values {
+		var z Int
+		if b := x.MarshalCache(); len(b) != 32 {
+			t.Fatalf("fixed form of %v has %d bytes", x.Hex(), len(b))
+		} else if err := z.UnmarshalCache(b); err != nil || !z.Eq(x) {
+			t.Fatalf("fixed %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+		b := x.MarshalCacheCompact()
+		if len(b) > 32 || (x.BitLen() <= 217 && len(b) == 32) {
+			t.Fatalf("compact form of %v has %d bytes", x.Hex(), len(b))
+		}
+		if err := z.UnmarshalCache(b); err != nil || !z.Eq(x) {
+			t.Fatalf("compact %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+		v := (*CacheValue)(&z)
+		if b, err := (*CacheValue)(x).MarshalBinary(); err != nil {
+			t.Fatal(err)
+		} else if err := v.UnmarshalBinary(b); err != nil || !z.Eq(x) {
+			t.Fatalf("binary %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+	}
+}
+
+func TestUnmarshalCacheErrors(t *testing.T) {
+	for _, b := range [][]byte{nil, {0x80}, {0x01, 0x02}, make([]byte, 33)} {
+		if err := new(Int).UnmarshalCache(b); err != errCacheValue {
+			t.Errorf("%x: expected errCacheValue, got %v", b, err)
+		}
+	}
+}
+
+func ExampleInt_MarshalCacheCompact() {
+	// A map stands in for a Redis or memcache client here.
+	cache := make(map[string][]byte)
+	balance := new(Int).SetUint64(1500000000000000000) // 1.5 ether in wei
+	cache["balance"] = balance.MarshalCacheCompact()
+
+	var got Int
+	if err := got.UnmarshalCache(cache["balance"]); err != nil {
+		panic(err)
+	}
+	fmt.Println(len(cache["balance"]), got.Uint64())
+	// Output: 9 1500000000000000000
+}
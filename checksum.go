@@ -0,0 +1,87 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+)
+
+// MultisetHash is an incremental checksum of a multiset of Ints, in the style
+// of the MSet-Add-Hash construction: each element is mapped through a keyed
+// hash (HMAC-SHA256), and the digests are summed modulo 2**256. Since the sum
+// is order-independent and invertible, elements can be added and removed in
+// any order, which allows e.g. a database to maintain an integrity check of a
+// large set of balances without rehashing it on every update.
+//
+// The checksum is only collision resistant as long as the key is secret.
+// A MultisetHash is not safe for concurrent use.
+type MultisetHash struct {
+	mac   hash.Hash
+	sum   Int
+	count int64
+	buf   [sha256.Size]byte
+}
+
+// NewMultisetHash returns an empty MultisetHash using the given key.
+func NewMultisetHash(key []byte) *MultisetHash {
+	return &MultisetHash{mac: hmac.New(sha256.New, key)}
+}
+
+// digest sets d to the keyed hash of the 32-byte big-endian form of x.
+func (h *MultisetHash) digest(d, x *Int) {
+	b := x.Bytes32()
+	h.mac.Reset()
+	h.mac.Write(b[:])
+	d.SetBytes(h.mac.Sum(h.buf[:0]))
+}
+
+// Add adds x to the multiset.
+func (h *MultisetHash) Add(x *Int) {
+	var d Int
+	h.digest(&d, x)
+	h.sum.Add(&h.sum, &d)
+	h.count++
+}
+
+// Remove removes x from the multiset. Removing an element which was never
+// added yields a checksum that no multiset of added elements can match.
+func (h *MultisetHash) Remove(x *Int) {
+	var d Int
+	h.digest(&d, x)
+	h.sum.Sub(&h.sum, &d)
+	h.count--
+}
+
+// Merge adds all elements of other, which must use the same key, to h.
+func (h *MultisetHash) Merge(other *MultisetHash) {
+	h.sum.Add(&h.sum, &other.sum)
+	h.count += other.count
+}
+
+// Len returns the number of elements in the multiset, i.e. the number of
+// additions minus the number of removals.
+func (h *MultisetHash) Len() int64 {
+	return h.count
+}
+
+// Sum returns the current checksum.
+func (h *MultisetHash) Sum() *Int {
+	return h.sum.Clone()
+}
+
+// Equal reports whether h and other (which must use the same key) hold the
+// same multiset, with overwhelming probability.
+func (h *MultisetHash) Equal(other *MultisetHash) bool {
+	return h.count == other.count && h.sum.Eq(&other.sum)
+}
+
+// Reset empties the multiset.
+func (h *MultisetHash) Reset() {
+	h.sum.Clear()
+	h.count = 0
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMultisetHash(t *testing.T) {
+	key := []byte("test key")
+	var values []*Int
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		values = append(values, x)
+	}
+	a, b := NewMultisetHash(key), NewMultisetHash(key)
+	for _, x := range values {
+		a.Add(x)
+	}
+	// Order independence.
+	for _, i := range rand.Perm(len(values)) {
+		b.Add(values[i])
+	}
+	if !a.Equal(b) || a.Len() != 100 {
+		t.Fatalf("order dependent: %v != %v", a.Sum().Hex(), b.Sum().Hex())
+	}
+	// Removal is the inverse of addition.
+	extra := new(Int).SetUint64(42)
+	b.Add(extra)
+	if a.Equal(b) {
+		t.Fatalf("extra element not detected")
+	}
+	b.Remove(extra)
+	if !a.Equal(b) {
+		t.Fatalf("remove did not undo add")
+	}
+	// Multiplicity matters.
+	b.Add(values[0])
+	b.Remove(values[1])
+	if a.Equal(b) {
+		t.Fatalf("replaced element not detected")
+	}
+	// Merging two halves gives the whole.
+	lo, hi := NewMultisetHash(key), NewMultisetHash(key)
+	for i, x := range values {
+		if i < 50 {
+			lo.Add(x)
+		} else {
+			hi.Add(x)
+		}
+	}
+	lo.Merge(hi)
+	if !lo.Equal(a) {
+		t.Fatalf("merge mismatch")
+	}
+	// A different key gives a different checksum.
+	c := NewMultisetHash([]byte("other key"))
+	for _, x := range values {
+		c.Add(x)
+	}
+	if c.Sum().Eq(a.Sum()) {
+		t.Fatalf("key not used")
+	}
+	a.Reset()
+	if !a.Sum().IsZero() || a.Len() != 0 {
+		t.Fatalf("reset failed")
+	}
+}
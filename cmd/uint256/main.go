@@ -0,0 +1,268 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Command uint256 converts, formats and computes with 256-bit unsigned
+// integers, using the same code as the uint256 package, for checking values
+// by hand.
+//
+// Usage:
+//
+//	uint256 convert [-from auto|dec|hex|bytes] [-to dec|hex|bytes|bytes32|bin] VALUE...
+//	uint256 format VALUE...
+//	uint256 arith OP X Y [M]
+//	uint256 eval [-wrap] EXPR [NAME=VALUE...]
+//	uint256 units [-from UNIT] [-to UNIT] AMOUNT...
+//	uint256 repl
+//
+// Values are decimal, or 0x-prefixed hex. The bytes form is hex without a
+// prefix or leading zero bytes, and bytes32 is the same padded to 32 bytes.
+// The arith operations are add, sub, mul, div, mod, exp, addmod and mulmod,
+// computed modulo 2**256 as in the EVM; eval evaluates an expression with
+// the eval package, with checked arithmetic unless -wrap is given. The units
+// are wei, kwei, mwei, gwei, szabo, finney and ether. repl starts an
+// interactive calculator with variables, a history, signed and hex display
+// modes, and EVM opcodes; enter :help in it for details.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/holiman/uint256/eval"
+)
+
+// errUsage is returned for invalid command lines, after printing the usage.
+var errUsage = errors.New("usage error")
+
+const usage = `usage:
+  uint256 convert [-from auto|dec|hex|bytes] [-to dec|hex|bytes|bytes32|bin] VALUE...
+  uint256 format VALUE...
+  uint256 arith add|sub|mul|div|mod|exp|addmod|mulmod X Y [M]
+  uint256 eval [-wrap] EXPR [NAME=VALUE...]
+  uint256 units [-from UNIT] [-to UNIT] AMOUNT...
+  uint256 repl
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command line args, and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	err := errUsage
+	if len(args) > 0 {
+		switch cmd, args := args[0], args[1:]; cmd {
+		case "convert":
+			err = convert(args, stdout, stderr)
+		case "format":
+			err = format(args, stdout)
+		case "arith":
+			err = arith(args, stdout)
+		case "eval":
+			err = evaluate(args, stdout, stderr)
+		case "units":
+			err = units(args, stdout, stderr)
+		case "repl":
+			err = repl(args, stdin, stdout, stderr)
+		case "help", "-h", "-help", "--help":
+			fmt.Fprint(stdout, usage)
+			return 0
+		}
+	}
+	switch {
+	case err == errUsage:
+		fmt.Fprint(stderr, usage)
+		return 2
+	case err != nil:
+		fmt.Fprintln(stderr, "uint256:", err)
+		return 1
+	}
+	return 0
+}
+
+// newFlagSet returns a flag set for the subcommand name, which reports
+// errors to stderr.
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+// parseValue parses s in the format from: auto accepts decimal or
+// 0x-prefixed hex.
+func parseValue(s, from string) (*uint256.Int, error) {
+	switch from {
+	case "auto":
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			return uint256.FromHex(s)
+		}
+		return uint256.FromDecimal(s)
+	case "dec":
+		return uint256.FromDecimal(s)
+	case "hex":
+		return uint256.FromHex(s)
+	case "bytes":
+		s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+		if len(s)%2 != 0 || len(s) > 64 {
+			return nil, fmt.Errorf("invalid byte string %q", s)
+		}
+		if s == "" {
+			return new(uint256.Int), nil
+		}
+		// Leading zero bytes are allowed here, unlike in hex values.
+		return uint256.FromText(s, 16)
+	}
+	return nil, fmt.Errorf("unknown input format %q", from)
+}
+
+// formatValue formats x in the format to.
+func formatValue(x *uint256.Int, to string) (string, error) {
+	switch to {
+	case "dec":
+		return x.Dec(), nil
+	case "hex":
+		return x.ToHex(), nil
+	case "bytes":
+		return fmt.Sprintf("%x", x.Bytes()), nil
+	case "bytes32":
+		return fmt.Sprintf("%x", x.Bytes32()), nil
+	case "bin":
+		return x.Text(2), nil
+	}
+	return "", fmt.Errorf("unknown output format %q", to)
+}
+
+func convert(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("convert", stderr)
+	from := fs.String("from", "auto", "input `format`: auto, dec, hex or bytes")
+	to := fs.String("to", "dec", "output `format`: dec, hex, bytes, bytes32 or bin")
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+	for _, arg := range fs.Args() {
+		x, err := parseValue(arg, *from)
+		if err != nil {
+			return err
+		}
+		s, err := formatValue(x, *to)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, s)
+	}
+	return nil
+}
+
+func format(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	for i, arg := range args {
+		x, err := parseValue(arg, "auto")
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			fmt.Fprintln(stdout)
+		}
+		fmt.Fprintf(stdout, "dec:     %s\n", x.Dec())
+		fmt.Fprintf(stdout, "hex:     %s\n", x.ToHex())
+		fmt.Fprintf(stdout, "bytes32: %x\n", x.Bytes32())
+		fmt.Fprintf(stdout, "signed:  %s\n", x.SDec())
+		fmt.Fprintf(stdout, "bits:    %d\n", x.BitLen())
+	}
+	return nil
+}
+
+func arith(args []string, stdout io.Writer) error {
+	if len(args) < 3 {
+		return errUsage
+	}
+	op, operands := args[0], args[1:]
+	want := 2
+	if op == "addmod" || op == "mulmod" {
+		want = 3
+	}
+	if len(operands) != want {
+		return errUsage
+	}
+	xs := make([]*uint256.Int, len(operands))
+	for i, arg := range operands {
+		x, err := parseValue(arg, "auto")
+		if err != nil {
+			return err
+		}
+		xs[i] = x
+	}
+	z, x, y := new(uint256.Int), xs[0], xs[1]
+	switch op {
+	case "add":
+		z.Add(x, y)
+	case "sub":
+		z.Sub(x, y)
+	case "mul":
+		z.Mul(x, y)
+	case "div":
+		z.Div(x, y)
+	case "mod":
+		z.Mod(x, y)
+	case "exp":
+		z.Exp(x, y)
+	case "addmod", "mulmod":
+		if xs[2].IsZero() {
+			// The methods panic on a zero modulus; the EVM gives zero.
+			break
+		}
+		if op == "addmod" {
+			z.AddMod(x, y, xs[2])
+		} else {
+			z.MulMod(x, y, xs[2])
+		}
+	default:
+		return fmt.Errorf("unknown operation %q", op)
+	}
+	fmt.Fprintln(stdout, z.Dec())
+	return nil
+}
+
+func evaluate(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("eval", stderr)
+	wrap := fs.Bool("wrap", false, "compute modulo 2**256 instead of failing on overflow")
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+	vars := make(map[string]*uint256.Int)
+	for _, arg := range fs.Args()[1:] {
+		i := strings.IndexByte(arg, '=')
+		if i < 0 {
+			return errUsage
+		}
+		x, err := parseValue(arg[i+1:], "auto")
+		if err != nil {
+			return err
+		}
+		vars[arg[:i]] = x
+	}
+	e, err := eval.Parse(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	var z *uint256.Int
+	if *wrap {
+		z, err = e.EvalWrapping(vars)
+	} else {
+		z, err = e.Eval(vars)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, z.Dec())
+	return nil
+}
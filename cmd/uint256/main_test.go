@@ -0,0 +1,81 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   string
+		status int
+		want   string
+	}{
+		{"convert -to hex 255 0x100", 0, "0xff\n0x100\n"},
+		{"convert -to bytes32 1", 0, "0000000000000000000000000000000000000000000000000000000000000001\n"},
+		{"convert -to bytes 0x10000", 0, "010000\n"},
+		{"convert -from bytes 000102", 0, "258\n"},
+		{"convert -to bin 5", 0, "101\n"},
+		{"convert -from dec 0x10", 1, ""},
+		{"convert -to oct 1", 1, ""},
+		{"format 0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 0, "" +
+			"dec:     115792089237316195423570985008687907853269984665640564039457584007913129639935\n" +
+			"hex:     0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff\n" +
+			"bytes32: ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff\n" +
+			"signed:  -1\n" +
+			"bits:    256\n"},
+		{"arith add 0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff 2", 0, "1\n"},
+		{"arith div 7 0", 0, "0\n"},
+		{"arith exp 2 10", 0, "1024\n"},
+		{"arith mulmod 10 10 7", 0, "2\n"},
+		{"arith addmod 1 2 0", 0, "0\n"},
+		{"arith pow 2 10", 1, ""},
+		{"arith add 1", 2, ""},
+		{"eval 2**8-x x=0x10", 0, "240\n"},
+		{"eval 0-1", 1, ""},
+		{"eval -wrap 0-1", 0, "115792089237316195423570985008687907853269984665640564039457584007913129639935\n"},
+		{"units 1.5", 0, "1500000000000000000\n"},
+		{"units -from gwei -to ether 21000", 0, "0.000021\n"},
+		{"units -from wei -to gwei 1000000000000", 0, "1000\n"},
+		{"units -from gwei 0.0000000001", 1, ""},
+		{"units -to parsec 1", 1, ""},
+		{"", 2, ""},
+		{"frobnicate", 2, ""},
+	}
+	for _, tc := range tests {
+		var stdout, stderr bytes.Buffer
+		status := run(strings.Fields(tc.args), nil, &stdout, &stderr)
+		if status != tc.status || stdout.String() != tc.want {
+			t.Errorf("%q: have status %d, output %q, want %d, %q (stderr %q)",
+				tc.args, status, stdout.String(), tc.status, tc.want, stderr.String())
+		}
+	}
+}
+
+func TestAmounts(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		decimals int
+		out      string
+	}{
+		{"0", 18, "0"},
+		{"1", 18, "1"},
+		{"0.000000000000000001", 18, "0.000000000000000001"},
+		{"123.450", 3, "123.45"},
+		{"007", 0, "7"},
+	} {
+		x, err := parseAmount(tc.in, tc.decimals)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.in, err)
+		}
+		if have := formatAmount(x, tc.decimals); have != tc.out {
+			t.Errorf("%q: have %s, want %s", tc.in, have, tc.out)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/holiman/uint256"
+	"github.com/holiman/uint256/eval"
+)
+
+const replHelp = `Enter an expression to evaluate, NAME = EXPR to assign a variable, or an
+EVM opcode followed by its operands, in the order the EVM pops them, e.g.
+SUB a b for a - b or SHL 8 x for x << 8. Operands are separated by spaces,
+so expressions among them must not contain any. Results are kept in _ for
+the latest and _1, _2, ... in order. The operators of expressions are
+unsigned; the opcodes SDIV, SMOD, SLT, SGT and SAR interpret their operands
+as signed.
+
+Opcodes: ADD MUL SUB DIV SDIV MOD SMOD ADDMOD MULMOD EXP SIGNEXTEND LT GT
+SLT SGT EQ ISZERO AND OR XOR NOT BYTE SHL SHR SAR
+
+Commands:
+  :signed on|off  show results as signed (two's complement) integers, and
+                  evaluate modulo 2**256 so that negative values work
+  :wrap on|off    evaluate modulo 2**256, instead of failing on overflow
+  :hex on|off     show results in hex, with a sign in signed mode
+  :vars           list the variables
+  :history        list the results
+  :help           show this help
+  :quit           exit
+`
+
+// opcodeArity holds the number of operands of the supported EVM opcodes.
+var opcodeArity = map[string]int{
+	"ADD": 2, "MUL": 2, "SUB": 2, "DIV": 2, "SDIV": 2, "MOD": 2, "SMOD": 2,
+	"ADDMOD": 3, "MULMOD": 3, "EXP": 2, "SIGNEXTEND": 2,
+	"LT": 2, "GT": 2, "SLT": 2, "SGT": 2, "EQ": 2, "ISZERO": 1,
+	"AND": 2, "OR": 2, "XOR": 2, "NOT": 1, "BYTE": 2,
+	"SHL": 2, "SHR": 2, "SAR": 2,
+}
+
+// session is the state of an interactive session.
+type session struct {
+	out     io.Writer
+	vars    map[string]*uint256.Int
+	history []*uint256.Int
+	signed  bool
+	wrap    bool
+	hex     bool
+}
+
+func newSession(out io.Writer) *session {
+	return &session{out: out, vars: make(map[string]*uint256.Int)}
+}
+
+// isTerminal reports whether r is an interactive terminal.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func repl(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) != 0 {
+		return errUsage
+	}
+	prompt := ""
+	if isTerminal(stdin) {
+		prompt = "> "
+		fmt.Fprintln(stdout, "uint256 calculator; :help for help, :quit to exit")
+	}
+	s := newSession(stdout)
+	in := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stdout, prompt)
+		if !in.Scan() {
+			break
+		}
+		quit, err := s.exec(in.Text())
+		if err != nil {
+			fmt.Fprintln(stderr, "error:", err)
+		}
+		if quit {
+			return nil
+		}
+	}
+	if prompt != "" {
+		fmt.Fprintln(stdout)
+	}
+	return in.Err()
+}
+
+// exec executes a line of input, and reports whether to quit.
+func (s *session) exec(line string) (bool, error) {
+	line = strings.TrimSpace(line)
+	switch {
+	case line == "":
+		return false, nil
+	case line[0] == ':':
+		return s.command(strings.Fields(line[1:]))
+	}
+	fields := strings.Fields(line)
+	if _, ok := opcodeArity[fields[0]]; ok {
+		z, err := s.opcode(fields[0], fields[1:])
+		if err != nil {
+			return false, err
+		}
+		s.result(z)
+		return false, nil
+	}
+	name, expr := "", line
+	if i := strings.IndexByte(line, '='); i > 0 {
+		name, expr = strings.TrimSpace(line[:i]), line[i+1:]
+		if !isIdentifier(name) || strings.HasPrefix(name, "_") {
+			return false, fmt.Errorf("invalid variable name %q", name)
+		}
+	}
+	z, err := s.eval(expr)
+	if err != nil {
+		return false, err
+	}
+	if name != "" {
+		s.vars[name] = z
+	}
+	s.result(z)
+	return false, nil
+}
+
+func isIdentifier(s string) bool {
+	for i, c := range s {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// eval evaluates expr with the session variables and mode.
+func (s *session) eval(expr string) (*uint256.Int, error) {
+	e, err := eval.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if s.wrap || s.signed {
+		return e.EvalWrapping(s.vars)
+	}
+	return e.Eval(s.vars)
+}
+
+// result records z in the history, and prints it.
+func (s *session) result(z *uint256.Int) {
+	s.history = append(s.history, z)
+	s.vars["_"] = z
+	s.vars["_"+strconv.Itoa(len(s.history))] = z
+	fmt.Fprintln(s.out, s.format(z))
+}
+
+// format formats z according to the display mode.
+func (s *session) format(z *uint256.Int) string {
+	switch {
+	case s.hex && s.signed:
+		return z.SHex()
+	case s.hex:
+		return z.ToHex()
+	case s.signed:
+		return z.SDec()
+	}
+	return z.Dec()
+}
+
+// command executes a :command.
+func (s *session) command(args []string) (bool, error) {
+	if len(args) == 0 {
+		return false, errors.New("missing command")
+	}
+	toggle := func(flag *bool) error {
+		if len(args) != 2 || (args[1] != "on" && args[1] != "off") {
+			return fmt.Errorf("usage: :%s on|off", args[0])
+		}
+		*flag = args[1] == "on"
+		return nil
+	}
+	switch args[0] {
+	case "signed":
+		return false, toggle(&s.signed)
+	case "wrap":
+		return false, toggle(&s.wrap)
+	case "hex":
+		return false, toggle(&s.hex)
+	case "vars":
+		names := make([]string, 0, len(s.vars))
+		for name := range s.vars {
+			if !strings.HasPrefix(name, "_") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(s.out, "%s = %s\n", name, s.format(s.vars[name]))
+		}
+	case "history":
+		for i, z := range s.history {
+			fmt.Fprintf(s.out, "_%d = %s\n", i+1, s.format(z))
+		}
+	case "help":
+		fmt.Fprint(s.out, replHelp)
+	case "quit", "q", "exit":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown command :%s", args[0])
+	}
+	return false, nil
+}
+
+// opcode computes the EVM opcode op on the operand expressions args.
+func (s *session) opcode(op string, args []string) (*uint256.Int, error) {
+	if len(args) != opcodeArity[op] {
+		return nil, fmt.Errorf("%s takes %d operands, have %d", op, opcodeArity[op], len(args))
+	}
+	xs := make([]*uint256.Int, len(args))
+	for i, arg := range args {
+		x, err := s.eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		xs[i] = x
+	}
+	// The operands are fresh copies, so Sdiv and Smod, which modify their
+	// arguments, do no harm.
+	z := new(uint256.Int)
+	x := xs[0]
+	var y *uint256.Int
+	if len(xs) > 1 {
+		y = xs[1]
+	}
+	// shift returns the shift amount x, saturated at 256.
+	shift := func() uint {
+		if x.LtUint64(256) {
+			return uint(x.Uint64())
+		}
+		return 256
+	}
+	switch op {
+	case "ADD":
+		z.Add(x, y)
+	case "MUL":
+		z.Mul(x, y)
+	case "SUB":
+		z.Sub(x, y)
+	case "DIV":
+		z.Div(x, y)
+	case "SDIV":
+		z.Sdiv(x, y)
+	case "MOD":
+		z.Mod(x, y)
+	case "SMOD":
+		z.Smod(x, y)
+	case "ADDMOD", "MULMOD":
+		if xs[2].IsZero() {
+			break
+		}
+		if op == "ADDMOD" {
+			z.AddMod(x, y, xs[2])
+		} else {
+			z.MulMod(x, y, xs[2])
+		}
+	case "EXP":
+		z.Exp(x, y)
+	case "SIGNEXTEND":
+		z.ExtendSign(y, x)
+	case "LT":
+		z.SetBool(x.Lt(y))
+	case "GT":
+		z.SetBool(x.Gt(y))
+	case "SLT":
+		z.SetBool(x.Slt(y))
+	case "SGT":
+		z.SetBool(x.Sgt(y))
+	case "EQ":
+		z.SetBool(x.Eq(y))
+	case "ISZERO":
+		z.SetBool(x.IsZero())
+	case "AND":
+		z.And(x, y)
+	case "OR":
+		z.Or(x, y)
+	case "XOR":
+		z.Xor(x, y)
+	case "NOT":
+		z.NotOf(x)
+	case "BYTE":
+		z.ByteOf(x, y)
+	case "SHL":
+		z.Lsh(y, shift())
+	case "SHR":
+		z.Rsh(y, shift())
+	case "SAR":
+		if n := shift(); n < 256 {
+			z.Srsh(y, n)
+		} else if y.IsNegative() {
+			z.SetAllOne()
+		} else {
+			z.Clear()
+		}
+	}
+	return z, nil
+}
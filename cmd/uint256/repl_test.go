@@ -0,0 +1,112 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRepl(t *testing.T) {
+	script := `
+x = 2**8
+x + 1
+_ * 2
+y = _1 - 1
+:vars
+SUB 1 2
+:signed on
+SUB 1 2
+-7 / 2
+SDIV -7 2
+SMOD -7 2
+SAR 1 -8
+SAR 300 -8
+SLT -1 0
+SIGNEXTEND 0 0xff
+BYTE 31 0x1234
+:hex on
+SDIV -7 2
+:signed off
+SHL 4 x
+0 - 1
+:hex off
+ADDMOD 1 2 0
+:history
+:quit
+ADD 1 1
+`
+	want := `256
+257
+514
+255
+x = 256
+y = 255
+115792089237316195423570985008687907853269984665640564039457584007913129639935
+-1
+57896044618658097711785492504343953926634992332820282019728792003956564819964
+-3
+-1
+-4
+-1
+1
+-1
+52
+-0x3
+0x1000
+0
+_1 = 256
+_2 = 257
+_3 = 514
+_4 = 255
+_5 = 115792089237316195423570985008687907853269984665640564039457584007913129639935
+_6 = 115792089237316195423570985008687907853269984665640564039457584007913129639935
+_7 = 57896044618658097711785492504343953926634992332820282019728792003956564819964
+_8 = 115792089237316195423570985008687907853269984665640564039457584007913129639933
+_9 = 115792089237316195423570985008687907853269984665640564039457584007913129639935
+_10 = 115792089237316195423570985008687907853269984665640564039457584007913129639932
+_11 = 115792089237316195423570985008687907853269984665640564039457584007913129639935
+_12 = 1
+_13 = 115792089237316195423570985008687907853269984665640564039457584007913129639935
+_14 = 52
+_15 = 115792089237316195423570985008687907853269984665640564039457584007913129639933
+_16 = 4096
+_17 = 0
+`
+	var stdout, stderr bytes.Buffer
+	if status := run([]string{"repl"}, strings.NewReader(script), &stdout, &stderr); status != 0 {
+		t.Fatalf("status %d, stderr %q", status, stderr.String())
+	}
+	if stdout.String() != want {
+		t.Errorf("have\n%s\nwant\n%s", stdout.String(), want)
+	}
+	if want := "error: eval: overflow at offset 2 in \"0 - 1\"\n"; stderr.String() != want {
+		t.Errorf("stderr: have %q, want %q", stderr.String(), want)
+	}
+}
+
+func TestReplErrors(t *testing.T) {
+	for _, line := range []string{
+		"1 +",
+		"z + 1",
+		"_x = 1",
+		"2x = 1",
+		"ADD 1",
+		":signed maybe",
+		":frobnicate",
+		":",
+	} {
+		var out bytes.Buffer
+		s := newSession(&out)
+		if _, err := s.exec(line); err == nil {
+			t.Errorf("%q: no error", line)
+		}
+		if len(s.history) != 0 {
+			t.Errorf("%q: result recorded", line)
+		}
+	}
+}
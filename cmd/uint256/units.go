@@ -0,0 +1,88 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+// unitDecimals maps the ether denominations to their number of decimals.
+var unitDecimals = map[string]int{
+	"wei":    0,
+	"kwei":   3,
+	"mwei":   6,
+	"gwei":   9,
+	"szabo":  12,
+	"finney": 15,
+	"ether":  18,
+}
+
+// parseAmount parses the decimal amount s, which may have a fraction of at
+// most decimals digits, scaled by 10**decimals.
+func parseAmount(s string, decimals int) (*uint256.Int, error) {
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], strings.TrimRight(s[i+1:], "0")
+	}
+	if len(frac) > decimals {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+	}
+	digits := strings.TrimLeft(whole+frac+strings.Repeat("0", decimals-len(frac)), "0")
+	if digits == "" {
+		digits = "0"
+	}
+	x, err := uint256.FromDecimal(digits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %v", s, err)
+	}
+	return x, nil
+}
+
+// formatAmount formats x scaled down by 10**decimals, without trailing
+// zeros in the fraction.
+func formatAmount(x *uint256.Int, decimals int) string {
+	s := x.Dec()
+	if decimals == 0 {
+		return s
+	}
+	if len(s) <= decimals {
+		s = strings.Repeat("0", decimals-len(s)+1) + s
+	}
+	whole, frac := s[:len(s)-decimals], strings.TrimRight(s[len(s)-decimals:], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+func units(args []string, stdout, stderr io.Writer) error {
+	fs := newFlagSet("units", stderr)
+	from := fs.String("from", "ether", "`unit` of the amounts")
+	to := fs.String("to", "wei", "`unit` to convert to")
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 {
+		return errUsage
+	}
+	fromDecimals, ok := unitDecimals[*from]
+	if !ok {
+		return fmt.Errorf("unknown unit %q", *from)
+	}
+	toDecimals, ok := unitDecimals[*to]
+	if !ok {
+		return fmt.Errorf("unknown unit %q", *to)
+	}
+	for _, arg := range fs.Args() {
+		wei, err := parseAmount(arg, fromDecimals)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, formatAmount(wei, toDecimals))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+var errFixedSizeBinary = errors.New("uint256: fixed-size binary data must be a multiple of 32 bytes")
+
+// Column is a columnar buffer of Ints, stored as one contiguous slab of
+// 4*Len() little-endian limbs. Reads and writes copy into and out of
+// caller-provided Ints, so iterating over a column does not allocate.
+// The zero value is an empty column ready to use.
+type Column struct {
+	words []uint64
+}
+
+// NewColumn returns an empty column with room for capacity values.
+func NewColumn(capacity int) *Column {
+	return &Column{words: make([]uint64, 0, 4*capacity)}
+}
+
+// Len returns the number of values in c.
+func (c *Column) Len() int {
+	return len(c.words) / 4
+}
+
+// Get sets z to the i'th value of c, and returns z.
+func (c *Column) Get(i int, z *Int) *Int {
+	w := c.words[4*i : 4*i+4]
+	z[0], z[1], z[2], z[3] = w[0], w[1], w[2], w[3]
+	return z
+}
+
+// Set sets the i'th value of c to x.
+func (c *Column) Set(i int, x *Int) {
+	w := c.words[4*i : 4*i+4]
+	w[0], w[1], w[2], w[3] = x[0], x[1], x[2], x[3]
+}
+
+// Append appends x to c.
+func (c *Column) Append(x *Int) {
+	c.words = append(c.words, x[0], x[1], x[2], x[3])
+}
+
+// Reset empties c, retaining its storage.
+func (c *Column) Reset() {
+	c.words = c.words[:0]
+}
+
+// Words returns the underlying slab of c, with value i held in the limbs
+// [4*i, 4*i+4), least significant first. The slice aliases the storage of c.
+func (c *Column) Words() []uint64 {
+	return c.words
+}
+
+// AppendFixedSizeBinary appends the values of c to dst as contiguous 32-byte
+// elements with the given byte order, which is the layout of the values
+// buffer of an Apache Arrow FixedSizeBinary(32) array.
+func (c *Column) AppendFixedSizeBinary(dst []byte, order Endianness) []byte {
+	n := len(dst)
+	dst = append(dst, make([]byte, 8*len(c.words))...)
+	out := dst[n:]
+	if order == LittleEndian {
+		for i, w := range c.words {
+			binary.LittleEndian.PutUint64(out[8*i:], w)
+		}
+		return dst
+	}
+	for i := 0; i < len(c.words); i += 4 {
+		b := out[8*i : 8*i+32]
+		binary.BigEndian.PutUint64(b[0:8], c.words[i+3])
+		binary.BigEndian.PutUint64(b[8:16], c.words[i+2])
+		binary.BigEndian.PutUint64(b[16:24], c.words[i+1])
+		binary.BigEndian.PutUint64(b[24:32], c.words[i])
+	}
+	return dst
+}
+
+// AppendFromFixedSizeBinary appends the 32-byte elements of data, in the
+// given byte order, to c. This imports the values buffer of an Apache Arrow
+// FixedSizeBinary(32) array; null slots (per the validity bitmap, which is not
+// consulted here) are imported as whatever bytes the buffer holds, usually 0.
+func (c *Column) AppendFromFixedSizeBinary(data []byte, order Endianness) error {
+	if len(data)%32 != 0 {
+		return errFixedSizeBinary
+	}
+	for ; len(data) > 0; data = data[32:] {
+		if order == LittleEndian {
+			c.words = append(c.words,
+				binary.LittleEndian.Uint64(data[0:8]),
+				binary.LittleEndian.Uint64(data[8:16]),
+				binary.LittleEndian.Uint64(data[16:24]),
+				binary.LittleEndian.Uint64(data[24:32]))
+		} else {
+			c.words = append(c.words,
+				binary.BigEndian.Uint64(data[24:32]),
+				binary.BigEndian.Uint64(data[16:24]),
+				binary.BigEndian.Uint64(data[8:16]),
+				binary.BigEndian.Uint64(data[0:8]))
+		}
+	}
+	return nil
+}
+
+// CmpVecBitmap returns a bitmap of the elements of a that are greater than
+// threshold: bit i%64 of word i/64 is set if a[i] > threshold. The bits past
+// len(a) in the last word are zero, so the words written out in little-endian
+// order form an Apache Arrow validity bitmap. The comparison is computed
+// without branches, as the borrow of threshold - a[i].
+func CmpVecBitmap(a []Int, threshold *Int) []uint64 {
+	t := *threshold // threshold may be an element of a
+	bitmap := make([]uint64, (len(a)+63)/64)
+	for i := range a {
+		x := &a[i]
+		_, b := bits.Sub64(t[0], x[0], 0)
+		_, b = bits.Sub64(t[1], x[1], b)
+		_, b = bits.Sub64(t[2], x[2], b)
+		_, b = bits.Sub64(t[3], x[3], b)
+		bitmap[i/64] |= b << (uint(i) % 64)
+	}
+	return bitmap
+}
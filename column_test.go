@@ -0,0 +1,114 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColumn(t *testing.T) {
+	var c Column
+	var values []*Int
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		values = append(values, x)
+		c.Append(x)
+	}
+	if c.Len() != 100 || len(c.Words()) != 400 {
+		t.Fatalf("len: got %d (%d words)", c.Len(), len(c.Words()))
+	}
+	var z Int
+	for i, x := range values {
+		if !c.Get(i, &z).Eq(x) {
+			t.Fatalf("value %d: got %v, exp %v", i, z.Hex(), x.Hex())
+		}
+	}
+	c.Set(5, new(Int).SetUint64(7))
+	if c.Get(5, &z).Uint64() != 7 || !c.Get(6, &z).Eq(values[6]) {
+		t.Fatalf("set failed")
+	}
+	c.Set(5, values[5])
+
+	if n := testing.AllocsPerRun(100, func() {
+		for i := 0; i < c.Len(); i++ {
+			c.Get(i, &z)
+		}
+	}); n != 0 {
+		t.Errorf("Get allocates: %v", n)
+	}
+
+	for _, order := range []Endianness{LittleEndian, BigEndian} {
+		data := c.AppendFixedSizeBinary([]byte{0xff}, order)[1:]
+		if len(data) != 32*100 {
+			t.Fatalf("exported %d bytes", len(data))
+		}
+		for i, x := range values {
+			exp := x.Bytes32()
+			if order == LittleEndian {
+				for l, r := 0, 31; l < r; l, r = l+1, r-1 {
+					exp[l], exp[r] = exp[r], exp[l]
+				}
+			}
+			if !bytes.Equal(data[32*i:32*i+32], exp[:]) {
+				t.Fatalf("order %d, element %d: got %x, exp %x", order, i, data[32*i:32*i+32], exp)
+			}
+		}
+		d := NewColumn(100)
+		if err := d.AppendFromFixedSizeBinary(data, order); err != nil {
+			t.Fatal(err)
+		}
+		for i, x := range values {
+			if !d.Get(i, &z).Eq(x) {
+				t.Fatalf("order %d, import %d: got %v, exp %v", order, i, z.Hex(), x.Hex())
+			}
+		}
+	}
+	if err := c.AppendFromFixedSizeBinary(make([]byte, 33), BigEndian); err != errFixedSizeBinary {
+		t.Errorf("expected errFixedSizeBinary, got %v", err)
+	}
+	c.Reset()
+	if c.Len() != 0 {
+		t.Errorf("reset failed")
+	}
+}
+
+func TestCmpVecBitmap(t *testing.T) {
+	for _, n := range []int{0, 1, 63, 64, 65, 200} {
+		a := randSlice(n)
+		var threshold Int
+		if n > 0 {
+			threshold = a[n/2]
+			// Elements equal to or differing only in the low limb from the
+			// threshold.
+			a[0] = threshold
+			if n > 1 {
+				a[1] = threshold
+				a[1][0]++
+			}
+		}
+		bitmap := CmpVecBitmap(a, &threshold)
+		if len(bitmap) != (n+63)/64 {
+			t.Fatalf("n=%d: have %d words", n, len(bitmap))
+		}
+		for i := 0; i < 64*len(bitmap); i++ {
+			have := bitmap[i/64]>>(uint(i)%64)&1 == 1
+			want := i < n && a[i].Gt(&threshold)
+			if have != want {
+				t.Fatalf("n=%d: bit %d is %v, want %v", n, i, have, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCmpVecBitmap(b *testing.B) {
+	a := randSlice(4096)
+	threshold := a[0]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CmpVecBitmap(a, &threshold)
+	}
+}
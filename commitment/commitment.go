@@ -0,0 +1,113 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package commitment provides the scalar plumbing for commitment schemes,
+// such as Pedersen commitments, over pluggable group implementations, with
+// scalars represented as uint256.Int.
+package commitment
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+// Element is an element of a Group. Its concrete type is defined by the
+// Group implementation.
+type Element interface{}
+
+// Group is an abelian group of prime order, written additively, such as the
+// points of an elliptic curve.
+type Group interface {
+	// Identity returns the neutral element.
+	Identity() Element
+	// Add returns a + b.
+	Add(a, b Element) Element
+	// ScalarMul returns k * a. The scalar k is already reduced modulo the
+	// order of the group.
+	ScalarMul(a Element, k *uint256.Int) Element
+	// Equal reports whether a and b are the same element.
+	Equal(a, b Element) bool
+	// Order returns the order of the group.
+	Order() *uint256.Int
+}
+
+// Commitment is a commitment scheme to a vector of scalars, hidden by a
+// blinding factor.
+type Commitment interface {
+	// Commit returns the commitment to values, using the given blinding.
+	Commit(values []uint256.Int, blinding *uint256.Int) (Element, error)
+	// Verify reports whether c opens to values with the given blinding.
+	Verify(c Element, values []uint256.Int, blinding *uint256.Int) bool
+}
+
+var errTooManyValues = errors.New("commitment: more values than generators")
+
+// Accumulator computes multi-scalar products sum(k_i * P_i) over a Group,
+// reducing each scalar modulo the group order. The zero value is not usable;
+// create one with NewAccumulator.
+type Accumulator struct {
+	group Group
+	acc   Element
+}
+
+// NewAccumulator returns an accumulator over g, holding the identity.
+func NewAccumulator(g Group) *Accumulator {
+	return &Accumulator{group: g, acc: g.Identity()}
+}
+
+// MulAdd adds k * p to the accumulated sum.
+func (a *Accumulator) MulAdd(p Element, k *uint256.Int) {
+	var scalar uint256.Int
+	scalar.Mod(k, a.group.Order())
+	if scalar.IsZero() {
+		return
+	}
+	a.acc = a.group.Add(a.acc, a.group.ScalarMul(p, &scalar))
+}
+
+// Sum returns the accumulated sum.
+func (a *Accumulator) Sum() Element {
+	return a.acc
+}
+
+// Reset sets the accumulated sum back to the identity.
+func (a *Accumulator) Reset() {
+	a.acc = a.group.Identity()
+}
+
+// Pedersen is a Pedersen vector commitment C = sum(v_i * G_i) + r * H.
+// The generators must be independent: nobody may know the discrete logarithm
+// of any of them with respect to the others.
+type Pedersen struct {
+	Group Group
+	G     []Element // Generators for the committed values.
+	H     Element   // Generator for the blinding factor.
+}
+
+var _ Commitment = (*Pedersen)(nil)
+
+// Commit returns the commitment to values, using the given blinding.
+// At most len(p.G) values can be committed to.
+func (p *Pedersen) Commit(values []uint256.Int, blinding *uint256.Int) (Element, error) {
+	if len(values) > len(p.G) {
+		return nil, errTooManyValues
+	}
+	acc := NewAccumulator(p.Group)
+	for i := range values {
+		acc.MulAdd(p.G[i], &values[i])
+	}
+	acc.MulAdd(p.H, blinding)
+	return acc.Sum(), nil
+}
+
+// Verify reports whether c opens to values with the given blinding.
+func (p *Pedersen) Verify(c Element, values []uint256.Int, blinding *uint256.Int) bool {
+	exp, err := p.Commit(values, blinding)
+	if err != nil {
+		return false
+	}
+	return p.Group.Equal(c, exp)
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package commitment
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+// schnorrGroup is the order-q subgroup of the multiplicative group modulo the
+// safe prime p = 2q+1. It is far too small to be secure, but exercises the
+// plumbing.
+type schnorrGroup struct {
+	p, q uint256.Int
+}
+
+func (g *schnorrGroup) Identity() Element { return *new(uint256.Int).SetOne() }
+
+func (g *schnorrGroup) Add(a, b Element) Element {
+	x, y := a.(uint256.Int), b.(uint256.Int)
+	return *new(uint256.Int).MulMod(&x, &y, &g.p)
+}
+
+func (g *schnorrGroup) ScalarMul(a Element, k *uint256.Int) Element {
+	x := a.(uint256.Int)
+	return *new(uint256.Int).ExpMod(&x, k, &g.p)
+}
+
+func (g *schnorrGroup) Equal(a, b Element) bool {
+	x, y := a.(uint256.Int), b.(uint256.Int)
+	return x.Eq(&y)
+}
+
+func (g *schnorrGroup) Order() *uint256.Int { return &g.q }
+
+func newPedersen() *Pedersen {
+	g := &schnorrGroup{}
+	g.p.SetUint64(2039)
+	g.q.SetUint64(1019)
+	// Squares modulo p generate the subgroup of order q.
+	return &Pedersen{
+		Group: g,
+		G:     []Element{*new(uint256.Int).SetUint64(4), *new(uint256.Int).SetUint64(9)},
+		H:     *new(uint256.Int).SetUint64(25),
+	}
+}
+
+func TestPedersen(t *testing.T) {
+	p := newPedersen()
+	values := []uint256.Int{*new(uint256.Int).SetUint64(3), *new(uint256.Int).SetUint64(5)}
+	blinding := new(uint256.Int).SetUint64(7)
+	c, err := p.Commit(values, blinding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4^3 * 9^5 * 25^7 mod 2039
+	if got := c.(uint256.Int); got.Uint64() != 1108 {
+		t.Errorf("got %d exp 1108", got.Uint64())
+	}
+	if !p.Verify(c, values, blinding) {
+		t.Errorf("commitment did not verify")
+	}
+	if p.Verify(c, values, new(uint256.Int).SetUint64(8)) {
+		t.Errorf("commitment verified with wrong blinding")
+	}
+	// Scalars are reduced modulo the group order.
+	wrapped := []uint256.Int{*new(uint256.Int).SetUint64(3 + 1019), values[1]}
+	if !p.Verify(c, wrapped, blinding) {
+		t.Errorf("commitment did not verify with unreduced scalars")
+	}
+	if _, err := p.Commit(make([]uint256.Int, 3), blinding); err != errTooManyValues {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestHomomorphic(t *testing.T) {
+	p := newPedersen()
+	v1 := []uint256.Int{*new(uint256.Int).SetUint64(10), *new(uint256.Int).SetUint64(1000)}
+	v2 := []uint256.Int{*new(uint256.Int).SetUint64(20), *new(uint256.Int).SetUint64(100)}
+	r1, r2 := new(uint256.Int).SetUint64(11), new(uint256.Int).SetUint64(1018)
+	c1, _ := p.Commit(v1, r1)
+	c2, _ := p.Commit(v2, r2)
+	// C(v1) + C(v2) == C(v1 + v2), with blinding r1 + r2.
+	sum := make([]uint256.Int, 2)
+	for i := range sum {
+		sum[i].Add(&v1[i], &v2[i])
+	}
+	r := new(uint256.Int).Add(r1, r2)
+	if !p.Verify(p.Group.Add(c1, c2), sum, r) {
+		t.Errorf("commitments are not additively homomorphic")
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	p := newPedersen()
+	acc := NewAccumulator(p.Group)
+	if !p.Group.Equal(acc.Sum(), p.Group.Identity()) {
+		t.Fatalf("new accumulator is not the identity")
+	}
+	acc.MulAdd(p.G[0], new(uint256.Int).SetUint64(2))
+	acc.MulAdd(p.G[0], new(uint256.Int).SetUint64(3))
+	acc.MulAdd(p.G[1], new(uint256.Int))
+	if got := acc.Sum().(uint256.Int); got.Uint64() != 4*4*4*4*4%2039 {
+		t.Errorf("got %d", got.Uint64())
+	}
+	acc.Reset()
+	if !p.Group.Equal(acc.Sum(), p.Group.Identity()) {
+		t.Errorf("reset accumulator is not the identity")
+	}
+}
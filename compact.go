@@ -0,0 +1,89 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// PutCompact returns z in the Bitcoin "compact" (nBits) representation: the
+// top byte is the length of z in bytes, and the low 23 bits are the three most
+// significant bytes of z. Precision below those three bytes is lost.
+func (z *Int) PutCompact() uint32 {
+	z = orZero(z)
+	size := uint32(z.ByteLen())
+	var compact uint32
+	if size <= 3 {
+		compact = uint32(z[0] << (8 * (3 - size)))
+	} else {
+		var t Int
+		compact = uint32(t.Rsh(z, uint(8*(size-3)))[0])
+	}
+	// The 0x00800000 bit denotes the sign, so if it is set, divide the
+	// mantissa by 256 and increase the exponent instead.
+	if compact&0x00800000 != 0 {
+		compact >>= 8
+		size++
+	}
+	return compact | size<<24
+}
+
+// SetCompact sets z to the value of the Bitcoin "compact" (nBits) encoding,
+// and reports whether the encoding had the sign bit set, or denoted a value
+// which does not fit in 256 bits. In the latter case, z is set to the lower
+// 256 bits of the value.
+func (z *Int) SetCompact(compact uint32) (negative, overflow bool) {
+	if z == nil {
+		panic(nilReceiver("SetCompact"))
+	}
+	size := compact >> 24
+	word := uint64(compact & 0x007fffff)
+	if size <= 3 {
+		word >>= 8 * (3 - size)
+		z.SetUint64(word)
+	} else {
+		z.Lsh(z.SetUint64(word), uint(8*(size-3)))
+	}
+	negative = word != 0 && compact&0x00800000 != 0
+	overflow = word != 0 && (size > 34 ||
+		(word > 0xff && size > 33) ||
+		(word > 0xffff && size > 32))
+	return negative, overflow
+}
+
+// ParseCompact is a convenience-constructor for SetCompact.
+// Returns a new Int and whether the encoding was negative or overflowed.
+func ParseCompact(compact uint32) (z *Int, negative, overflow bool) {
+	z = new(Int)
+	negative, overflow = z.SetCompact(compact)
+	return z, negative, overflow
+}
+
+// TargetToDifficulty sets z to the difficulty corresponding to target,
+// relative to the difficulty-1 target limit, i.e. limit / target.
+// If target == 0, z is set to 0.
+func (z *Int) TargetToDifficulty(limit, target *Int) *Int {
+	return z.Div(limit, target)
+}
+
+// DifficultyToTarget sets z to the target corresponding to difficulty,
+// relative to the difficulty-1 target limit, i.e. limit / difficulty.
+// If difficulty == 0, z is set to 0.
+func (z *Int) DifficultyToTarget(limit, difficulty *Int) *Int {
+	return z.Div(limit, difficulty)
+}
+
+// Work sets z to the expected number of hashes required to find a block
+// hash not exceeding target, i.e. 2**256 / (target+1), and returns z.
+// If target is the maximum value, z is set to 1. If target == 0, the work is
+// 2**256, which wraps around, and z is set to 0.
+func (z *Int) Work(target *Int) *Int {
+	// 2**256 is not representable, but 2**256 / (target+1) equals
+	// (2**256 - target - 1) / (target+1) + 1, where the numerator is ^target.
+	var denom, num Int
+	if denom.IncOverflow(target) {
+		return z.SetOne()
+	}
+	num.NotOf(target)
+	z.Div(&num, &denom)
+	return z.AddOne(z)
+}
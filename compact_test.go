@@ -0,0 +1,105 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	// Test vectors from Bitcoin Core's arith_uint256_tests.
+	var tests = []struct {
+		compact  uint32
+		value    string
+		encoded  uint32
+		negative bool
+		overflow bool
+	}{
+		{0x00000000, "0", 0x00000000, false, false},
+		{0x00123456, "0", 0x00000000, false, false},
+		{0x01003456, "0", 0x00000000, false, false},
+		{0x02000056, "0", 0x00000000, false, false},
+		{0x03000000, "0", 0x00000000, false, false},
+		{0x04000000, "0", 0x00000000, false, false},
+		{0x00923456, "0", 0x00000000, false, false},
+		{0x01803456, "0", 0x00000000, false, false},
+		{0x02800056, "0", 0x00000000, false, false},
+		{0x03800000, "0", 0x00000000, false, false},
+		{0x04800000, "0", 0x00000000, false, false},
+		{0x01123456, "12", 0x01120000, false, false},
+		{0x01fedcba, "7e", 0x017e0000, true, false},
+		{0x02123456, "1234", 0x02123400, false, false},
+		{0x03123456, "123456", 0x03123456, false, false},
+		{0x04123456, "12345600", 0x04123456, false, false},
+		{0x04923456, "12345600", 0x04123456, true, false},
+		{0x05009234, "92340000", 0x05009234, false, false},
+		{0x20123456, "1234560000000000000000000000000000000000000000000000000000000000", 0x20123456, false, false},
+		{0x1d00ffff, "ffff0000000000000000000000000000000000000000000000000000", 0x1d00ffff, false, false},
+		{0xff123456, "0", 0, false, true},
+	}
+	for i, tt := range tests {
+		z, negative, overflow := ParseCompact(tt.compact)
+		if negative != tt.negative || overflow != tt.overflow {
+			t.Errorf("test %d: got negative=%v overflow=%v, exp %v %v", i, negative, overflow, tt.negative, tt.overflow)
+		}
+		if overflow {
+			continue
+		}
+		exp, _ := new(big.Int).SetString(tt.value, 16)
+		requireEq(t, exp, z, tt.value)
+		if got := z.PutCompact(); got != tt.encoded {
+			t.Errorf("test %d: got compact %#08x, exp %#08x", i, got, tt.encoded)
+		}
+	}
+}
+
+func TestCompactRoundTrip(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		compact := x.PutCompact()
+		z, negative, overflow := ParseCompact(compact)
+		if negative || overflow {
+			t.Fatalf("%v: compact %#08x is negative=%v overflow=%v", x.Hex(), compact, negative, overflow)
+		}
+		// The encoding keeps the top bytes, and never exceeds the input.
+		if z.Gt(x) {
+			t.Fatalf("%v: compact value %v is larger", x.Hex(), z.Hex())
+		}
+		if z.PutCompact() != compact {
+			t.Fatalf("%v: compact %#08x not stable", x.Hex(), compact)
+		}
+	}
+}
+
+func TestDifficulty(t *testing.T) {
+	limit, _, _ := ParseCompact(0x1d00ffff)
+	// Block 100000 of the bitcoin chain.
+	target, _, _ := ParseCompact(0x1b04864c)
+	diff := new(Int).TargetToDifficulty(limit, target)
+	if diff.Uint64() != 14484 {
+		t.Errorf("got difficulty %d, exp 14484", diff.Uint64())
+	}
+	if got := new(Int).DifficultyToTarget(limit, new(Int).SetOne()); !got.Eq(limit) {
+		t.Errorf("got target %v, exp %v", got.Hex(), limit.Hex())
+	}
+	if got := new(Int).Work(limit); got.Uint64() != 0x100010001 || !got.IsUint64() {
+		t.Errorf("got work %v, exp 0x100010001", got.Hex())
+	}
+	if got := new(Int).Work(new(Int).SetAllOne()); !got.IsOne() {
+		t.Errorf("got work %v, exp 1", got.Hex())
+	}
+	if got := new(Int).Work(new(Int)); !got.IsZero() {
+		// 2**256 wraps to zero.
+		t.Errorf("got work %v, exp 0", got.Hex())
+	}
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randNums()
+		exp := new(big.Int).Div(bigtt256, new(big.Int).Add(b, big.NewInt(1)))
+		exp.Mod(exp, bigtt256)
+		requireEq(t, exp, new(Int).Work(x), "work")
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Encodings used by Compress, stored in the first byte of the output.
+const (
+	compressDelta byte = iota // sorted: first value, then uvarint deltas
+	compressFOR               // frame of reference: minimum, then uvarint offsets
+)
+
+var errCompressed = errors.New("uint256: invalid compressed data")
+
+// Compress encodes values compactly, for snapshotting large tables of
+// balances, which are mostly far smaller than 2**256. Sorted (non-decreasing)
+// input is delta encoded; otherwise each value is stored as its offset from
+// the minimum (frame-of-reference encoding). Either way the numbers are
+// written as uvarints, so small deltas or offsets take only a byte or two,
+// instead of 32 bytes per value.
+func Compress(values []Int) []byte {
+	sorted := true
+	min := 0
+	for i := 1; i < len(values); i++ {
+		if values[i].Lt(&values[i-1]) {
+			sorted = false
+		}
+		if values[i].Lt(&values[min]) {
+			min = i
+		}
+	}
+	var d Int
+	out := make([]byte, 1, 1+binary.MaxVarintLen64+len(values)*2)
+	out = d.SetUint64(uint64(len(values))).AppendUvarint(out)
+	if len(values) == 0 {
+		return out
+	}
+	if sorted {
+		out[0] = compressDelta
+		out = values[0].AppendUvarint(out)
+		for i := 1; i < len(values); i++ {
+			out = d.Sub(&values[i], &values[i-1]).AppendUvarint(out)
+		}
+		return out
+	}
+	out[0] = compressFOR
+	ref := &values[min]
+	out = ref.AppendUvarint(out)
+	for i := range values {
+		out = d.Sub(&values[i], ref).AppendUvarint(out)
+	}
+	return out
+}
+
+// Decompress decodes the output of Compress.
+func Decompress(data []byte) ([]Int, error) {
+	if len(data) == 0 {
+		return nil, errCompressed
+	}
+	mode := data[0]
+	count, n := binary.Uvarint(data[1:])
+	// Every value takes at least one byte, which bounds the allocation.
+	if n <= 0 || count > uint64(len(data)) || mode > compressFOR {
+		return nil, errCompressed
+	}
+	data = data[1+n:]
+	values := make([]Int, count)
+	if count == 0 {
+		if len(data) != 0 {
+			return nil, errCompressed
+		}
+		return values, nil
+	}
+	next := func(z *Int) bool {
+		n := z.SetUvarint(data)
+		if n <= 0 {
+			return false
+		}
+		data = data[n:]
+		return true
+	}
+	var ref, d Int
+	if !next(&ref) {
+		return nil, errCompressed
+	}
+	if mode == compressDelta {
+		values[0] = ref
+		for i := 1; i < len(values); i++ {
+			if !next(&d) || values[i].AddOverflow(&values[i-1], &d) {
+				return nil, errCompressed
+			}
+		}
+	} else {
+		for i := range values {
+			if !next(&d) || values[i].AddOverflow(&ref, &d) {
+				return nil, errCompressed
+			}
+		}
+	}
+	if len(data) != 0 {
+		return nil, errCompressed
+	}
+	return values, nil
+}
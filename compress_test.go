@@ -0,0 +1,80 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func testCompressRoundTrip(t *testing.T, values []Int) []byte {
+	t.Helper()
+	enc := Compress(values)
+	dec, err := Decompress(enc)
+	if err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+	if len(dec) != len(values) {
+		t.Fatalf("got %d values, exp %d", len(dec), len(values))
+	}
+	for i := range values {
+		if !dec[i].Eq(&values[i]) {
+			t.Fatalf("value %d: got %v, exp %v", i, dec[i].Hex(), values[i].Hex())
+		}
+	}
+	return enc
+}
+
+func TestCompress(t *testing.T) {
+	testCompressRoundTrip(t, nil)
+	testCompressRoundTrip(t, []Int{{}})
+	testCompressRoundTrip(t, []Int{*new(Int).SetAllOne(), {}, *new(Int).SetAllOne()})
+
+	// Balances around 10**21 wei, unsorted: frame of reference.
+	rng := rand.New(rand.NewSource(1))
+	base := Int{0x35c9adc5dea00000, 0x36} // 10**21
+	values := make([]Int, 1000)
+	for i := range values {
+		values[i].Add(&base, new(Int).SetUint64(uint64(rng.Int63n(1<<40))))
+	}
+	enc := testCompressRoundTrip(t, values)
+	if enc[0] != compressFOR || len(enc) > 1000*7 {
+		t.Errorf("frame of reference: mode %d, %d bytes", enc[0], len(enc))
+	}
+	// Sorted: delta encoding.
+	sort.Slice(values, func(i, j int) bool { return values[i].Lt(&values[j]) })
+	enc = testCompressRoundTrip(t, values)
+	if enc[0] != compressDelta || len(enc) > 1000*5 {
+		t.Errorf("delta: mode %d, %d bytes", enc[0], len(enc))
+	}
+	// Random 256-bit values still round trip.
+	for i := range values {
+		_, x, _ := randNums()
+		values[i] = *x
+	}
+	testCompressRoundTrip(t, values)
+}
+
+func TestDecompressErrors(t *testing.T) {
+	valid := Compress([]Int{{1}, {2}, {3}})
+	overflow := append([]byte{compressDelta, 2}, new(Int).SetAllOne().AppendUvarint(nil)...)
+	overflow = append(overflow, 1)
+	for i, data := range [][]byte{
+		nil,
+		{compressDelta},
+		{2, 0},                   // unknown mode
+		{compressDelta, 0, 0},    // trailing data
+		{compressDelta, 5, 1, 1}, // count too large
+		valid[:len(valid)-1],     // truncated
+		append(valid, 0),         // trailing data
+		overflow,
+	} {
+		if _, err := Decompress(data); err != errCompressed {
+			t.Errorf("case %d: expected errCompressed, got %v", i, err)
+		}
+	}
+}
@@ -8,6 +8,7 @@
 package uint256
 
 import (
+	"math"
 	"math/big"
 	"math/bits"
 )
@@ -19,6 +20,9 @@ const (
 // ToBig returns a big.Int version of z.
 func (z *Int) ToBig() *big.Int {
 	b := new(big.Int)
+	if z == nil {
+		return b
+	}
 	switch maxWords { // Compile-time check.
 	case 4: // 64-bit architectures.
 		words := [4]big.Word{big.Word(z[0]), big.Word(z[1]), big.Word(z[2]), big.Word(z[3])}
@@ -48,6 +52,9 @@ func FromBig(b *big.Int) (*Int, bool) {
 // SetFromBig converts a big.Int to Int and sets the value to z.
 // TODO: Ensure we have sufficient testing, esp for negative bigints.
 func (z *Int) SetFromBig(b *big.Int) bool {
+	if z == nil {
+		panic(nilReceiver("SetFromBig"))
+	}
 	z.Clear()
 	words := b.Bits()
 	overflow := len(words) > maxWords
@@ -83,7 +90,53 @@ func (z *Int) SetFromBig(b *big.Int) bool {
 	}
 
 	if b.Sign() == -1 {
-		z.Neg()
+		z.NegOf(z)
 	}
 	return overflow
 }
+
+// AsUint64 returns z as a uint64, and whether it fits; if it does not, the
+// value returned is 0 rather than the truncated low bits.
+func (z *Int) AsUint64() (uint64, bool) {
+	z = orZero(z)
+	if !z.IsUint64() {
+		return 0, false
+	}
+	return z[0], true
+}
+
+// AsInt64 returns z as an int64, and whether it fits, i.e. z <= math.MaxInt64.
+// If it does not, the value returned is 0.
+func (z *Int) AsInt64() (int64, bool) {
+	z = orZero(z)
+	if !z.IsUint64() || z[0] > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(z[0]), true
+}
+
+// AsUint32 returns z as a uint32, and whether it fits. If it does not, the
+// value returned is 0.
+func (z *Int) AsUint32() (uint32, bool) {
+	z = orZero(z)
+	if !z.IsUint64() || z[0] > math.MaxUint32 {
+		return 0, false
+	}
+	return uint32(z[0]), true
+}
+
+// AsInt returns z as an int, and whether it fits, which depends on the size
+// of int on the platform. If it does not, the value returned is 0.
+func (z *Int) AsInt() (int, bool) {
+	z = orZero(z)
+	if !z.IsUint64() || z[0] > math.MaxInt64>>(64-bits.UintSize) {
+		return 0, false
+	}
+	return int(z[0]), true
+}
+
+// AsBigInt returns z as a big.Int. Every Int fits, so ok is always true; it
+// is provided so that all the As conversions can be used the same way.
+func (z *Int) AsBigInt() (b *big.Int, ok bool) {
+	return z.ToBig(), true
+}
@@ -7,7 +7,9 @@ package uint256
 
 import (
 	"bytes"
+	"math"
 	"math/big"
+	"math/bits"
 	"testing"
 )
 
@@ -149,3 +151,39 @@ func BenchmarkToBig(bench *testing.B) {
 	param4 := new(Int).Lsh(param3, 64)
 	bench.Run("4words", func(bench *testing.B) { benchmarkToBig(bench, param4) })
 }
+
+func TestAsConversions(t *testing.T) {
+	for _, tc := range []struct {
+		x      *Int
+		u64    bool
+		i64    bool
+		u32    bool
+		i      bool
+		i64Val int64
+	}{
+		{new(Int), true, true, true, true, 0},
+		{new(Int).SetUint64(math.MaxUint32), true, true, true, bits.UintSize == 64, math.MaxUint32},
+		{new(Int).SetUint64(math.MaxUint32 + 1), true, true, false, bits.UintSize == 64, math.MaxUint32 + 1},
+		{new(Int).SetUint64(math.MaxInt64), true, true, false, bits.UintSize == 64, math.MaxInt64},
+		{new(Int).SetUint64(math.MaxInt64 + 1), true, false, false, false, 0},
+		{new(Int).SetUint64(math.MaxUint64), true, false, false, false, 0},
+		{&Int{0, 1}, false, false, false, false, 0},
+		{new(Int).SetAllOne(), false, false, false, false, 0},
+	} {
+		if v, ok := tc.x.AsUint64(); ok != tc.u64 || (ok && v != tc.x[0]) || (!ok && v != 0) {
+			t.Errorf("AsUint64(%v): got %d, %v", tc.x.Hex(), v, ok)
+		}
+		if v, ok := tc.x.AsInt64(); ok != tc.i64 || v != tc.i64Val {
+			t.Errorf("AsInt64(%v): got %d, %v", tc.x.Hex(), v, ok)
+		}
+		if v, ok := tc.x.AsUint32(); ok != tc.u32 || (ok && uint64(v) != tc.x[0]) || (!ok && v != 0) {
+			t.Errorf("AsUint32(%v): got %d, %v", tc.x.Hex(), v, ok)
+		}
+		if v, ok := tc.x.AsInt(); ok != tc.i || (ok && uint64(v) != tc.x[0]) || (!ok && v != 0) {
+			t.Errorf("AsInt(%v): got %d, %v", tc.x.Hex(), v, ok)
+		}
+		if b, ok := tc.x.AsBigInt(); !ok || b.Cmp(tc.x.ToBig()) != 0 {
+			t.Errorf("AsBigInt(%v): got %v, %v", tc.x.Hex(), b, ok)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"sync"
+)
+
+// errCounterOverflow is returned by Counter when it would wrap.
+var errCounterOverflow = fmt.Errorf("%w: counter overflows 256 bits", ErrOutOfRange)
+
+// Counter generates sequence numbers, such as nonces, which must never wrap
+// around silently: an increment that would overflow fails with an error
+// wrapping ErrOutOfRange, and leaves the counter unchanged. It is safe for
+// concurrent use. The zero value starts at zero.
+type Counter struct {
+	mu         sync.Mutex
+	value      Int
+	onOverflow func(value, delta *Int)
+}
+
+// NewCounter returns a Counter starting at start.
+func NewCounter(start *Int) *Counter {
+	return &Counter{value: *start}
+}
+
+// OnOverflow sets f to be called, without holding the lock of c, with the
+// value and the increment of each failed increment, e.g. to raise an alert.
+// A nil f removes it.
+func (c *Counter) OnOverflow(f func(value, delta *Int)) {
+	c.mu.Lock()
+	c.onOverflow = f
+	c.mu.Unlock()
+}
+
+// Value returns the current value of c, which Next returns next.
+func (c *Counter) Value() *Int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value.Clone()
+}
+
+// Next returns the current value of c, and increments it. Since c cannot be
+// incremented past 2**256 - 1, that value is never returned.
+func (c *Counter) Next() (*Int, error) {
+	return c.Add(&Int{1})
+}
+
+// Add returns the current value of c, and adds delta to it.
+func (c *Counter) Add(delta *Int) (*Int, error) {
+	c.mu.Lock()
+	old := c.value
+	if c.value.AddOverflow(&old, delta) {
+		c.value = old
+		f := c.onOverflow
+		c.mu.Unlock()
+		if f != nil {
+			f(&old, delta.Clone())
+		}
+		return nil, errCounterOverflow
+	}
+	c.mu.Unlock()
+	return &old, nil
+}
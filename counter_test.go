@@ -0,0 +1,89 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestCounter(t *testing.T) {
+	var c Counter
+	for want := uint64(0); want < 3; want++ {
+		v, err := c.Next()
+		if err != nil || v.Uint64() != want {
+			t.Fatalf("Next: have %v %v, want %d", v, err, want)
+		}
+	}
+	if v, err := c.Add(NewInt().SetUint64(10)); err != nil || v.Uint64() != 3 {
+		t.Errorf("Add: have %v %v, want 3", v, err)
+	}
+	if v := c.Value(); v.Uint64() != 13 {
+		t.Errorf("Value: have %v, want 13", v)
+	}
+}
+
+func TestCounterOverflow(t *testing.T) {
+	max := new(Int).SetAllOne()
+	c := NewCounter(new(Int).Sub(max, NewInt().SetOne()))
+	var (
+		calls        int
+		value, delta *Int
+	)
+	c.OnOverflow(func(v, d *Int) {
+		calls++
+		value, delta = v, d
+	})
+	if v, err := c.Next(); err != nil || !v.Eq(new(Int).Sub(max, NewInt().SetOne())) {
+		t.Fatalf("Next: have %v %v", v, err)
+	}
+	if v, err := c.Next(); !errors.Is(err, ErrOutOfRange) || v != nil {
+		t.Errorf("Next at max: have %v %v, want %v", v, err, errCounterOverflow)
+	}
+	if calls != 1 || !value.Eq(max) || !delta.IsOne() {
+		t.Errorf("callback: have %d calls with %v %v", calls, value, delta)
+	}
+	if !c.Value().Eq(max) {
+		t.Errorf("failed Next changed the counter to %v", c.Value())
+	}
+	c.OnOverflow(nil)
+	if _, err := c.Add(max); err == nil {
+		t.Errorf("Add: no error")
+	}
+	if calls != 1 {
+		t.Errorf("removed callback was called")
+	}
+}
+
+func TestCounterConcurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		nexts      = 500
+	)
+	var (
+		c    Counter
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[uint64]bool)
+	)
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < nexts; i++ {
+				v, _ := c.Next()
+				mu.Lock()
+				seen[v.Uint64()] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != goroutines*nexts {
+		t.Errorf("have %d distinct values, want %d", len(seen), goroutines*nexts)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "strings"
+
+// MarshalCSV implements the field marshaling interface of CSV struct codecs
+// such as gocsv, writing z as a decimal string. To write hex instead, declare
+// the field as CSVHex.
+func (z *Int) MarshalCSV() (string, error) {
+	return z.Dec(), nil
+}
+
+// UnmarshalCSV implements the field unmarshaling interface of CSV struct
+// codecs such as gocsv. It accepts a decimal or 0x-prefixed hex string,
+// ignoring surrounding white space, so that columns written in either mode
+// can be read back.
+func (z *Int) UnmarshalCSV(s string) error {
+	if z == nil {
+		panic(nilReceiver("UnmarshalCSV"))
+	}
+	return z.setFromDecimalOrHex(strings.TrimSpace(s))
+}
+
+// CSVHex is an Int which is written to CSV as a 0x-prefixed hex string
+// rather than in decimal. It is read back like an Int.
+type CSVHex Int
+
+// MarshalCSV writes v as a 0x-prefixed hex string.
+func (v *CSVHex) MarshalCSV() (string, error) {
+	return (*Int)(v).ToHex(), nil
+}
+
+// UnmarshalCSV reads v like Int.UnmarshalCSV.
+func (v *CSVHex) UnmarshalCSV(s string) error {
+	return (*Int)(v).UnmarshalCSV(s)
+}
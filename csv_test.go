@@ -0,0 +1,65 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestCSVRoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		s, err := x.MarshalCSV()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != x.Dec() {
+			t.Fatalf("MarshalCSV: got %s, exp %s", s, x.Dec())
+		}
+		var z Int
+		if err := z.UnmarshalCSV(s); err != nil || !z.Eq(x) {
+			t.Fatalf("UnmarshalCSV(%s): got %v, %v", s, z.Hex(), err)
+		}
+
+		s, err = (*CSVHex)(x).MarshalCSV()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != x.ToHex() {
+			t.Fatalf("CSVHex.MarshalCSV: got %s, exp %s", s, x.ToHex())
+		}
+		var h CSVHex
+		if err := h.UnmarshalCSV(s); err != nil || !(*Int)(&h).Eq(x) {
+			t.Fatalf("CSVHex.UnmarshalCSV(%s): got %v, %v", s, (*Int)(&h).Hex(), err)
+		}
+	}
+}
+
+func TestUnmarshalCSV(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp uint64
+		ok  bool
+	}{
+		{"0", 0, true},
+		{"1000", 1000, true},
+		{" 1000 ", 1000, true},
+		{"0x3e8", 1000, true},
+		{"0X3E8", 1000, true},
+		{"", 0, false},
+		{"1,000", 0, false},
+		{"-1", 0, false},
+		{"0x", 0, false},
+	} {
+		var z Int
+		err := z.UnmarshalCSV(tc.s)
+		if (err == nil) != tc.ok {
+			t.Errorf("%q: got err %v", tc.s, err)
+			continue
+		}
+		if tc.ok && z.Uint64() != tc.exp {
+			t.Errorf("%q: got %d, exp %d", tc.s, z.Uint64(), tc.exp)
+		}
+	}
+}
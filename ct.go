@@ -0,0 +1,133 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// The methods in this file are intended as building blocks for scalar
+// multiplication (e.g. Montgomery ladders) layered on top of this package.
+// They do not branch on, or index memory by, secret data: conditions are
+// passed first, as a uint64 flag which must be either 0 or 1.
+
+// ctMask returns an all-ones mask if flag == 1, and zero if flag == 0.
+func ctMask(flag uint64) uint64 {
+	return -(flag & 1)
+}
+
+// CMov sets z to x if flag == 1, and leaves z unchanged if flag == 0.
+// The operation is performed in constant time. Returns z.
+func (z *Int) CMov(flag uint64, x *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("CMov"))
+	}
+	mask := ctMask(flag)
+	z[0] ^= (z[0] ^ x[0]) & mask
+	z[1] ^= (z[1] ^ x[1]) & mask
+	z[2] ^= (z[2] ^ x[2]) & mask
+	z[3] ^= (z[3] ^ x[3]) & mask
+	return z
+}
+
+// CSelect sets z to x if flag == 1, and to y if flag == 0, and returns z.
+// The operation is performed in constant time. z may alias x or y.
+func (z *Int) CSelect(flag uint64, x, y *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("CSelect"))
+	}
+	mask := ctMask(flag)
+	for i := range z {
+		z[i] = y[i] ^ (x[i]^y[i])&mask
+	}
+	return z
+}
+
+// Select sets z to x if cond is true, and to y otherwise, and returns z.
+// It selects with a mask rather than a branch, so it costs the same for
+// unpredictable conditions, as in interpreters; the conversion of cond
+// compiles to a conditional set instruction on common platforms, but
+// unlike CSelect, it is not guaranteed to run in constant time.
+func (z *Int) Select(cond bool, x, y *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("Select"))
+	}
+	var flag uint64
+	if cond {
+		flag = 1
+	}
+	return z.CSelect(flag, x, y)
+}
+
+// CMovIf sets z to x if cond is true, and leaves z unchanged otherwise, like
+// CMov with a boolean condition, and returns z. As for Select, it is not
+// guaranteed to run in constant time.
+func (z *Int) CMovIf(cond bool, x *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("CMovIf"))
+	}
+	return z.Select(cond, x, z)
+}
+
+// CSwap swaps the values of z and x if flag == 1, and leaves both unchanged
+// if flag == 0. The operation is performed in constant time.
+func (z *Int) CSwap(flag uint64, x *Int) {
+	if z == nil {
+		panic(nilReceiver("CSwap"))
+	}
+	mask := ctMask(flag)
+	for i := range z {
+		t := (z[i] ^ x[i]) & mask
+		z[i] ^= t
+		x[i] ^= t
+	}
+}
+
+// Bit returns the value of bit n of z, where n = 0 is the LSB. If n > 255,
+// the result is 0. Unlike isBitSet, the result is a 0/1 word suitable as a
+// flag for CMov and CSwap.
+func (z *Int) Bit(n uint) uint64 {
+	z = orZero(z)
+	if n > 255 {
+		return 0
+	}
+	return (z[n>>6] >> (n & 0x3f)) & 1
+}
+
+// Window returns the width-bit window of z starting at bit pos, i.e.
+// (z >> pos) & (2**width - 1). The width must be in the range [1, 64].
+// Bits above 255 are treated as zero.
+func (z *Int) Window(pos, width uint) uint64 {
+	z = orZero(z)
+	if width == 0 || width > 64 {
+		panic("uint256: window width out of range")
+	}
+	if pos > 255 {
+		return 0
+	}
+	word := pos >> 6
+	shift := pos & 0x3f
+	w := z[word] >> shift
+	if shift != 0 && word < 3 {
+		w |= z[word+1] << (64 - shift)
+	}
+	if width < 64 {
+		w &= (1 << width) - 1
+	}
+	return w
+}
+
+// Windows decomposes z into fixed width-bit windows, least significant first,
+// such that z = sum(windows[i] * 2**(i*width)). The number of windows is always
+// ceil(256 / width), independent of the value of z. The width must be in the
+// range [1, 8].
+func (z *Int) Windows(width uint) []uint8 {
+	if width == 0 || width > 8 {
+		panic("uint256: window width out of range")
+	}
+	n := (256 + width - 1) / width
+	windows := make([]uint8, n)
+	for i := uint(0); i < n; i++ {
+		windows[i] = uint8(z.Window(i*width, width))
+	}
+	return windows
+}
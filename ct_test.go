@@ -0,0 +1,140 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCMov(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randNums()
+		z := x.Clone()
+		if z.CMov(0, y); !z.Eq(x) {
+			t.Fatalf("CMov with flag 0 changed value: %v -> %v", x.Hex(), z.Hex())
+		}
+		if z.CMov(1, y); !z.Eq(y) {
+			t.Fatalf("CMov with flag 1: got %v, exp %v", z.Hex(), y.Hex())
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randNums()
+		x0, y0 := *x, *y
+		z := new(Int)
+		if z.Select(true, x, y); !z.Eq(x) {
+			t.Fatalf("Select(true): got %v, exp %v", z.Hex(), x.Hex())
+		}
+		if z.Select(false, x, y); !z.Eq(y) {
+			t.Fatalf("Select(false): got %v, exp %v", z.Hex(), y.Hex())
+		}
+		if z.CSelect(1, x, y); !z.Eq(x) {
+			t.Fatalf("CSelect(1): got %v, exp %v", z.Hex(), x.Hex())
+		}
+		if z.CSelect(0, x, y); !z.Eq(y) {
+			t.Fatalf("CSelect(0): got %v, exp %v", z.Hex(), y.Hex())
+		}
+		if *x != x0 || *y != y0 {
+			t.Fatalf("Select modified its operands")
+		}
+		// Aliased operands.
+		a := x.Clone()
+		if a.Select(false, a, y); !a.Eq(y) {
+			t.Fatalf("aliased Select(false): got %v, exp %v", a.Hex(), y.Hex())
+		}
+		a = y.Clone()
+		if a.Select(true, x, a); !a.Eq(x) {
+			t.Fatalf("aliased Select(true): got %v, exp %v", a.Hex(), x.Hex())
+		}
+		z = x.Clone()
+		if z.CMovIf(false, y); !z.Eq(x) {
+			t.Fatalf("CMovIf(false) changed value: %v -> %v", x.Hex(), z.Hex())
+		}
+		if z.CMovIf(true, y); !z.Eq(y) {
+			t.Fatalf("CMovIf(true): got %v, exp %v", z.Hex(), y.Hex())
+		}
+	}
+}
+
+func TestCSwap(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randNums()
+		a, b := x.Clone(), y.Clone()
+		a.CSwap(0, b)
+		if !a.Eq(x) || !b.Eq(y) {
+			t.Fatalf("CSwap with flag 0 swapped values")
+		}
+		a.CSwap(1, b)
+		if !a.Eq(y) || !b.Eq(x) {
+			t.Fatalf("CSwap with flag 1 did not swap values")
+		}
+	}
+}
+
+func TestBit(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		b, x, _ := randNums()
+		for n := uint(0); n < 260; n++ {
+			if got, exp := x.Bit(n), uint64(b.Bit(int(n))); got != exp {
+				t.Fatalf("bit %d of %v: got %d exp %d", n, x.Hex(), got, exp)
+			}
+		}
+	}
+}
+
+func TestWindows(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randHighNums()
+		for width := uint(1); width <= 8; width++ {
+			acc := new(big.Int)
+			windows := x.Windows(width)
+			for j := len(windows) - 1; j >= 0; j-- {
+				acc.Lsh(acc, width)
+				acc.Add(acc, big.NewInt(int64(windows[j])))
+			}
+			if acc.Cmp(b) != 0 {
+				t.Fatalf("width %d: recomposed %x, exp %x", width, acc, b)
+			}
+		}
+		for pos := uint(0); pos < 256; pos += 7 {
+			exp := new(big.Int).Rsh(b, pos)
+			exp.And(exp, new(big.Int).SetUint64(^uint64(0)))
+			if got := x.Window(pos, 64); got != exp.Uint64() {
+				t.Fatalf("window at %d: got %x exp %x", pos, got, exp)
+			}
+		}
+	}
+}
+
+// TestLadder implements modular exponentiation as a Montgomery ladder on top
+// of CSwap and Bit, and compares it against big.Int.
+func TestLadder(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		bb, base, _ := randNums()
+		be, exp, _ := randNums()
+		bm, m, _ := randNums()
+		if m.IsZero() {
+			continue
+		}
+		r0, r1 := new(Int).SetOne(), base.Clone()
+		r1.Mod(r1, m)
+		for n := 255; n >= 0; n-- {
+			bit := exp.Bit(uint(n))
+			r0.CSwap(bit, r1)
+			r1.MulMod(r0, r1, m)
+			r0.MulMod(r0, r0, m)
+			r0.CSwap(bit, r1)
+		}
+		r0.Mod(r0, m)
+		requireEq(t, new(big.Int).Exp(bb, be, bm), r0, "ladder")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "fmt"
+
+// DebugString returns a one-line description of z for logs and debugging,
+// showing its limbs (least significant first, as stored), bit length, hex
+// and decimal forms, and its value when interpreted as a two's complement
+// signed number, e.g.
+//
+//	{limbs: [0xffffffffffffffff 0x0 0x0 0x0], bits: 64, hex: 0xffffffffffffffff, dec: 18446744073709551615, signed: 18446744073709551615}
+func (z *Int) DebugString() string {
+	if z == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{limbs: [%#x %#x %#x %#x], bits: %d, hex: %s, dec: %s, signed: %s}",
+		z[0], z[1], z[2], z[3], z.BitLen(), z.ToHex(), z.Dec(), z.SDec())
+}
+
+// GoString implements fmt.GoStringer, and is used by Format for %#v. It
+// returns a Go expression which evaluates to z, so that test failure messages
+// can be pasted into source.
+func (z *Int) GoString() string {
+	if z == nil {
+		return "(*uint256.Int)(nil)"
+	}
+	return "&" + z.GoLiteral()
+}
+
+// GoLiteral returns the Go composite literal of the value of z, such as
+// uint256.Int{0x1, 0x0, 0x0, 0x0}, for pasting values captured while
+// debugging into test fixtures. A nil z is written as the zero literal.
+func (z *Int) GoLiteral() string {
+	var x Int
+	if z != nil {
+		x = *z
+	}
+	return fmt.Sprintf("uint256.Int{%#x, %#x, %#x, %#x}", x[0], x[1], x[2], x[3])
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"go/parser"
+	"testing"
+)
+
+func TestDebugString(t *testing.T) {
+	for _, tc := range []struct {
+		x   *Int
+		exp string
+	}{
+		{new(Int), "{limbs: [0x0 0x0 0x0 0x0], bits: 0, hex: 0x0, dec: 0, signed: 0}"},
+		{&Int{0xffffffffffffffff}, "{limbs: [0xffffffffffffffff 0x0 0x0 0x0], bits: 64, hex: 0xffffffffffffffff, dec: 18446744073709551615, signed: 18446744073709551615}"},
+		{new(Int).SetAllOne(), "{limbs: [0xffffffffffffffff 0xffffffffffffffff 0xffffffffffffffff 0xffffffffffffffff], bits: 256, hex: 0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff, " +
+			"dec: 115792089237316195423570985008687907853269984665640564039457584007913129639935, signed: -1}"},
+		{nil, "<nil>"},
+	} {
+		if got := tc.x.DebugString(); got != tc.exp {
+			t.Errorf("got %s, exp %s", got, tc.exp)
+		}
+	}
+}
+
+func TestGoString(t *testing.T) {
+	x := &Int{1, 0x20, 0, 0xabc}
+	exp := "&uint256.Int{0x1, 0x20, 0x0, 0xabc}"
+	if got := x.GoString(); got != exp {
+		t.Errorf("got %s, exp %s", got, exp)
+	}
+	if got := fmt.Sprintf("%#v", x); got != exp {
+		t.Errorf("%%#v: got %s, exp %s", got, exp)
+	}
+	if got, exp := fmt.Sprintf("%v", x), x.ToBig().String(); got != exp {
+		t.Errorf("%%v: got %s, exp %s", got, exp)
+	}
+	if got, exp := fmt.Sprintf("%#v", (*Int)(nil)), "(*uint256.Int)(nil)"; got != exp {
+		t.Errorf("nil: got %s, exp %s", got, exp)
+	}
+}
+
+func TestGoLiteral(t *testing.T) {
+	for _, tc := range []struct {
+		x   *Int
+		exp string
+	}{
+		{new(Int), "uint256.Int{0x0, 0x0, 0x0, 0x0}"},
+		{nil, "uint256.Int{0x0, 0x0, 0x0, 0x0}"},
+		{&Int{1, 0x20, 0, 0xabc}, "uint256.Int{0x1, 0x20, 0x0, 0xabc}"},
+		{new(Int).SetAllOne(), "uint256.Int{0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}"},
+	} {
+		if got := tc.x.GoLiteral(); got != tc.exp {
+			t.Errorf("got %s, exp %s", got, tc.exp)
+		}
+	}
+	// The literal must be valid Go source.
+	if _, err := parser.ParseExpr(new(Int).SetAllOne().GoLiteral()); err != nil {
+		t.Errorf("GoLiteral does not parse: %v", err)
+	}
+	if _, err := parser.ParseExpr(new(Int).GoString()); err != nil {
+		t.Errorf("GoString does not parse: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+	"strconv"
+)
+
+const (
+	// maxDecimalLen is the number of decimal digits of 2**256 - 1.
+	maxDecimalLen = 78
+
+	// Decimal conversion works in chunks of 19 digits, since 10**19 is the
+	// largest power of ten that fits in a uint64.
+	tenToThe19   = 10000000000000000000
+	decimalChunk = 19
+)
+
+var (
+	errEmptyString   = errors.New("uint256: empty string")
+	errDecimalSyntax = errors.New("uint256: invalid decimal string")
+	errLeadingZero   = errors.New("uint256: leading zero digits")
+	errOverflow      = errors.New("uint256: value overflows 256 bits")
+)
+
+// divRem64 sets z to x / d, and returns the remainder x mod d. The divisor
+// must not be zero.
+func (z *Int) divRem64(x *Int, d uint64) (rem uint64) {
+	if !fastDiv64 {
+		return z.divRem64Reciprocal(x, d)
+	}
+	for i := 3; i >= 0; i-- {
+		z[i], rem = bits.Div64(rem, x[i], d)
+	}
+	return rem
+}
+
+// divRem64Reciprocal implements divRem64 with one bits.Div64 call, for the
+// reciprocal of the normalized divisor, instead of four, for platforms where
+// bits.Div64 is not an intrinsic.
+func (z *Int) divRem64Reciprocal(x *Int, d uint64) (rem uint64) {
+	// Divide x << s by d << s, which has the same quotient. Shifts by 64 or
+	// more give 0, so s == 0 needs no special case.
+	s := uint(bits.LeadingZeros64(d))
+	dn := d << s
+	reciprocal := reciprocal2by1(dn)
+	rem = x[3] >> (64 - s)
+	for i := 3; i >= 0; i-- {
+		u := x[i] << s
+		if i > 0 {
+			u |= x[i-1] >> (64 - s)
+		}
+		z[i], rem = udivrem2by1(rem, u, dn, reciprocal)
+	}
+	return rem >> s
+}
+
+// mulAdd64 sets z to z * m + a, and reports whether the result overflowed.
+func (z *Int) mulAdd64(m, a uint64) bool {
+	carry := a
+	for i := range z {
+		hi, lo := bits.Mul64(z[i], m)
+		var c uint64
+		z[i], c = bits.Add64(lo, carry, 0)
+		carry = hi + c
+	}
+	return carry != 0
+}
+
+// Dec returns the decimal representation of z, without leading zeros.
+func (z *Int) Dec() string {
+	if z.IsUint64() {
+		return strconv.FormatUint(z.Uint64(), 10)
+	}
+	var (
+		buf [maxDecimalLen]byte
+		pos = len(buf)
+		y   = *z
+	)
+	for {
+		rem := y.divRem64(&y, tenToThe19)
+		if y.IsZero() {
+			// The most significant chunk, which is non-zero and not padded.
+			for ; rem > 0; rem /= 10 {
+				pos--
+				buf[pos] = byte('0' + rem%10)
+			}
+			return string(buf[pos:])
+		}
+		for i := 0; i < decimalChunk; i++ {
+			pos--
+			buf[pos] = byte('0' + rem%10)
+			rem /= 10
+		}
+	}
+}
+
+// SDec returns the decimal representation of z interpreted as a two's
+// complement signed number, with a leading minus sign if it is negative.
+func (z *Int) SDec() string {
+	if z.IsNegative() {
+		var y Int
+		return "-" + y.NegOf(z).Dec()
+	}
+	return z.Dec()
+}
+
+// SetFromDecimal sets z from the strict decimal representation s: a
+// non-empty string of ASCII digits, with no sign, whitespace, or leading
+// zeros (other than "0" itself), whose value is at most 2**256 - 1. Errors
+// are of type *ParseError. The policy is DecimalOptions.
+func (z *Int) SetFromDecimal(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromDecimal"))
+	}
+	return z.parse(s, &decimalOptions)
+}
+
+// setDecimal sets z from the strict decimal representation s, as accepted by
+// SetFromDecimal, returning the offset of the error in s, or -1 for overflow.
+func (z *Int) setDecimal(s string) (int, error) {
+	if len(s) == 0 {
+		return 0, errEmptyString
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return i, errDecimalSyntax
+		}
+	}
+	if s[0] == '0' && len(s) > 1 {
+		return 0, errLeadingZero
+	}
+	return z.setDecimalDigits(s)
+}
+
+// setDecimalDigits sets z from the valid decimal digits s, without leading
+// zeros, returning -1 and an error on overflow.
+func (z *Int) setDecimalDigits(s string) (int, error) {
+	if len(s) > maxDecimalLen {
+		return -1, errOverflow
+	}
+	var res Int
+	// The first chunk takes the remainder, so that the rest have 19 digits.
+	chunk := len(s) % decimalChunk
+	if chunk == 0 {
+		chunk = decimalChunk
+	}
+	mul := uint64(1)
+	for i := 0; i < chunk; i++ {
+		mul *= 10
+	}
+	for len(s) > 0 {
+		var v uint64
+		for i := 0; i < chunk; i++ {
+			v = v*10 + uint64(s[i]-'0')
+		}
+		if res.mulAdd64(mul, v) {
+			return -1, errOverflow
+		}
+		s = s[chunk:]
+		chunk, mul = decimalChunk, tenToThe19
+	}
+	z.Copy(&res)
+	return 0, nil
+}
+
+// FromDecimal is a convenience-constructor for SetFromDecimal.
+func FromDecimal(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromDecimal(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// MustFromDecimal is like FromDecimal, but panics if s is not a valid
+// decimal representation. It is intended for constants and tests.
+func MustFromDecimal(s string) *Int {
+	z, err := FromDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return z
+}
+
+// ParseDecimalFixed parses the decimal number s, such as "1.23456", scaled by
+// 10**decimals, i.e. as a fixed-point number with the given number of
+// decimals, and reports whether the result is exact. Fraction digits beyond
+// decimals are truncated, and exact is false if any of them is non-zero.
+// s is ASCII digits, optionally followed by a point and at least one more
+// digit; leading zeros are allowed, but signs and exponents are not. Errors
+// are of type *ParseError, and overflow is an error even if exact is false.
+func ParseDecimalFixed(s string, decimals int) (z Int, exact bool, err error) {
+	if decimals < 0 {
+		panic("uint256: negative number of decimals")
+	}
+	whole, frac := s, ""
+	point := len(s)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			whole, frac, point = s[:i], s[i+1:], i
+			break
+		}
+	}
+	if len(whole) == 0 {
+		return z, false, newParseError(s, 0, expectFixed, errEmptyString)
+	}
+	if point < len(s) && len(frac) == 0 {
+		return z, false, newParseError(s, len(s), expectFixed, errDecimalSyntax)
+	}
+	for i := 0; i < len(s); i++ {
+		if (s[i] < '0' || s[i] > '9') && i != point {
+			return z, false, newParseError(s, i, expectFixed, errDecimalSyntax)
+		}
+	}
+	exact = true
+	if len(frac) > decimals {
+		for i := decimals; i < len(frac); i++ {
+			if frac[i] != '0' {
+				exact = false
+				break
+			}
+		}
+		frac = frac[:decimals]
+	}
+	// The value is the digits of whole and frac, followed by pad zeros.
+	digits := whole + frac
+	for len(digits) > 1 && digits[0] == '0' {
+		digits = digits[1:]
+	}
+	pad := decimals - len(frac)
+	if digits == "0" {
+		return z, exact, nil
+	}
+	if len(digits)+pad > maxDecimalLen {
+		return z, exact, newParseError(s, -1, expectFixed, errOverflow)
+	}
+	if _, err := z.setDecimal(digits); err != nil {
+		return z, exact, newParseError(s, -1, expectFixed, err)
+	}
+	for ; pad > 0; pad-- {
+		if z.mulAdd64(10, 0) {
+			return Int{}, exact, newParseError(s, -1, expectFixed, errOverflow)
+		}
+	}
+	return z, exact, nil
+}
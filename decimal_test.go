@@ -0,0 +1,212 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDecRandom(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		b, x, _ := randHighNums()
+		if i%2 == 0 {
+			b, x, _ = randNums()
+		}
+		s := x.Dec()
+		if exp := b.String(); s != exp {
+			t.Fatalf("Dec(%v): got %s, exp %s", x.Hex(), s, exp)
+		}
+		var z Int
+		if err := z.SetFromDecimal(s); err != nil || !z.Eq(x) {
+			t.Fatalf("SetFromDecimal(%s): got %v (err %v)", s, z.Hex(), err)
+		}
+	}
+}
+
+func TestDecEdgeCases(t *testing.T) {
+	for _, tc := range []struct {
+		x   *Int
+		exp string
+	}{
+		{new(Int), "0"},
+		{new(Int).SetUint64(tenToThe19 - 1), "9999999999999999999"},
+		{new(Int).SetUint64(tenToThe19), "10000000000000000000"},
+		{&Int{0, 1}, "18446744073709551616"},
+		{new(Int).SetAllOne(), "115792089237316195423570985008687907853269984665640564039457584007913129639935"},
+	} {
+		if got := tc.x.Dec(); got != tc.exp {
+			t.Errorf("Dec(%v): got %s, exp %s", tc.x.Hex(), got, tc.exp)
+		}
+		if got := MustFromDecimal(tc.exp); !got.Eq(tc.x) {
+			t.Errorf("MustFromDecimal(%s): got %v", tc.exp, got.Hex())
+		}
+	}
+}
+
+func TestSetFromDecimalErrors(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		err error
+	}{
+		{"", errEmptyString},
+		{"00", errLeadingZero},
+		{"0123", errLeadingZero},
+		{"+1", errDecimalSyntax},
+		{"-1", errDecimalSyntax},
+		{" 1", errDecimalSyntax},
+		{"1 ", errDecimalSyntax},
+		{"12a4", errDecimalSyntax},
+		{"0x10", errDecimalSyntax},
+		{"1_000", errDecimalSyntax},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", errOverflow},
+		{"999999999999999999999999999999999999999999999999999999999999999999999999999999", errOverflow},
+		{strings.Repeat("9", 100), errOverflow},
+		{strings.Repeat("9", 99) + "x", errDecimalSyntax},
+	} {
+		z := new(Int).SetUint64(42)
+		if err := z.SetFromDecimal(tc.s); !errors.Is(err, tc.err) {
+			t.Errorf("%q: got err %v, exp %v", tc.s, err, tc.err)
+		}
+		if z.Uint64() != 42 {
+			t.Errorf("%q: receiver modified on error", tc.s)
+		}
+	}
+	if _, err := FromDecimal("abc"); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+// TestDivRem64Reciprocal checks the divRem64 fallback for WebAssembly and
+// TinyGo on every platform.
+func TestDivRem64Reciprocal(t *testing.T) {
+	divisors := []uint64{1, 2, 3, 10, tenToThe19, 1 << 63, ^uint64(0), 0x100000001}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		d := divisors[i%len(divisors)]
+		if i >= 2*len(divisors) {
+			d = x[0] ^ x[3]>>uint(i%64) | 1
+		}
+		var q1, q2 Int
+		r1 := q1.divRem64(x, d)
+		r2 := q2.divRem64Reciprocal(x, d)
+		if r1 != r2 || !q1.Eq(&q2) {
+			t.Fatalf("%v / %d: got %v rem %d, exp %v rem %d", x, d, &q2, r2, &q1, r1)
+		}
+		exp := new(Int).Div(x, new(Int).SetUint64(d))
+		if !q1.Eq(exp) {
+			t.Fatalf("%v / %d: got %v, exp %v", x, d, &q1, exp)
+		}
+		// Aliased operands.
+		y := x.Clone()
+		if r := y.divRem64Reciprocal(y, d); r != r1 || !y.Eq(&q1) {
+			t.Fatalf("%v / %d aliased: got %v rem %d, exp %v rem %d", x, d, y, r, &q1, r1)
+		}
+	}
+}
+
+func BenchmarkDec(b *testing.B) {
+	x := new(Int).SetAllOne()
+	for i := 0; i < b.N; i++ {
+		_ = x.Dec()
+	}
+}
+
+func BenchmarkSetFromDecimal(b *testing.B) {
+	s := new(Int).SetAllOne().Dec()
+	var z Int
+	for i := 0; i < b.N; i++ {
+		_ = z.SetFromDecimal(s)
+	}
+}
+
+func TestParseDecimalFixed(t *testing.T) {
+	max := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	for _, tc := range []struct {
+		s        string
+		decimals int
+		want     string
+		exact    bool
+	}{
+		{"0", 18, "0", true},
+		{"0.0", 0, "0", true},
+		{"1", 0, "1", true},
+		{"1.5", 0, "1", false},
+		{"1.23456", 5, "123456", true},
+		{"1.23456", 3, "1234", false},
+		{"1.23400", 3, "1234", true},
+		{"1.2", 18, "1200000000000000000", true},
+		{"007.50", 2, "750", true},
+		{"0.000000000000000001", 18, "1", true},
+		{"0.0000000000000000009", 18, "0", false},
+		{"0.00", 1000, "0", true},
+		{max, 0, max, true},
+		{max + ".999", 0, max, false},
+		{max[:len(max)-1] + "." + max[len(max)-1:], 1, max, true},
+	} {
+		z, exact, err := ParseDecimalFixed(tc.s, tc.decimals)
+		if err != nil {
+			t.Errorf("%q, %d: %v", tc.s, tc.decimals, err)
+			continue
+		}
+		if z.Dec() != tc.want || exact != tc.exact {
+			t.Errorf("%q, %d: have %v, %v, want %v, %v", tc.s, tc.decimals, z.Dec(), exact, tc.want, tc.exact)
+		}
+	}
+	for _, tc := range []struct {
+		s        string
+		decimals int
+		offset   int
+		err      error
+	}{
+		{"", 0, 0, errEmptyString},
+		{".5", 0, 0, errEmptyString},
+		{"5.", 0, 2, errDecimalSyntax},
+		{"1.2.3", 2, 3, errDecimalSyntax},
+		{"-1", 0, 0, errDecimalSyntax},
+		{"1e5", 0, 1, errDecimalSyntax},
+		{"1.5x", 5, 3, errDecimalSyntax},
+		{max + "0", 0, -1, errOverflow},
+		{"1", 78, -1, errOverflow},
+		{"1.16", 77, -1, errOverflow},
+	} {
+		_, _, err := ParseDecimalFixed(tc.s, tc.decimals)
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Offset != tc.offset || !errors.Is(err, tc.err) {
+			t.Errorf("%q, %d: have err %v, want %v at offset %d", tc.s, tc.decimals, err, tc.err, tc.offset)
+		}
+	}
+}
+
+func TestSDecSHex(t *testing.T) {
+	for _, tc := range []struct {
+		x        *Int
+		dec, hex string
+	}{
+		{new(Int), "0", "0x0"},
+		{new(Int).SetUint64(255), "255", "0xff"},
+		{MaxUint256(), "-1", "-0x1"},
+		{new(Int).SetAllOne().SubUint64(MaxUint256(), 15), "-16", "-0x10"},
+		{MaxInt256(), "57896044618658097711785492504343953926634992332820282019728792003956564819967",
+			"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{MinInt256(), "-57896044618658097711785492504343953926634992332820282019728792003956564819968",
+			"-0x8000000000000000000000000000000000000000000000000000000000000000"},
+	} {
+		if have := tc.x.SDec(); have != tc.dec {
+			t.Errorf("SDec(%v): have %s, want %s", tc.x.Hex(), have, tc.dec)
+		}
+		if have := tc.x.SHex(); have != tc.hex {
+			t.Errorf("SHex(%v): have %s, want %s", tc.x.Hex(), have, tc.hex)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randHighNums()
+		if have, want := x.SDec(), S256(x.ToBig()).String(); have != want {
+			t.Fatalf("SDec(%v): have %s, want %s", x.Hex(), have, want)
+		}
+	}
+}
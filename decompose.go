@@ -0,0 +1,226 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// Endianness selects the order in which the digits (bits, limbs or bytes) of
+// an Int are laid out.
+type Endianness int
+
+const (
+	// LittleEndian places the least significant digit first.
+	LittleEndian Endianness = iota
+	// BigEndian places the most significant digit first.
+	BigEndian
+)
+
+// index returns the digit number of z held at position i of an n-digit
+// decomposition in the given order.
+func (order Endianness) index(i, n int) int {
+	if order == BigEndian {
+		return n - 1 - i
+	}
+	return i
+}
+
+// ToBits returns the 256 bits of z in the given order, as used for bit
+// decomposition of circuit witnesses.
+func (z *Int) ToBits(order Endianness) [256]bool {
+	var out [256]bool
+	for i := range out {
+		out[order.index(i, 256)] = z.Bit(uint(i)) == 1
+	}
+	return out
+}
+
+// ToBitBytes is like ToBits, but returns each bit as a byte with the value
+// 0 or 1.
+func (z *Int) ToBitBytes(order Endianness) [256]byte {
+	var out [256]byte
+	for i := range out {
+		out[order.index(i, 256)] = byte(z.Bit(uint(i)))
+	}
+	return out
+}
+
+// SetFromBits sets z to the value whose bits are given in bits, in the given
+// order. Returns true if any bit beyond the 256th is set (overflow), in which
+// case the excess bits are dropped.
+func (z *Int) SetFromBits(bits []bool, order Endianness) bool {
+	if z == nil {
+		panic(nilReceiver("SetFromBits"))
+	}
+	z.Clear()
+	overflow := false
+	for i, b := range bits {
+		if !b {
+			continue
+		}
+		if n := order.index(i, len(bits)); n < 256 {
+			z[n/64] |= 1 << uint(n%64)
+		} else {
+			overflow = true
+		}
+	}
+	return overflow
+}
+
+// SetFromBitBytes is like SetFromBits, but takes each bit as a byte; any
+// non-zero byte is treated as a set bit.
+func (z *Int) SetFromBitBytes(bits []byte, order Endianness) bool {
+	if z == nil {
+		panic(nilReceiver("SetFromBitBytes"))
+	}
+	z.Clear()
+	overflow := false
+	for i, b := range bits {
+		if b == 0 {
+			continue
+		}
+		if n := order.index(i, len(bits)); n < 256 {
+			z[n/64] |= 1 << uint(n%64)
+		} else {
+			overflow = true
+		}
+	}
+	return overflow
+}
+
+// FromBits is a convenience-constructor for SetFromBits.
+// The second return value indicates whether the value overflowed 256 bits.
+func FromBits(bits []bool, order Endianness) (*Int, bool) {
+	z := new(Int)
+	overflow := z.SetFromBits(bits, order)
+	return z, overflow
+}
+
+// ToLimbs splits z into width-bit limbs in the given order, such that (in
+// little-endian order) z = sum(limbs[i] * 2**(i*width)). The number of limbs
+// is always ceil(256 / width). The width must be in the range [1, 256].
+func (z *Int) ToLimbs(width uint, order Endianness) []Int {
+	z = orZero(z)
+	if width == 0 || width > 256 {
+		panic("uint256: limb width out of range")
+	}
+	n := int((256 + width - 1) / width)
+	limbs := make([]Int, n)
+	// For width 256, the shift yields 0 and the subtraction wraps to all ones.
+	var mask Int
+	mask.SetOne().Lsh(&mask, width).Sub(&mask, new(Int).SetOne())
+	for i := 0; i < n; i++ {
+		limb := &limbs[order.index(i, n)]
+		limb.Rsh(z, uint(i)*width)
+		limb.And(limb, &mask)
+	}
+	return limbs
+}
+
+// SetFromLimbs sets z from width-bit limbs in the given order, the inverse of
+// ToLimbs. Returns true if the value overflows 256 bits, or if any limb does
+// not fit in width bits. The width must be in the range [1, 256].
+func (z *Int) SetFromLimbs(limbs []Int, width uint, order Endianness) bool {
+	if z == nil {
+		panic(nilReceiver("SetFromLimbs"))
+	}
+	if width == 0 || width > 256 {
+		panic("uint256: limb width out of range")
+	}
+	z.Clear()
+	overflow := false
+	var shifted Int
+	for i := range limbs {
+		limb := &limbs[order.index(i, len(limbs))]
+		if uint(limb.BitLen()) > width {
+			overflow = true
+		}
+		pos := uint(i) * width
+		if limb.IsZero() {
+			continue
+		}
+		if pos >= 256 || uint(limb.BitLen())+pos > 256 {
+			overflow = true
+		}
+		if pos < 256 {
+			shifted.Lsh(limb, pos)
+			z.Or(z, &shifted)
+		}
+	}
+	return overflow
+}
+
+// FromLimbs is a convenience-constructor for SetFromLimbs.
+// The second return value indicates whether the value overflowed.
+func FromLimbs(limbs []Int, width uint, order Endianness) (*Int, bool) {
+	z := new(Int)
+	overflow := z.SetFromLimbs(limbs, width, order)
+	return z, overflow
+}
+
+// SetWordsBE sets z from 64-bit words in big-endian order, most significant
+// first, as used by some field and bignum libraries. Slices of fewer than
+// four words are zero-extended, so values of narrower types convert without
+// padding. Returns true if the value overflows 256 bits, i.e. if any word but
+// the last four is non-zero.
+func (z *Int) SetWordsBE(words []uint64) bool {
+	if z == nil {
+		panic(nilReceiver("SetWordsBE"))
+	}
+	overflow := false
+	for len(words) > 4 {
+		if words[0] != 0 {
+			overflow = true
+		}
+		words = words[1:]
+	}
+	z.Clear()
+	for i, w := range words {
+		z[len(words)-1-i] = w
+	}
+	return overflow
+}
+
+// WordsBE returns the 64-bit words of z in big-endian order, most significant
+// first, the inverse of SetWordsBE.
+func (z *Int) WordsBE() [4]uint64 {
+	z = orZero(z)
+	return [4]uint64{z[3], z[2], z[1], z[0]}
+}
+
+// LittleEndianLimbs returns the 64-bit limbs of z, least significant first,
+// as they are stored.
+func (z *Int) LittleEndianLimbs() [4]uint64 {
+	z = orZero(z)
+	return *z
+}
+
+// BigEndianLimbs is an alias of WordsBE, named to pair with
+// LittleEndianLimbs.
+func (z *Int) BigEndianLimbs() [4]uint64 {
+	return z.WordsBE()
+}
+
+// SetLittleEndianLimbs sets z from 64-bit limbs, least significant first, and
+// returns z.
+func (z *Int) SetLittleEndianLimbs(limbs [4]uint64) *Int {
+	if z == nil {
+		panic(nilReceiver("SetLittleEndianLimbs"))
+	}
+	*z = limbs
+	return z
+}
+
+// SetBigEndianLimbs is an alias of SetWordsBE for exactly four limbs, which
+// cannot overflow, named to pair with SetLittleEndianLimbs. It returns z.
+func (z *Int) SetBigEndianLimbs(limbs [4]uint64) *Int {
+	z.SetWordsBE(limbs[:])
+	return z
+}
+
+// Limbs returns a pointer to the limbs of z, least significant first, which
+// aliases z. It gives foreign code, e.g. through cgo, direct access to the
+// storage of z, with the layout described at Int.
+func (z *Int) Limbs() *[4]uint64 {
+	return (*[4]uint64)(z)
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestBitsRoundTrip(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randHighNums()
+		for _, order := range []Endianness{LittleEndian, BigEndian} {
+			bits := x.ToBits(order)
+			bitBytes := x.ToBitBytes(order)
+			for n := 0; n < 256; n++ {
+				j := n
+				if order == BigEndian {
+					j = 255 - n
+				}
+				if exp := b.Bit(n) == 1; bits[j] != exp || (bitBytes[j] == 1) != exp {
+					t.Fatalf("bit %d of %v (order %d): got %v/%d", n, x.Hex(), order, bits[j], bitBytes[j])
+				}
+			}
+			if z, overflow := FromBits(bits[:], order); overflow || !z.Eq(x) {
+				t.Fatalf("FromBits: got %v (overflow %v), exp %v", z.Hex(), overflow, x.Hex())
+			}
+			var z Int
+			if overflow := z.SetFromBitBytes(bitBytes[:], order); overflow || !z.Eq(x) {
+				t.Fatalf("SetFromBitBytes: got %v (overflow %v), exp %v", z.Hex(), overflow, x.Hex())
+			}
+		}
+	}
+}
+
+func TestFromBitsShort(t *testing.T) {
+	// 0b1101 = 13.
+	if z, _ := FromBits([]bool{true, false, true, true}, LittleEndian); z.Uint64() != 13 {
+		t.Errorf("little-endian: got %d, exp 13", z.Uint64())
+	}
+	if z, _ := FromBits([]bool{true, true, false, true}, BigEndian); z.Uint64() != 13 {
+		t.Errorf("big-endian: got %d, exp 13", z.Uint64())
+	}
+	bits := make([]bool, 300)
+	bits[0] = true
+	if z, overflow := FromBits(bits, LittleEndian); overflow || z.Uint64() != 1 {
+		t.Errorf("unset high bits: got %v (overflow %v)", z.Hex(), overflow)
+	}
+	if _, overflow := FromBits(bits, BigEndian); !overflow {
+		t.Errorf("expected overflow")
+	}
+}
+
+func TestLimbs(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		b, x, _ := randHighNums()
+		for _, width := range []uint{1, 7, 8, 51, 64, 68, 88, 128, 200, 255, 256} {
+			limbs := x.ToLimbs(width, LittleEndian)
+			if exp := int((256 + width - 1) / width); len(limbs) != exp {
+				t.Fatalf("width %d: got %d limbs, exp %d", width, len(limbs), exp)
+			}
+			acc := new(big.Int)
+			for j := len(limbs) - 1; j >= 0; j-- {
+				if uint(limbs[j].BitLen()) > width {
+					t.Fatalf("width %d: limb %d too wide: %v", width, j, limbs[j].Hex())
+				}
+				acc.Lsh(acc, width)
+				acc.Add(acc, limbs[j].ToBig())
+			}
+			if acc.Cmp(b) != 0 {
+				t.Fatalf("width %d: limbs of %v sum to %x", width, x.Hex(), acc)
+			}
+			if z, overflow := FromLimbs(limbs, width, LittleEndian); overflow || !z.Eq(x) {
+				t.Fatalf("width %d: FromLimbs got %v (overflow %v), exp %v", width, z.Hex(), overflow, x.Hex())
+			}
+			be := x.ToLimbs(width, BigEndian)
+			for j := range be {
+				if !be[j].Eq(&limbs[len(limbs)-1-j]) {
+					t.Fatalf("width %d: big-endian limb %d mismatch", width, j)
+				}
+			}
+			if z, overflow := FromLimbs(be, width, BigEndian); overflow || !z.Eq(x) {
+				t.Fatalf("width %d: FromLimbs (big-endian) got %v (overflow %v)", width, z.Hex(), overflow)
+			}
+		}
+	}
+}
+
+func TestFromLimbsOverflow(t *testing.T) {
+	// A limb wider than the limb width.
+	if _, overflow := FromLimbs([]Int{{0x100}}, 8, LittleEndian); !overflow {
+		t.Errorf("expected overflow for wide limb")
+	}
+	// A limb beyond bit 255.
+	limbs := make([]Int, 5)
+	limbs[4].SetOne()
+	if _, overflow := FromLimbs(limbs, 64, LittleEndian); !overflow {
+		t.Errorf("expected overflow for fifth 64-bit limb")
+	}
+	// The top 88-bit limb only has room for 80 bits.
+	limbs = []Int{{}, {}, {0, 1 << 16}}
+	if _, overflow := FromLimbs(limbs, 88, LittleEndian); !overflow {
+		t.Errorf("expected overflow for top 88-bit limb")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for width 0")
+		}
+	}()
+	new(Int).ToLimbs(0, LittleEndian)
+}
+
+func TestWordsBE(t *testing.T) {
+	x := &Int{1, 2, 3, 4}
+	if words := x.WordsBE(); words != [4]uint64{4, 3, 2, 1} {
+		t.Fatalf("WordsBE: got %v", words)
+	}
+	for _, tc := range []struct {
+		words    []uint64
+		want     Int
+		overflow bool
+	}{
+		{nil, Int{}, false},
+		{[]uint64{7}, Int{7}, false},
+		{[]uint64{1, 2}, Int{2, 1}, false},
+		{[]uint64{4, 3, 2, 1}, Int{1, 2, 3, 4}, false},
+		{[]uint64{0, 0, 4, 3, 2, 1}, Int{1, 2, 3, 4}, false},
+		{[]uint64{5, 0, 4, 3, 2, 1}, Int{1, 2, 3, 4}, true},
+	} {
+		z := &Int{9, 9, 9, 9}
+		if overflow := z.SetWordsBE(tc.words); *z != tc.want || overflow != tc.overflow {
+			t.Errorf("SetWordsBE(%v): got %v, %v, exp %v, %v", tc.words, z, overflow, &tc.want, tc.overflow)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		words := x.WordsBE()
+		var z Int
+		if z.SetWordsBE(words[:]); !z.Eq(x) {
+			t.Fatalf("round trip of %v: got %v", x.Hex(), z.Hex())
+		}
+	}
+}
+
+func TestLimbOrders(t *testing.T) {
+	if typ := reflect.TypeOf(Int{}); typ.Size() != 32 || typ.Align() != reflect.TypeOf(uint64(0)).Align() {
+		t.Fatalf("layout: size %d, align %d", typ.Size(), typ.Align())
+	}
+	x := &Int{1, 2, 3, 4}
+	if limbs := x.LittleEndianLimbs(); limbs != [4]uint64{1, 2, 3, 4} {
+		t.Errorf("LittleEndianLimbs: got %v", limbs)
+	}
+	if limbs := x.BigEndianLimbs(); limbs != [4]uint64{4, 3, 2, 1} {
+		t.Errorf("BigEndianLimbs: got %v", limbs)
+	}
+	if z := new(Int).SetLittleEndianLimbs([4]uint64{1, 2, 3, 4}); !z.Eq(x) {
+		t.Errorf("SetLittleEndianLimbs: got %v", z.Hex())
+	}
+	if z := new(Int).SetBigEndianLimbs([4]uint64{4, 3, 2, 1}); !z.Eq(x) {
+		t.Errorf("SetBigEndianLimbs: got %v", z.Hex())
+	}
+	// Limbs aliases z.
+	p := x.Limbs()
+	p[0] = 5
+	if x[0] != 5 || &p[0] != &x[0] {
+		t.Errorf("Limbs does not alias its receiver")
+	}
+}
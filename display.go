@@ -0,0 +1,57 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// The methods in this file return display-ready strings, and can be invoked
+// from text/template and html/template, e.g. {{.Balance.Short 6 4}}.
+
+// ellipsis separates the kept ends of a shortened representation.
+const ellipsis = "…"
+
+// DecimalString returns the decimal representation of z. It is the same as
+// Dec, under a name which reads well in templates.
+func (z *Int) DecimalString() string {
+	return z.Dec()
+}
+
+// HexString returns the 0x-prefixed hex representation of z, without leading
+// zeros. It is the same as ToHex, under a name which reads well in templates.
+func (z *Int) HexString() string {
+	return z.ToHex()
+}
+
+// shorten returns s with all but its first head and last tail bytes replaced
+// by an ellipsis, or s itself if that would not make it shorter, counting the
+// ellipsis as one character.
+func shorten(s string, head, tail int) string {
+	if head < 0 {
+		head = 0
+	}
+	if tail < 0 {
+		tail = 0
+	}
+	if len(s) <= head+tail+1 {
+		return s
+	}
+	return s[:head] + ellipsis + s[len(s)-tail:]
+}
+
+// Short returns the decimal representation of z, shortened to its first
+// prefix and last suffix digits around an ellipsis, e.g. "115792…9935".
+// Values with at most prefix+suffix+1 digits are returned in full, since
+// shortening them would not save any space.
+func (z *Int) Short(prefix, suffix int) string {
+	return shorten(z.Dec(), prefix, suffix)
+}
+
+// Abbrev returns the 0x-prefixed hex representation of z, shortened to its
+// first head and last tail hex digits around an ellipsis, e.g. "0x1234…abcd"
+// for Abbrev(4, 4). The prefix is not counted as digits. As with Short,
+// values with at most head+tail+1 digits are returned in full, so the result
+// always shows every digit it does not replace, and never hides only one.
+func (z *Int) Abbrev(head, tail int) string {
+	return "0x" + shorten(z.ToHex()[2:], head, tail)
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestDisplayStrings(t *testing.T) {
+	x := new(Int).SetUint64(1000)
+	if got := x.DecimalString(); got != "1000" {
+		t.Errorf("DecimalString: got %s", got)
+	}
+	if got := x.HexString(); got != "0x3e8" {
+		t.Errorf("HexString: got %s", got)
+	}
+}
+
+func TestShort(t *testing.T) {
+	max := new(Int).SetAllOne()
+	for _, tc := range []struct {
+		x              *Int
+		prefix, suffix int
+		exp            string
+	}{
+		{max, 6, 4, "115792…9935"},
+		{max, 0, 4, "…9935"},
+		{max, 3, 0, "115…"},
+		{max, -1, -1, "…"},
+		{max, 40, 40, max.Dec()},
+		{new(Int).SetUint64(12345), 2, 2, "12345"},  // hiding one digit saves nothing
+		{new(Int).SetUint64(123456), 2, 2, "12…56"}, // hiding two does
+		{new(Int), 0, 0, "0"},
+	} {
+		if got := tc.x.Short(tc.prefix, tc.suffix); got != tc.exp {
+			t.Errorf("Short(%d, %d) of %s: got %q, exp %q", tc.prefix, tc.suffix, tc.x.Dec(), got, tc.exp)
+		}
+	}
+}
+
+func TestDisplayTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("").Parse(`{{.DecimalString}} {{.HexString}} {{.Short 2 2}}`))
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, new(Int).SetUint64(1234567)); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := sb.String(), "1234567 0x12d687 12…67"; got != exp {
+		t.Errorf("got %q, exp %q", got, exp)
+	}
+}
+
+func TestAbbrev(t *testing.T) {
+	x, _ := FromHex("0x1234567890abcdef")
+	for _, tc := range []struct {
+		x          *Int
+		head, tail int
+		exp        string
+	}{
+		{x, 4, 4, "0x1234…cdef"},
+		{x, 0, 4, "0x…cdef"},
+		{x, 4, 0, "0x1234…"},
+		{x, 8, 7, "0x1234567890abcdef"}, // one hidden digit saves nothing
+		{x, 7, 7, "0x1234567…0abcdef"},
+		{x, 100, 100, "0x1234567890abcdef"},
+		{new(Int), 4, 4, "0x0"},
+		{new(Int), 0, 0, "0x0"},
+		{new(Int).SetUint64(0xab), 0, 0, "0x…"},
+		{new(Int).SetAllOne(), 4, 4, "0xffff…ffff"},
+	} {
+		if got := tc.x.Abbrev(tc.head, tc.tail); got != tc.exp {
+			t.Errorf("Abbrev(%d, %d) of %s: got %q, exp %q", tc.head, tc.tail, tc.x.ToHex(), got, tc.exp)
+		}
+	}
+}
@@ -4,10 +4,47 @@ import "math/bits"
 
 // reciprocal2by1 computes <^d, ^0> / d.
 func reciprocal2by1(d uint64) uint64 {
+	if !fastDiv64 {
+		return reciprocal2by1Table(d)
+	}
 	reciprocal, _ := bits.Div64(^d, ^uint64(0), d)
 	return reciprocal
 }
 
+// reciprocalTable holds 0x7fd00 / (256 + i), the initial 11-bit
+// approximations of the reciprocals for reciprocal2by1Table.
+var reciprocalTable = func() (t [256]uint16) {
+	for i := range t {
+		t[i] = uint16(0x7fd00 / (256 + i))
+	}
+	return t
+}()
+
+// reciprocal2by1Table computes <^d, ^0> / d, for normalized d, without
+// division, by refining a table lookup with Newton iterations.
+// Implementation ported from https://github.com/chfast/intx and is based on
+// "Improved division by invariant integers", Algorithm 2.
+func reciprocal2by1Table(d uint64) uint64 {
+	d9 := d >> 55
+	v0 := uint64(reciprocalTable[d9-256])
+
+	d40 := (d >> 24) + 1
+	v1 := (v0 << 11) - uint64(uint32(v0*v0*d40>>40)) - 1
+
+	v2 := (v1 << 13) + (v1 * (0x1000000000000000 - v1*d40) >> 47)
+
+	d0 := d & 1
+	d63 := (d >> 1) + d0 // ceil(d/2)
+	e := ((v2 >> 1) & (0 - d0)) - v2*d63
+	hi, _ := bits.Mul64(v2, e)
+	v3 := (hi >> 1) + (v2 << 31)
+
+	hi, lo := bits.Mul64(v3, d)
+	_, carry := bits.Add64(lo, d, 0)
+	hi += carry
+	return v3 - hi - d
+}
+
 // udivrem2by1 divides <uh, ul> / d and produces both quotient and remainder.
 // It uses the provided d's reciprocal.
 // Implementation ported from https://github.com/chfast/intx and is based on
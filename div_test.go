@@ -0,0 +1,214 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"flag"
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+var exhaustive = flag.Bool("exhaustive", false, "run the division tests over larger sub-ranges of the operands")
+
+// divWords returns the words the division tests build their operands from,
+// and the top words of normalized divisors, which have the high bit set so
+// that divrem shifts them by zero.
+func divWords() (words, tops []uint64) {
+	words = []uint64{0, 1, 1<<63 - 1, 1 << 63, ^uint64(0)}
+	tops = []uint64{1 << 63, 1<<63 + 1, ^uint64(0)}
+	if *exhaustive {
+		words = append(words, 2, 1<<63+1)
+		tops = append(tops, 1<<63+2, ^uint64(0)-1)
+	}
+	return words, tops
+}
+
+// forEachWords calls f with the words sets[0][i0], sets[1][i1], ... for
+// all combinations of the indices, in a slice which f must not retain.
+func forEachWords(sets [][]uint64, f func([]uint64)) {
+	idx := make([]int, len(sets))
+	ws := make([]uint64, len(sets))
+	for {
+		for i, set := range sets {
+			ws[i] = set[idx[i]]
+		}
+		f(ws)
+		i := 0
+		for ; i < len(idx); i++ {
+			if idx[i]++; idx[i] < len(sets[i]) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i == len(idx) {
+			return
+		}
+	}
+}
+
+func wordsToBig(ws []uint64) *big.Int {
+	b := new(big.Int)
+	for i := len(ws) - 1; i >= 0; i-- {
+		b.Lsh(b, 64).Or(b, new(big.Int).SetUint64(ws[i]))
+	}
+	return b
+}
+
+// knuthEstimate returns the quotient digit which udivremKnuth estimates for
+// u / d, with len(u) == len(d)+1, before the add-back correction.
+func knuthEstimate(u, d []uint64) uint64 {
+	n := len(d)
+	u2, u1, u0 := u[n], u[n-1], u[n-2]
+	dh, dl := d[n-1], d[n-2]
+	if u2 >= dh {
+		return ^uint64(0)
+	}
+	qhat, rhat := bits.Div64(u2, u1, dh)
+	if ph, pl := bits.Mul64(qhat, dl); ph > rhat || (ph == rhat && pl > u0) {
+		qhat--
+	}
+	return qhat
+}
+
+// TestKnuthAddBack exhaustively divides, over sub-ranges of the operands,
+// n+1 words by normalized n-word divisors with a single step of
+// udivremKnuth, and checks that the rare add-back path, taken when the
+// estimated quotient digit is one too large, is both reached and correct.
+func TestKnuthAddBack(t *testing.T) {
+	words, tops := divWords()
+	for n := 2; n <= 4; n++ {
+		sets := make([][]uint64, 0, 2*n+1)
+		for i := 0; i < n-1; i++ {
+			sets = append(sets, words)
+		}
+		sets = append(sets, tops)
+		for i := 0; i < n; i++ {
+			sets = append(sets, words)
+		}
+		// The top word of u, which is chosen below relative to dh.
+		sets = append(sets, []uint64{0, 1, 2, 3})
+		var cases, addBacks int
+		forEachWords(sets, func(ws []uint64) {
+			d := append([]uint64(nil), ws[:n]...)
+			u := append([]uint64(nil), ws[n:2*n]...)
+			dh := d[n-1]
+			// The quotient digit must fit in a word, so u[1:] < d.
+			u = append(u, []uint64{0, 1, dh - 2, dh - 1}[ws[2*n]])
+			bu, bd := wordsToBig(u), wordsToBig(d)
+			if wordsToBig(u[1:]).Cmp(bd) >= 0 {
+				return
+			}
+			q, r := new(big.Int).QuoRem(bu, bd, new(big.Int))
+			qhat := knuthEstimate(u, d)
+			if qhat != q.Uint64() {
+				if qhat-1 != q.Uint64() {
+					t.Fatalf("u=%#x d=%#x: estimate %#x off by more than one from %#x", u, d, qhat, q)
+				}
+				addBacks++
+			}
+			cases++
+			var quot [1]uint64
+			un := append([]uint64(nil), u...)
+			udivremKnuth(quot[:], un, d)
+			if quot[0] != q.Uint64() || wordsToBig(un).Cmp(r) != 0 {
+				t.Fatalf("u=%#x d=%#x: have quotient %#x remainder %#x, want %#x %#x", u, d, quot[0], un, q, r)
+			}
+		})
+		if addBacks == 0 {
+			t.Errorf("n=%d: add-back path not reached in %d cases", n, cases)
+		}
+		t.Logf("n=%d: %d cases, %d add-backs", n, cases, addBacks)
+	}
+}
+
+// TestDivAddBack divides constructed operands which take the add-back path
+// of udivremKnuth in their first step, through Div and Mod: with the
+// divisor y = {d0, d1, dh}, the dividend x = {a, b, d1, dh} and b < d0, the
+// first quotient digit is estimated as 1 from the top words, while the
+// true digit is 0.
+func TestDivAddBack(t *testing.T) {
+	words, tops := divWords()
+	low := []uint64{1, 2, 3, 1 << 63, ^uint64(0)}
+	if *exhaustive {
+		low = append(low, 4, 5, 1<<32, 1<<63+1)
+	}
+	sets := [][]uint64{words, low, words, tops, {0, 1}}
+	forEachWords(sets, func(ws []uint64) {
+		a, d0, d1, dh := ws[0], ws[1], ws[2], ws[3]
+		b := d0 - 1
+		if ws[4] == 1 {
+			b = 0
+		}
+		x, y := &Int{a, b, d1, dh}, &Int{d0, d1, dh, 0}
+		if knuthEstimate([]uint64{b, d1, dh, 0}, y[:3]) != 1 {
+			t.Fatalf("x=%v y=%v: add-back not taken", x.Hex(), y.Hex())
+		}
+		checkDiv(t, x, y)
+	})
+}
+
+// TestDivNormalized divides by every divisor, from sub-ranges of the words,
+// whose top word has the high bit set, so that divrem normalizes it by a
+// shift of zero, where its shifts by 64-shift move whole words out.
+func TestDivNormalized(t *testing.T) {
+	words, tops := divWords()
+	xs := []uint64{0, 1, 1 << 63, ^uint64(0)}
+	if *exhaustive {
+		xs = words
+	}
+	for n := 1; n <= 4; n++ {
+		sets := make([][]uint64, 0, n)
+		for i := 0; i < n-1; i++ {
+			sets = append(sets, words)
+		}
+		sets = append(sets, tops)
+		forEachWords(sets, func(ds []uint64) {
+			var y Int
+			copy(y[:], ds)
+			forEachWords([][]uint64{xs, xs, xs, xs}, func(ws []uint64) {
+				checkDiv(t, &Int{ws[0], ws[1], ws[2], ws[3]}, &y)
+			})
+		})
+	}
+}
+
+// checkDiv checks Div and Mod of x by y against big.Int, and with
+// CheckInvariants.
+func checkDiv(t *testing.T, x, y *Int) {
+	t.Helper()
+	quot, rem := new(Int).Div(x, y), new(Int).Mod(x, y)
+	q, r := new(big.Int).QuoRem(x.ToBig(), y.ToBig(), new(big.Int))
+	if !checkEq(q, quot) || !checkEq(r, rem) {
+		t.Fatalf("%v / %v: have %v, %v, want %#x, %#x", x.Hex(), y.Hex(), quot.Hex(), rem.Hex(), q, r)
+	}
+	if err := CheckInvariants(x, y, quot, rem); err != nil {
+		t.Fatalf("%v / %v: %v", x.Hex(), y.Hex(), err)
+	}
+}
+
+// TestReciprocal2by1Table checks the division-free reciprocal used on
+// 32-bit and WebAssembly targets against bits.Div64, on every platform.
+func TestReciprocal2by1Table(t *testing.T) {
+	check := func(d uint64) {
+		exp, _ := bits.Div64(^d, ^uint64(0), d)
+		if got := reciprocal2by1Table(d); got != exp {
+			t.Fatalf("reciprocal of %#x: got %#x, exp %#x", d, got, exp)
+		}
+	}
+	// Every table entry at both ends, and the extremes.
+	for i := uint64(0); i < 256; i++ {
+		check((256 + i) << 55)
+		check((256+i)<<55 | (1<<55 - 1))
+	}
+	check(1 << 63)
+	check(^uint64(0))
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000000; i++ {
+		check(rng.Uint64() | 1<<63)
+	}
+}
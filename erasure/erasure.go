@@ -0,0 +1,248 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package erasure implements systematic Reed-Solomon erasure coding with
+// 256-bit symbols over a prime field, for data-availability use cases.
+//
+// The codes are evaluation codes: the data shards are the evaluations of a
+// polynomial of degree < k at the points 0..k-1, and the parity shards are its
+// evaluations at the points k..n-1. Any k of the n shards determine the rest.
+package erasure
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	errShardCount   = errors.New("erasure: invalid number of shards")
+	errShardSize    = errors.New("erasure: shards must have the same, non-zero size")
+	errTooFewShards = errors.New("erasure: too few shards to reconstruct")
+	errSymbol       = errors.New("erasure: symbol not reduced modulo the field")
+)
+
+// Field is the arithmetic of a prime field, in the shape used by generic
+// erasure-coding implementations. All operands are reduced modulo the field,
+// and each operation sets z and returns it.
+type Field interface {
+	// Modulus returns the prime modulus of the field.
+	Modulus() *uint256.Int
+	// Add sets z to x + y.
+	Add(z, x, y *uint256.Int) *uint256.Int
+	// Sub sets z to x - y.
+	Sub(z, x, y *uint256.Int) *uint256.Int
+	// Mul sets z to x * y.
+	Mul(z, x, y *uint256.Int) *uint256.Int
+	// Inv sets z to 1 / x. It returns false if x is zero.
+	Inv(z, x *uint256.Int) (*uint256.Int, bool)
+}
+
+// PrimeField implements Field on top of the Montgomery arithmetic of a
+// uint256.Field.
+type PrimeField struct {
+	f       *uint256.Field
+	modulus uint256.Int
+}
+
+// NewPrimeField returns a Field backed by f, whose modulus must be prime.
+func NewPrimeField(f *uint256.Field) *PrimeField {
+	return &PrimeField{f: f, modulus: *f.Modulus()}
+}
+
+// Modulus returns a copy of the modulus of the field.
+func (p *PrimeField) Modulus() *uint256.Int {
+	return new(uint256.Int).Copy(&p.modulus)
+}
+
+// Add sets z to x + y modulo the field, and returns z.
+func (p *PrimeField) Add(z, x, y *uint256.Int) *uint256.Int { return z.AddF(x, y, p.f) }
+
+// Sub sets z to x - y modulo the field, and returns z.
+func (p *PrimeField) Sub(z, x, y *uint256.Int) *uint256.Int { return z.SubF(x, y, p.f) }
+
+// Mul sets z to x * y modulo the field, and returns z.
+func (p *PrimeField) Mul(z, x, y *uint256.Int) *uint256.Int { return z.MulF(x, y, p.f) }
+
+// Inv sets z to the inverse of x modulo the field, and returns z and true.
+// If x is zero, z is left unchanged and false is returned.
+func (p *PrimeField) Inv(z, x *uint256.Int) (*uint256.Int, bool) {
+	return z.ModInverse(x, &p.modulus)
+}
+
+// Encoder encodes and reconstructs shards of a fixed data/parity layout.
+type Encoder struct {
+	field        Field
+	data, parity int
+	points       []uint256.Int // evaluation point of each shard
+}
+
+// NewEncoder returns an Encoder over field for the given number of data and
+// parity shards. The total number of shards must not exceed the modulus.
+func NewEncoder(field Field, dataShards, parityShards int) (*Encoder, error) {
+	n := dataShards + parityShards
+	if dataShards < 1 || parityShards < 0 || field.Modulus().LtUint64(uint64(n)) {
+		return nil, errShardCount
+	}
+	e := &Encoder{field: field, data: dataShards, parity: parityShards, points: make([]uint256.Int, n)}
+	for i := range e.points {
+		e.points[i].SetUint64(uint64(i))
+	}
+	return e, nil
+}
+
+// lagrange returns the coefficients c such that the polynomial of degree
+// < len(from) through the points (x[from[i]], y_i) takes the value
+// sum(c_i * y_i) at x[to].
+func (e *Encoder) lagrange(from []int, to int) []uint256.Int {
+	var (
+		f        = e.field
+		coeffs   = make([]uint256.Int, len(from))
+		num, den uint256.Int
+		diff     uint256.Int
+		xt       = &e.points[to]
+	)
+	for i, a := range from {
+		num.SetOne()
+		den.SetOne()
+		xa := &e.points[a]
+		for _, b := range from {
+			if b == a {
+				continue
+			}
+			xb := &e.points[b]
+			f.Mul(&num, &num, f.Sub(&diff, xt, xb))
+			f.Mul(&den, &den, f.Sub(&diff, xa, xb))
+		}
+		f.Inv(&den, &den) // non-zero, since the points are distinct
+		f.Mul(&coeffs[i], &num, &den)
+	}
+	return coeffs
+}
+
+// shardSize checks that all non-nil shards have the same non-zero size, and
+// that their symbols are reduced, and returns the size.
+func (e *Encoder) shardSize(shards [][]uint256.Int) (int, error) {
+	m := e.field.Modulus()
+	size := 0
+	for _, s := range shards {
+		if s == nil {
+			continue
+		}
+		if size == 0 {
+			size = len(s)
+		}
+		if len(s) == 0 || len(s) != size {
+			return 0, errShardSize
+		}
+		for i := range s {
+			if !s[i].Lt(m) {
+				return 0, errSymbol
+			}
+		}
+	}
+	if size == 0 {
+		return 0, errShardSize
+	}
+	return size, nil
+}
+
+// fill computes the shard at index to from the shards at indices from.
+func (e *Encoder) fill(shards [][]uint256.Int, from []int, to, size int) {
+	coeffs := e.lagrange(from, to)
+	out := make([]uint256.Int, size)
+	var t uint256.Int
+	for i, idx := range from {
+		c := &coeffs[i]
+		for s, y := range shards[idx] {
+			e.field.Add(&out[s], &out[s], e.field.Mul(&t, c, &y))
+		}
+	}
+	shards[to] = out
+}
+
+// Encode computes the parity shards from the data shards. The shards slice
+// must hold the data shards followed by the parity shards, which may be nil.
+func (e *Encoder) Encode(shards [][]uint256.Int) error {
+	if len(shards) != e.data+e.parity {
+		return errShardCount
+	}
+	for i := 0; i < e.data; i++ {
+		if shards[i] == nil {
+			return errShardSize
+		}
+	}
+	size, err := e.shardSize(shards[:e.data])
+	if err != nil {
+		return err
+	}
+	from := make([]int, e.data)
+	for i := range from {
+		from[i] = i
+	}
+	for j := e.data; j < len(shards); j++ {
+		e.fill(shards, from, j, size)
+	}
+	return nil
+}
+
+// Reconstruct recreates the missing (nil) shards in place, from any dataShards
+// of the present ones.
+func (e *Encoder) Reconstruct(shards [][]uint256.Int) error {
+	if len(shards) != e.data+e.parity {
+		return errShardCount
+	}
+	var present, missing []int
+	for i, s := range shards {
+		if s == nil {
+			missing = append(missing, i)
+		} else if len(present) < e.data {
+			present = append(present, i)
+		}
+	}
+	if len(present) < e.data {
+		return errTooFewShards
+	}
+	size, err := e.shardSize(shards)
+	if err != nil {
+		return err
+	}
+	for _, j := range missing {
+		e.fill(shards, present, j, size)
+	}
+	return nil
+}
+
+// Verify reports whether the parity shards are consistent with the data
+// shards.
+func (e *Encoder) Verify(shards [][]uint256.Int) (bool, error) {
+	if len(shards) != e.data+e.parity {
+		return false, errShardCount
+	}
+	for _, s := range shards {
+		if s == nil {
+			return false, errShardSize
+		}
+	}
+	size, err := e.shardSize(shards)
+	if err != nil {
+		return false, err
+	}
+	check := make([][]uint256.Int, len(shards))
+	copy(check, shards[:e.data])
+	from := make([]int, e.data)
+	for i := range from {
+		from[i] = i
+	}
+	for j := e.data; j < len(shards); j++ {
+		e.fill(check, from, j, size)
+		for s := range check[j] {
+			if !check[j][s].Eq(&shards[j][s]) {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package erasure
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func randShards(rng *rand.Rand, field Field, n, size int) [][]uint256.Int {
+	m := field.Modulus()
+	shards := make([][]uint256.Int, n)
+	for i := range shards {
+		shards[i] = make([]uint256.Int, size)
+		for s := range shards[i] {
+			shards[i][s] = uint256.Int{rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64()}
+			shards[i][s].Mod(&shards[i][s], m)
+		}
+	}
+	return shards
+}
+
+func TestEncodeReconstruct(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	fields := []Field{
+		NewPrimeField(uint256.BN254Scalar),
+		NewPrimeField(uint256.NewField(new(uint256.Int).SetUint64(97))),
+	}
+	for _, field := range fields {
+		for _, layout := range [][2]int{{1, 0}, {1, 3}, {4, 2}, {10, 4}} {
+			data, parity := layout[0], layout[1]
+			enc, err := NewEncoder(field, data, parity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			shards := append(randShards(rng, field, data, 16), make([][]uint256.Int, parity)...)
+			if err := enc.Encode(shards); err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := enc.Verify(shards); !ok || err != nil {
+				t.Fatalf("%v: verify failed (err %v)", layout, err)
+			}
+			orig := append([][]uint256.Int{}, shards...)
+			// Drop a random selection of parity-many shards.
+			damaged := append([][]uint256.Int{}, shards...)
+			for _, i := range rng.Perm(data + parity)[:parity] {
+				damaged[i] = nil
+			}
+			if err := enc.Reconstruct(damaged); err != nil {
+				t.Fatal(err)
+			}
+			for i := range orig {
+				for s := range orig[i] {
+					if !damaged[i][s].Eq(&orig[i][s]) {
+						t.Fatalf("%v: shard %d symbol %d mismatch", layout, i, s)
+					}
+				}
+			}
+			if parity > 0 {
+				field.Add(&shards[data][0], &shards[data][0], &uint256.Int{1})
+				if ok, _ := enc.Verify(shards); ok {
+					t.Fatalf("%v: corrupted parity verified", layout)
+				}
+				for i := 0; i <= parity; i++ {
+					damaged[i] = nil
+				}
+				if err := enc.Reconstruct(damaged); err != errTooFewShards {
+					t.Fatalf("%v: expected errTooFewShards, got %v", layout, err)
+				}
+			}
+		}
+	}
+}
+
+func TestEncoderErrors(t *testing.T) {
+	field := NewPrimeField(uint256.NewField(new(uint256.Int).SetUint64(7)))
+	if _, err := NewEncoder(field, 5, 3); err != errShardCount {
+		t.Errorf("expected errShardCount for too many shards, got %v", err)
+	}
+	if _, err := NewEncoder(field, 0, 3); err != errShardCount {
+		t.Errorf("expected errShardCount for no data shards, got %v", err)
+	}
+	enc, _ := NewEncoder(field, 2, 1)
+	if err := enc.Encode(make([][]uint256.Int, 2)); err != errShardCount {
+		t.Errorf("expected errShardCount, got %v", err)
+	}
+	if err := enc.Encode([][]uint256.Int{{{1}}, {{1}, {2}}, nil}); err != errShardSize {
+		t.Errorf("expected errShardSize, got %v", err)
+	}
+	if err := enc.Encode([][]uint256.Int{{{1}}, {{7}}, nil}); err != errSymbol {
+		t.Errorf("expected errSymbol, got %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package eval evaluates arithmetic expressions over uint256.Int, such as
+// "(2**255 - 19) % x", for command-line tools, debugging and limit formulas
+// in configuration.
+//
+// Literals are decimal, optionally with a fraction and exponent as long as
+// the value is an integer, e.g. 1e18 or 2.5e9, or 0x-prefixed hex.
+// Identifiers name variables, or functions when followed by arguments in
+// parentheses: min(x, y), max(x, y), addmod(x, y, m) and mulmod(x, y, m).
+// The operators, from the lowest precedence to the highest, are
+//
+//	|
+//	^
+//	&
+//	<<  >>
+//	+   -
+//	*   /   %
+//	-   ~       (unary)
+//	**          (right-associative)
+//
+// so that, as in Python, -x**2 is -(x**2). Eval checks for overflow and
+// underflow, so that a mistaken formula fails rather than giving a wrapped
+// result; EvalWrapping computes modulo 2**256 instead, as the EVM does.
+package eval
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	// ErrSyntax is wrapped by the errors for malformed expressions.
+	ErrSyntax = errors.New("eval: syntax error")
+	// ErrOverflow is wrapped by the errors for results outside
+	// [0, 2**256 - 1] in checked evaluation.
+	ErrOverflow = errors.New("eval: overflow")
+	// ErrDivisionByZero is wrapped by the errors for division or modulo by
+	// zero.
+	ErrDivisionByZero = errors.New("eval: division by zero")
+	// ErrUndefined is wrapped by the errors for unbound variables.
+	ErrUndefined = errors.New("eval: undefined variable")
+)
+
+// Error is the error returned for a malformed expression or a failed
+// evaluation, locating the cause in the expression.
+type Error struct {
+	Expr   string // The expression.
+	Offset int    // Byte offset of the failing token or operator in Expr.
+	Err    error  // The cause, wrapping one of the Err variables.
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v at offset %d in %q", e.Err, e.Offset, e.Expr)
+}
+
+// Unwrap returns the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// funcArity holds the number of arguments of the functions.
+var funcArity = map[string]int{
+	"min":    2,
+	"max":    2,
+	"addmod": 3,
+	"mulmod": 3,
+}
+
+// node is a node of the syntax tree of an expression.
+type node struct {
+	op   string // Operator or function name; empty for literals and variables.
+	pos  int    // Offset of the token in the expression.
+	val  *uint256.Int
+	name string // Variable name.
+	args []*node
+}
+
+// Expr is a parsed expression, which can be evaluated repeatedly with
+// different variables. It is safe for concurrent use.
+type Expr struct {
+	src  string
+	root *node
+}
+
+// Parse parses the expression s.
+func Parse(s string) (*Expr, error) {
+	p := &parser{src: s}
+	p.next()
+	root := p.parseBinary(0)
+	if p.err == nil && p.tok != "" {
+		p.fail(fmt.Errorf("%w: unexpected %q", ErrSyntax, p.tok))
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &Expr{src: s, root: root}, nil
+}
+
+// Eval parses and evaluates the expression s, with checked arithmetic.
+func Eval(s string, vars map[string]*uint256.Int) (*uint256.Int, error) {
+	e, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return e.Eval(vars)
+}
+
+// String returns the source of e.
+func (e *Expr) String() string {
+	return e.src
+}
+
+// Vars returns the names of the variables in e, in sorted order.
+func (e *Expr) Vars() []string {
+	seen := make(map[string]bool)
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.name != "" {
+			seen[n.name] = true
+		}
+		for _, a := range n.args {
+			walk(a)
+		}
+	}
+	walk(e.root)
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Eval evaluates e with the given variables, failing on overflow and
+// underflow.
+func (e *Expr) Eval(vars map[string]*uint256.Int) (*uint256.Int, error) {
+	return e.eval(vars, false)
+}
+
+// EvalWrapping evaluates e with the given variables, computing modulo
+// 2**256: unary minus is the two's complement, and shifts by 256 or more
+// give zero.
+func (e *Expr) EvalWrapping(vars map[string]*uint256.Int) (*uint256.Int, error) {
+	return e.eval(vars, true)
+}
+
+func (e *Expr) eval(vars map[string]*uint256.Int, wrap bool) (*uint256.Int, error) {
+	ev := evaluator{vars: vars, wrap: wrap}
+	z, err := ev.eval(e.root)
+	if err != nil {
+		return nil, &Error{Expr: e.src, Offset: ev.pos, Err: err}
+	}
+	return z, nil
+}
+
+// parser is a recursive-descent parser of expressions.
+type parser struct {
+	src string
+	off int    // Offset of the next token.
+	tok string // Current token, empty at the end.
+	pos int    // Offset of the current token.
+	err error
+}
+
+// operators holds the operator tokens, longest first.
+var operators = []string{"**", "<<", ">>", "+", "-", "*", "/", "%", "&", "|", "^", "~", "(", ")", ","}
+
+// binaryPrec holds the precedence levels of the binary operators, except **.
+var binaryPrec = [][]string{
+	{"|"},
+	{"^"},
+	{"&"},
+	{"<<", ">>"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (p *parser) fail(err error) {
+	if p.err == nil {
+		p.err = &Error{Expr: p.src, Offset: p.pos, Err: err}
+	}
+}
+
+// next advances to the next token.
+func (p *parser) next() {
+	s := p.src
+	for p.off < len(s) && strings.IndexByte(" \t\r\n", s[p.off]) >= 0 {
+		p.off++
+	}
+	p.pos = p.off
+	if p.off == len(s) {
+		p.tok = ""
+		return
+	}
+	end := p.off + 1
+	switch c := s[p.off]; {
+	case isDigit(c):
+		// Numbers, including hex digits, fractions and exponents; the
+		// literal parsers check the details.
+		hex := end < len(s) && (s[end] == 'x' || s[end] == 'X')
+		for end < len(s) {
+			c := s[end]
+			sign := (c == '+' || c == '-') && (s[end-1] == 'e' || s[end-1] == 'E') && !hex
+			if !isDigit(c) && !isLetter(c) && c != '.' && !sign {
+				break
+			}
+			end++
+		}
+	case isLetter(c):
+		for end < len(s) && (isLetter(s[end]) || isDigit(s[end])) {
+			end++
+		}
+	default:
+		end = p.off
+		for _, op := range operators {
+			if strings.HasPrefix(s[p.off:], op) {
+				end = p.off + len(op)
+				break
+			}
+		}
+		if end == p.off {
+			p.tok = s[p.off : p.off+1]
+			p.fail(fmt.Errorf("%w: unexpected %q", ErrSyntax, p.tok))
+			p.tok, p.off = "", len(s)
+			return
+		}
+	}
+	p.tok, p.off = s[p.off:end], end
+}
+
+// parseBinary parses the operators of precedence level and above.
+func (p *parser) parseBinary(level int) *node {
+	if level == len(binaryPrec) {
+		return p.parseUnary()
+	}
+	x := p.parseBinary(level + 1)
+	for p.err == nil {
+		op, pos := p.tok, p.pos
+		found := false
+		for _, o := range binaryPrec[level] {
+			found = found || o == op
+		}
+		if !found {
+			break
+		}
+		p.next()
+		y := p.parseBinary(level + 1)
+		x = &node{op: op, pos: pos, args: []*node{x, y}}
+	}
+	return x
+}
+
+// parseUnary parses unary operators, which bind less tightly than **.
+func (p *parser) parseUnary() *node {
+	if op, pos := p.tok, p.pos; op == "-" || op == "~" {
+		p.next()
+		return &node{op: "unary" + op, pos: pos, args: []*node{p.parseUnary()}}
+	}
+	return p.parsePower()
+}
+
+// parsePower parses **, which is right-associative, and whose exponent may
+// have unary operators.
+func (p *parser) parsePower() *node {
+	x := p.parseOperand()
+	if p.err == nil && p.tok == "**" {
+		pos := p.pos
+		p.next()
+		x = &node{op: "**", pos: pos, args: []*node{x, p.parseUnary()}}
+	}
+	return x
+}
+
+// parseOperand parses a literal, variable, function call or parenthesized
+// expression.
+func (p *parser) parseOperand() *node {
+	if p.err != nil {
+		return nil
+	}
+	tok, pos := p.tok, p.pos
+	switch {
+	case tok == "":
+		p.fail(fmt.Errorf("%w: unexpected end of expression", ErrSyntax))
+		return nil
+	case tok == "(":
+		p.next()
+		x := p.parseBinary(0)
+		p.expect(")")
+		return x
+	case isDigit(tok[0]):
+		var (
+			v   *uint256.Int
+			err error
+		)
+		if strings.HasPrefix(tok, "0x") || strings.HasPrefix(tok, "0X") {
+			v, err = uint256.FromHex(tok)
+		} else {
+			v, err = uint256.FromJSONNumber(json.Number(tok))
+		}
+		if err != nil {
+			p.fail(fmt.Errorf("%w: %v", ErrSyntax, err))
+			return nil
+		}
+		p.next()
+		return &node{pos: pos, val: v}
+	case isLetter(tok[0]):
+		p.next()
+		if p.tok != "(" {
+			return &node{pos: pos, name: tok}
+		}
+		arity, ok := funcArity[tok]
+		if !ok {
+			p.pos = pos
+			p.fail(fmt.Errorf("%w: unknown function %q", ErrSyntax, tok))
+			return nil
+		}
+		p.next()
+		call := &node{op: tok, pos: pos}
+		for p.err == nil && p.tok != ")" {
+			if len(call.args) > 0 {
+				p.expect(",")
+			}
+			call.args = append(call.args, p.parseBinary(0))
+		}
+		p.expect(")")
+		if p.err == nil && len(call.args) != arity {
+			p.pos = pos
+			p.fail(fmt.Errorf("%w: %s takes %d arguments, have %d", ErrSyntax, tok, arity, len(call.args)))
+		}
+		return call
+	}
+	p.fail(fmt.Errorf("%w: unexpected %q", ErrSyntax, tok))
+	return nil
+}
+
+// expect consumes the token tok, or fails.
+func (p *parser) expect(tok string) {
+	if p.err != nil {
+		return
+	}
+	if p.tok != tok {
+		if p.tok == "" {
+			p.fail(fmt.Errorf("%w: missing %q", ErrSyntax, tok))
+		} else {
+			p.fail(fmt.Errorf("%w: unexpected %q, want %q", ErrSyntax, p.tok, tok))
+		}
+		return
+	}
+	p.next()
+}
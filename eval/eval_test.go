@@ -0,0 +1,145 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package eval
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func TestEval(t *testing.T) {
+	vars := map[string]*uint256.Int{
+		"x":     new(uint256.Int).SetUint64(1000),
+		"gwei":  new(uint256.Int).SetUint64(1000000000),
+		"max_1": new(uint256.Int).SetAllOne(),
+	}
+	tests := []struct {
+		expr string
+		want string // Decimal, or 0x-prefixed hex.
+	}{
+		{"1 + 2 * 3", "7"},
+		{"(1 + 2) * 3", "9"},
+		{"2 ** 3 ** 2", "512"},
+		{"10 - 4 - 3", "3"},
+		{"100 / 7 % 4", "2"},
+		{"1 << 8 >> 4", "16"},
+		{"0xf0 | 0x0f ^ 0xff & 0x3c", "0xf3"},
+		{"(2**255 - 19) % x", "949"},
+		{"21000 * 30 * gwei", "630000000000000"},
+		{"2.5e18", "2500000000000000000"},
+		{"1E3 + 0X10", "1016"},
+		{"min(x, 7) + max(x, 7)", "1007"},
+		{"addmod(max_1, 2, 10)", "7"},
+		{"mulmod(max_1, max_1, 12)", "9"},
+		{"~0 - max_1", "0"},
+		{"-0", "0"},
+		{"max_1", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	}
+	for _, tc := range tests {
+		have, err := Eval(tc.expr, vars)
+		if err != nil {
+			t.Errorf("%q: %v", tc.expr, err)
+			continue
+		}
+		want, _ := uint256.FromDecimal(tc.want)
+		if len(tc.want) > 2 && tc.want[:2] == "0x" {
+			want, _ = uint256.FromHex(tc.want)
+		}
+		if !have.Eq(want) {
+			t.Errorf("%q: have %v, want %v", tc.expr, have.Dec(), want.Dec())
+		}
+	}
+}
+
+func TestEvalWrapping(t *testing.T) {
+	tests := []struct {
+		expr string
+		want *uint256.Int
+	}{
+		{"-1", new(uint256.Int).SetAllOne()},
+		{"2**256 + 5", new(uint256.Int).SetUint64(5)},
+		{"0 - 1 + 2", new(uint256.Int).SetOne()},
+		{"1 << 256", new(uint256.Int)},
+		{"-2**2 + 5", new(uint256.Int).SetOne()},
+		{"~0 * ~0", new(uint256.Int).SetOne()},
+	}
+	for _, tc := range tests {
+		e, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.expr, err)
+		}
+		have, err := e.EvalWrapping(nil)
+		if err != nil || !have.Eq(tc.want) {
+			t.Errorf("%q: have %v %v, want %v", tc.expr, have, err, tc.want)
+		}
+		// The same expressions overflow in checked mode.
+		if _, err := e.Eval(nil); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%q: checked: have %v, want %v", tc.expr, err, ErrOverflow)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	vars := map[string]*uint256.Int{"x": new(uint256.Int)}
+	tests := []struct {
+		expr   string
+		want   error
+		offset int
+	}{
+		{"", ErrSyntax, 0},
+		{"1 +", ErrSyntax, 3},
+		{"(1 + 2", ErrSyntax, 6},
+		{"1 2", ErrSyntax, 2},
+		{"1 $ 2", ErrSyntax, 2},
+		{"1.5", ErrSyntax, 0},
+		{"0xg", ErrSyntax, 0},
+		{"3x", ErrSyntax, 0},
+		{"foo(1)", ErrSyntax, 0},
+		{"min(1)", ErrSyntax, 0},
+		{"min(1, 2, 3)", ErrSyntax, 0},
+		{"1 + y", ErrUndefined, 4},
+		{"5 / x", ErrDivisionByZero, 2},
+		{"5 % (x * 2)", ErrDivisionByZero, 2},
+		{"addmod(1, 2, x)", ErrDivisionByZero, 0},
+		{"1 + (x - 1)", ErrOverflow, 7},
+		{"2**128 * 2**128", ErrOverflow, 7},
+		{"3 ** 162", ErrOverflow, 2},
+		{"1 << 256", ErrOverflow, 2},
+		{"2**255 << 1", ErrOverflow, 7},
+	}
+	for _, tc := range tests {
+		_, err := Eval(tc.expr, vars)
+		var e *Error
+		if !errors.Is(err, tc.want) || !errors.As(err, &e) {
+			t.Errorf("%q: have %v, want %v", tc.expr, err, tc.want)
+			continue
+		}
+		if e.Offset != tc.offset {
+			t.Errorf("%q: have offset %d, want %d (%v)", tc.expr, e.Offset, tc.offset, err)
+		}
+	}
+}
+
+func TestExprVars(t *testing.T) {
+	e, err := Parse("a * (b + a) - min(c, 1)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := e.Vars(), []string{"a", "b", "c"}; !reflect.DeepEqual(have, want) {
+		t.Errorf("have %v, want %v", have, want)
+	}
+	if e.String() != "a * (b + a) - min(c, 1)" {
+		t.Errorf("String: have %q", e.String())
+	}
+	// Variables are not modified by evaluation.
+	a := new(uint256.Int).SetUint64(3)
+	if _, err := Eval("-a", map[string]*uint256.Int{"a": a}); err == nil || a.Uint64() != 3 {
+		t.Errorf("variable modified: %v", a)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package eval
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256"
+)
+
+// evaluator evaluates a syntax tree.
+type evaluator struct {
+	vars map[string]*uint256.Int
+	wrap bool
+	pos  int // Offset of the node which failed.
+}
+
+func (ev *evaluator) eval(n *node) (*uint256.Int, error) {
+	if n.val != nil {
+		return n.val.Clone(), nil
+	}
+	if n.name != "" {
+		v, ok := ev.vars[n.name]
+		if !ok || v == nil {
+			ev.pos = n.pos
+			return nil, fmt.Errorf("%w %q", ErrUndefined, n.name)
+		}
+		return v.Clone(), nil
+	}
+	args := make([]*uint256.Int, len(n.args))
+	for i, a := range n.args {
+		v, err := ev.eval(a)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	z, err := ev.apply(n.op, args)
+	if err != nil {
+		ev.pos = n.pos
+		return nil, err
+	}
+	return z, nil
+}
+
+// apply applies the operator or function op to args.
+func (ev *evaluator) apply(op string, args []*uint256.Int) (*uint256.Int, error) {
+	z := new(uint256.Int)
+	x := args[0]
+	var y *uint256.Int
+	if len(args) > 1 {
+		y = args[1]
+	}
+	switch op {
+	case "unary-":
+		if !x.IsZero() && !ev.wrap {
+			return nil, ErrOverflow
+		}
+		return x.NegOf(x), nil
+	case "unary~":
+		return x.NotOf(x), nil
+	case "+":
+		if z.AddOverflow(x, y) && !ev.wrap {
+			return nil, ErrOverflow
+		}
+	case "-":
+		if z.SubOverflow(x, y) && !ev.wrap {
+			return nil, ErrOverflow
+		}
+	case "*":
+		z.Mul(x, y)
+		if !ev.wrap && !x.IsZero() && !new(uint256.Int).Div(z, x).Eq(y) {
+			return nil, ErrOverflow
+		}
+	case "/", "%":
+		if y.IsZero() {
+			return nil, ErrDivisionByZero
+		}
+		if op == "/" {
+			return z.Div(x, y), nil
+		}
+		return z.Mod(x, y), nil
+	case "**":
+		return ev.exp(x, y)
+	case "<<", ">>":
+		n := uint(256)
+		if y.LtUint64(256) {
+			n = uint(y.Uint64())
+		}
+		if op == ">>" {
+			return z.Rsh(x, n), nil
+		}
+		z.Lsh(x, n)
+		if !ev.wrap && !x.IsZero() && (n == 256 || !new(uint256.Int).Rsh(z, n).Eq(x)) {
+			return nil, ErrOverflow
+		}
+	case "&":
+		z.And(x, y)
+	case "|":
+		z.Or(x, y)
+	case "^":
+		z.Xor(x, y)
+	case "min":
+		if x.Lt(y) {
+			return x, nil
+		}
+		return y, nil
+	case "max":
+		if x.Gt(y) {
+			return x, nil
+		}
+		return y, nil
+	case "addmod", "mulmod":
+		if args[2].IsZero() {
+			return nil, ErrDivisionByZero
+		}
+		if op == "addmod" {
+			return z.AddMod(x, y, args[2]), nil
+		}
+		return z.MulMod(x, y, args[2]), nil
+	default:
+		panic("eval: unknown operator " + op)
+	}
+	return z, nil
+}
+
+// exp returns x**y, checking for overflow unless wrapping.
+func (ev *evaluator) exp(x, y *uint256.Int) (*uint256.Int, error) {
+	z := new(uint256.Int)
+	if ev.wrap || x.IsZero() || x.IsOne() || y.IsZero() {
+		return z.Exp(x, y), nil
+	}
+	// For x >= 2, x**y overflows for any y >= 256.
+	if !y.LtUint64(256) {
+		return nil, ErrOverflow
+	}
+	z.SetOne()
+	for i := y.Uint64(); i > 0; i-- {
+		p := new(uint256.Int).Mul(z, x)
+		if !new(uint256.Int).Div(p, x).Eq(z) {
+			return nil, ErrOverflow
+		}
+		z = p
+	}
+	return z, nil
+}
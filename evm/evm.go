@@ -0,0 +1,48 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package evm implements the comparison opcodes of the Ethereum Virtual
+// Machine on uint256.Int stack slots, in the way interpreters execute them:
+// an opcode pops its first operand x, and overwrites its last operand y,
+// the new top of the stack, with the result, which is 1 for true and 0 for
+// false. Only y is modified.
+//
+// Outside of a stack machine, use the boolean comparisons of uint256.Int,
+// with uint256.Int.SetBool or uint256.BoolToInt where a number is needed.
+package evm
+
+import "github.com/holiman/uint256"
+
+// Lt sets y to x < y, as the LT opcode does.
+func Lt(x, y *uint256.Int) {
+	y.SetBool(x.Lt(y))
+}
+
+// Gt sets y to x > y, as the GT opcode does.
+func Gt(x, y *uint256.Int) {
+	y.SetBool(x.Gt(y))
+}
+
+// Slt sets y to x < y, with x and y interpreted as two's complement signed
+// integers, as the SLT opcode does.
+func Slt(x, y *uint256.Int) {
+	y.SetBool(x.Slt(y))
+}
+
+// Sgt sets y to x > y, with x and y interpreted as two's complement signed
+// integers, as the SGT opcode does.
+func Sgt(x, y *uint256.Int) {
+	y.SetBool(x.Sgt(y))
+}
+
+// Eq sets y to x == y, as the EQ opcode does.
+func Eq(x, y *uint256.Int) {
+	y.SetBool(x.Eq(y))
+}
+
+// IsZero sets x to x == 0, as the ISZERO opcode does.
+func IsZero(x *uint256.Int) {
+	x.SetBool(x.IsZero())
+}
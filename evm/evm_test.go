@@ -0,0 +1,57 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func TestComparisons(t *testing.T) {
+	minusOne := new(uint256.Int).SetAllOne()
+	one := uint256.NewInt().SetOne()
+	tests := []struct {
+		name string
+		op   func(x, y *uint256.Int)
+		x, y *uint256.Int
+		want uint64
+	}{
+		{"Lt", Lt, one, minusOne, 1},
+		{"Lt", Lt, minusOne, one, 0},
+		{"Lt", Lt, one, one, 0},
+		{"Gt", Gt, minusOne, one, 1},
+		{"Gt", Gt, one, minusOne, 0},
+		{"Slt", Slt, minusOne, one, 1},
+		{"Slt", Slt, one, minusOne, 0},
+		{"Sgt", Sgt, one, minusOne, 1},
+		{"Sgt", Sgt, minusOne, one, 0},
+		{"Eq", Eq, one, one, 1},
+		{"Eq", Eq, one, minusOne, 0},
+	}
+	for _, tc := range tests {
+		x, y := tc.x.Clone(), tc.y.Clone()
+		tc.op(x, y)
+		if !y.Eq(new(uint256.Int).SetUint64(tc.want)) {
+			t.Errorf("%s(%v, %v): have %v, want %d", tc.name, tc.x, tc.y, y, tc.want)
+		}
+		if !x.Eq(tc.x) {
+			t.Errorf("%s(%v, %v): x modified", tc.name, tc.x, tc.y)
+		}
+	}
+	// The operands may be the same slot, as after DUP1.
+	x := one.Clone()
+	Eq(x, x)
+	if !x.Eq(one) {
+		t.Errorf("Eq(x, x): have %v, want 1", x)
+	}
+	for _, tc := range []struct{ x, want uint64 }{{0, 1}, {1, 0}, {7, 0}} {
+		x := new(uint256.Int).SetUint64(tc.x)
+		if IsZero(x); x.Uint64() != tc.want {
+			t.Errorf("IsZero(%d): have %v, want %d", tc.x, x, tc.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// factorTrialLimit bounds the primes which Factor64 removes by trial division
+// before using Pollard's rho method.
+const factorTrialLimit = 1 << 10
+
+// Factor64 returns the prime factors of z, with multiplicity and in
+// ascending order, if z fits in 64 bits. Small factors are removed by trial
+// division, and the rest found with Pollard's rho method, with a
+// deterministic Miller-Rabin primality test. Zero and one have no prime
+// factors. The boolean is false, and the factors nil, if z does not fit in
+// 64 bits.
+func (z *Int) Factor64() ([]uint64, bool) {
+	z = orZero(z)
+	if !z.IsUint64() {
+		return nil, false
+	}
+	n := z[0]
+	if n < 2 {
+		return nil, true
+	}
+	var factors []uint64
+	for ; n&1 == 0; n >>= 1 {
+		factors = append(factors, 2)
+	}
+	primeGroupsOnce.Do(initPrimeGroups)
+trial:
+	for i := range primeGroups {
+		for j := range primeGroups[i].primes {
+			m := &primeGroups[i].primes[j]
+			if m.p >= factorTrialLimit || m.p*m.p > n {
+				break trial
+			}
+			// For a multiple of p, multiplication by the inverse of p is
+			// exact division.
+			for m.divides(n) {
+				factors = append(factors, m.p)
+				n *= m.inv
+			}
+		}
+	}
+	factors = factorRho64(n, factors)
+	sort.Slice(factors, func(i, j int) bool { return factors[i] < factors[j] })
+	return factors, true
+}
+
+// factorRho64 appends the prime factors of n, which has no factors below
+// factorTrialLimit, to factors.
+func factorRho64(n uint64, factors []uint64) []uint64 {
+	if n == 1 {
+		return factors
+	}
+	if n < factorTrialLimit*factorTrialLimit || isPrime64(n) {
+		return append(factors, n)
+	}
+	d := pollardRho64(n)
+	return factorRho64(n/d, factorRho64(d, factors))
+}
+
+// mulMod64 returns a * b mod m, for a, b < m.
+func mulMod64(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, rem := bits.Div64(hi, lo, m)
+	return rem
+}
+
+// powMod64 returns x**e mod m, for x < m.
+func powMod64(x, e, m uint64) uint64 {
+	r := uint64(1)
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = mulMod64(r, x, m)
+		}
+		x = mulMod64(x, x, m)
+	}
+	return r
+}
+
+// isPrime64 reports whether the odd number n > 37 is prime. The Miller-Rabin
+// test with the primes up to 37 as bases is deterministic below 2**64.
+func isPrime64(n uint64) bool {
+	s := uint(bits.TrailingZeros64(n - 1))
+	d := (n - 1) >> s
+	for _, a := range [...]uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37} {
+		x := powMod64(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for i := uint(1); i < s && composite; i++ {
+			x = mulMod64(x, x, n)
+			composite = x != n-1
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// gcd64 returns the greatest common divisor of a and b.
+func gcd64(a, b uint64) uint64 {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+	shift := bits.TrailingZeros64(a | b)
+	a >>= uint(bits.TrailingZeros64(a))
+	for b != 0 {
+		b >>= uint(bits.TrailingZeros64(b))
+		if a > b {
+			a, b = b, a
+		}
+		b -= a
+	}
+	return a << uint(shift)
+}
+
+// pollardRho64 returns a non-trivial factor of the odd composite n, using
+// Brent's variant of Pollard's rho method, with f(x) = x**2 + c.
+func pollardRho64(n uint64) uint64 {
+	// batch is the number of differences multiplied together per gcd.
+	const batch = 128
+	diff := func(a, b uint64) uint64 {
+		if a > b {
+			return a - b
+		}
+		return b - a
+	}
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 {
+			x = mulMod64(x, x, n)
+			if x >= n-c {
+				return x - (n - c)
+			}
+			return x + c
+		}
+		var (
+			x, ys uint64
+			y, q  = uint64(2), uint64(1)
+			g     = uint64(1)
+		)
+		for r := 1; g == 1; r *= 2 {
+			x = y
+			for i := 0; i < r; i++ {
+				y = f(y)
+			}
+			for k := 0; k < r && g == 1; k += batch {
+				ys = y
+				for i := 0; i < batch && i < r-k; i++ {
+					y = f(y)
+					q = mulMod64(q, diff(x, y), n)
+				}
+				g = gcd64(q, n)
+			}
+		}
+		if g == n {
+			// The batch overshot; retrace it one step at a time.
+			for g = 1; g == 1; {
+				ys = f(ys)
+				g = gcd64(diff(x, ys), n)
+			}
+		}
+		if g != n {
+			return g
+		}
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// checkFactors checks that factors are ascending primes whose product is n.
+func checkFactors(t *testing.T, n uint64, factors []uint64) {
+	t.Helper()
+	prod := new(big.Int).SetUint64(1)
+	for i, f := range factors {
+		if i > 0 && f < factors[i-1] {
+			t.Fatalf("%d: factors not sorted: %v", n, factors)
+		}
+		if !new(big.Int).SetUint64(f).ProbablyPrime(20) {
+			t.Fatalf("%d: factor %d is not prime", n, f)
+		}
+		prod.Mul(prod, new(big.Int).SetUint64(f))
+	}
+	if prod.Cmp(new(big.Int).SetUint64(n)) != 0 {
+		t.Fatalf("%d: product of %v is %v", n, factors, prod)
+	}
+}
+
+func TestFactor64(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want []uint64
+	}{
+		{0, nil},
+		{1, nil},
+		{2, []uint64{2}},
+		{360, []uint64{2, 2, 2, 3, 3, 5}},
+		{^uint64(0), []uint64{3, 5, 17, 257, 641, 65537, 6700417}},
+		// The largest prime below 2**64.
+		{18446744073709551557, []uint64{18446744073709551557}},
+		// Products of two primes near 2**32.
+		{4294967291 * 4294967279, []uint64{4294967279, 4294967291}},
+		{4294967291 * 4294967291, []uint64{4294967291, 4294967291}},
+		{1021 * 1031 * 1033, []uint64{1021, 1031, 1033}},
+	}
+	for _, tc := range tests {
+		have, ok := NewInt().SetUint64(tc.n).Factor64()
+		if !ok || !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("Factor64(%d): have %v %v, want %v", tc.n, have, ok, tc.want)
+		}
+	}
+	if f, ok := (&Int{0, 1}).Factor64(); ok || f != nil {
+		t.Errorf("Factor64 of 2**64: have %v %v, want nil false", f, ok)
+	}
+}
+
+func TestFactor64Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		n := rnd.Uint64() >> uint(rnd.Intn(40))
+		if i%2 == 0 {
+			// A semiprime with two large factors.
+			p := new(big.Int).SetUint64(uint64(rnd.Int63n(1<<31) + 1<<20))
+			q := new(big.Int).SetUint64(uint64(rnd.Int63n(1<<31) + 1<<20))
+			for !p.ProbablyPrime(20) {
+				p.Add(p, big.NewInt(1))
+			}
+			for !q.ProbablyPrime(20) {
+				q.Add(q, big.NewInt(1))
+			}
+			n = p.Uint64() * q.Uint64()
+		}
+		factors, ok := NewInt().SetUint64(n).Factor64()
+		if !ok {
+			t.Fatalf("Factor64(%d) failed", n)
+		}
+		checkFactors(t, n, factors)
+	}
+}
+
+func TestIsPrime64(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		n := rnd.Uint64()>>uint(rnd.Intn(56)) | 1
+		if n <= 37 {
+			continue
+		}
+		if have, want := isPrime64(n), new(big.Int).SetUint64(n).ProbablyPrime(20); have != want {
+			t.Fatalf("isPrime64(%d): have %v, want %v", n, have, want)
+		}
+	}
+	// Strong pseudoprimes to the bases up to 7 and up to 23.
+	for _, n := range []uint64{3215031751, 3825123056546413051} {
+		if isPrime64(n) {
+			t.Errorf("isPrime64(%d): have true, want false", n)
+		}
+	}
+}
+
+func BenchmarkFactor64(b *testing.B) {
+	x := NewInt().SetUint64(4294967291 * 4294967279)
+	for i := 0; i < b.N; i++ {
+		x.Factor64()
+	}
+}
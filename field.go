@@ -0,0 +1,180 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "math/bits"
+
+// Field holds the precomputed constants for fast arithmetic modulo an odd
+// modulus, using Montgomery multiplication on the four limbs.
+type Field struct {
+	modulus Int
+	inv     uint64 // -modulus**-1 mod 2**64
+	r2      Int    // 2**512 mod modulus
+}
+
+// BN254Scalar is the scalar field of the BN254 (alt_bn128) curve, which is
+// the native field of most zk-SNARK hash functions, such as Poseidon and MiMC.
+var BN254Scalar = NewField(&Int{
+	0x43e1f593f0000001,
+	0x2833e84879b97091,
+	0xb85045b68181585d,
+	0x30644e72e131a029,
+})
+
+// NewField returns a Field for the given modulus, which must be odd and
+// larger than 1.
+func NewField(modulus *Int) *Field {
+	if modulus[0]&1 == 0 || modulus.IsOne() {
+		panic("uint256: field modulus must be odd and larger than 1")
+	}
+	f := &Field{modulus: *modulus}
+	// Newton's iteration doubles the number of correct low bits each step.
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - modulus[0]*inv
+	}
+	f.inv = -inv
+	// 2**256 mod m == (2**256 - m) mod m
+	var r Int
+	r.Sub(&r, modulus).Mod(&r, modulus)
+	f.r2.MulMod(&r, &r, modulus)
+	return f
+}
+
+// Modulus returns a copy of the modulus of f.
+func (f *Field) Modulus() *Int {
+	return f.modulus.Clone()
+}
+
+// montMul sets z to x * y * 2**-256 mod m, for x, y < m, using the CIOS
+// method. It returns z.
+func (f *Field) montMul(z, x, y *Int) *Int {
+	var (
+		t     [6]uint64
+		m     = &f.modulus
+		carry uint64
+	)
+	for i := 0; i < 4; i++ {
+		// t += x * y[i]
+		carry = 0
+		t[0], carry = umulStep(t[0], x[0], y[i], carry)
+		t[1], carry = umulStep(t[1], x[1], y[i], carry)
+		t[2], carry = umulStep(t[2], x[2], y[i], carry)
+		t[3], carry = umulStep(t[3], x[3], y[i], carry)
+		t[4], carry = bits.Add64(t[4], carry, 0)
+		t[5] = carry
+
+		// t = (t + q * m) / 2**64, where q makes the low word vanish.
+		q := t[0] * f.inv
+		_, carry = umulStep(t[0], q, m[0], 0)
+		t[0], carry = umulStep(t[1], q, m[1], carry)
+		t[1], carry = umulStep(t[2], q, m[2], carry)
+		t[2], carry = umulStep(t[3], q, m[3], carry)
+		t[3], carry = bits.Add64(t[4], carry, 0)
+		t[4] = t[5] + carry
+	}
+	// The result is below 2m, so at most one subtraction is needed.
+	var (
+		r      Int
+		borrow uint64
+	)
+	r[0], borrow = bits.Sub64(t[0], m[0], 0)
+	r[1], borrow = bits.Sub64(t[1], m[1], borrow)
+	r[2], borrow = bits.Sub64(t[2], m[2], borrow)
+	r[3], borrow = bits.Sub64(t[3], m[3], borrow)
+	if t[4] == 0 && borrow != 0 {
+		z[0], z[1], z[2], z[3] = t[0], t[1], t[2], t[3]
+		return z
+	}
+	return z.Copy(&r)
+}
+
+// ToMont sets z to the Montgomery form x * 2**256 mod m of x < m, and
+// returns z.
+func (f *Field) ToMont(z, x *Int) *Int {
+	return f.montMul(z, x, &f.r2)
+}
+
+// FromMont sets z to the standard form of the Montgomery form x, and
+// returns z.
+func (f *Field) FromMont(z, x *Int) *Int {
+	return f.montMul(z, x, &Int{1})
+}
+
+// MontWordsBE returns the Montgomery form of x < m as big-endian words, most
+// significant first, for field libraries which store elements that way.
+func (f *Field) MontWordsBE(x *Int) [4]uint64 {
+	var z Int
+	return f.ToMont(&z, x).WordsBE()
+}
+
+// SetMontWordsBE sets z to the standard form of the Montgomery form held in
+// the big-endian words, the inverse of MontWordsBE, and returns z.
+func (f *Field) SetMontWordsBE(z *Int, words [4]uint64) *Int {
+	x := Int{words[3], words[2], words[1], words[0]}
+	return f.FromMont(z, &x)
+}
+
+// MulMont sets z to the Montgomery product of the Montgomery forms x and y,
+// and returns z. Chains of multiplications (such as S-boxes of hash
+// functions) are fastest when performed entirely in Montgomery form.
+func (f *Field) MulMont(z, x, y *Int) *Int {
+	return f.montMul(z, x, y)
+}
+
+// AddF sets z to the sum x + y modulo the field f, and returns z.
+// The operands must be reduced, i.e. smaller than the modulus.
+func (z *Int) AddF(x, y *Int, f *Field) *Int {
+	var (
+		r      Int
+		m      = &f.modulus
+		carry  uint64
+		borrow uint64
+	)
+	z0, carry := bits.Add64(x[0], y[0], 0)
+	z1, carry := bits.Add64(x[1], y[1], carry)
+	z2, carry := bits.Add64(x[2], y[2], carry)
+	z3, carry := bits.Add64(x[3], y[3], carry)
+	r[0], borrow = bits.Sub64(z0, m[0], 0)
+	r[1], borrow = bits.Sub64(z1, m[1], borrow)
+	r[2], borrow = bits.Sub64(z2, m[2], borrow)
+	r[3], borrow = bits.Sub64(z3, m[3], borrow)
+	if carry == 0 && borrow != 0 {
+		z[0], z[1], z[2], z[3] = z0, z1, z2, z3
+		return z
+	}
+	return z.Copy(&r)
+}
+
+// SubF sets z to the difference x - y modulo the field f, and returns z.
+// The operands must be reduced, i.e. smaller than the modulus.
+func (z *Int) SubF(x, y *Int, f *Field) *Int {
+	var (
+		m      = &f.modulus
+		borrow uint64
+		carry  uint64
+	)
+	z0, borrow := bits.Sub64(x[0], y[0], 0)
+	z1, borrow := bits.Sub64(x[1], y[1], borrow)
+	z2, borrow := bits.Sub64(x[2], y[2], borrow)
+	z3, borrow := bits.Sub64(x[3], y[3], borrow)
+	if borrow != 0 {
+		z0, carry = bits.Add64(z0, m[0], 0)
+		z1, carry = bits.Add64(z1, m[1], carry)
+		z2, carry = bits.Add64(z2, m[2], carry)
+		z3, _ = bits.Add64(z3, m[3], carry)
+	}
+	z[0], z[1], z[2], z[3] = z0, z1, z2, z3
+	return z
+}
+
+// MulF sets z to the product x * y modulo the field f, and returns z.
+// The operands must be reduced, i.e. smaller than the modulus.
+func (z *Int) MulF(x, y *Int, f *Field) *Int {
+	// (x * y * 2**-256) * (2**512) * 2**-256 == x * y
+	f.montMul(z, x, y)
+	return f.montMul(z, z, &f.r2)
+}
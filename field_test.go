@@ -0,0 +1,97 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func testField(t *testing.T, f *Field) {
+	t.Helper()
+	bm := f.Modulus().ToBig()
+	for i := 0; i < 1000; i++ {
+		bx, x, _ := randHighNums()
+		by, y, _ := randHighNums()
+		x.Mod(x, &f.modulus)
+		y.Mod(y, &f.modulus)
+		bx.Mod(bx, bm)
+		by.Mod(by, bm)
+
+		exp := new(big.Int).Add(bx, by)
+		requireEq(t, exp.Mod(exp, bm), new(Int).AddF(x, y, f), "AddF")
+		exp.Sub(bx, by)
+		requireEq(t, exp.Mod(exp, bm), new(Int).SubF(x, y, f), "SubF")
+		exp.Mul(bx, by)
+		requireEq(t, exp.Mod(exp, bm), new(Int).MulF(x, y, f), "MulF")
+
+		// Montgomery round trip, and products in Montgomery form.
+		var xm, ym, z Int
+		f.ToMont(&xm, x)
+		f.ToMont(&ym, y)
+		if f.FromMont(&z, &xm); !z.Eq(x) {
+			t.Fatalf("montgomery round trip of %v: got %v", x.Hex(), z.Hex())
+		}
+		if words := f.MontWordsBE(x); words != xm.WordsBE() {
+			t.Fatalf("MontWordsBE of %v: got %x, exp %x", x.Hex(), words, xm.WordsBE())
+		} else if f.SetMontWordsBE(&z, words); !z.Eq(x) {
+			t.Fatalf("MontWordsBE round trip of %v: got %v", x.Hex(), z.Hex())
+		}
+		f.FromMont(&z, f.MulMont(&z, &xm, &ym))
+		requireEq(t, exp, &z, "MulMont")
+
+		// Aliased operands.
+		z.Copy(x)
+		requireEq(t, exp, z.MulF(&z, y, f), "aliased MulF")
+	}
+}
+
+func TestBN254Scalar(t *testing.T) {
+	exp, _ := new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808495617", 10)
+	requireEq(t, exp, BN254Scalar.Modulus(), "modulus")
+	testField(t, BN254Scalar)
+
+	// Edge values: m-1 squared is 1.
+	mm1 := new(Int).Sub(BN254Scalar.Modulus(), new(Int).SetOne())
+	if z := new(Int).MulF(mm1, mm1, BN254Scalar); !z.IsOne() {
+		t.Errorf("got %v", z.Hex())
+	}
+	if z := new(Int).AddF(mm1, new(Int).SetOne(), BN254Scalar); !z.IsZero() {
+		t.Errorf("got %v", z.Hex())
+	}
+}
+
+func TestFieldModuli(t *testing.T) {
+	moduli := []*Int{
+		// The largest prime below 2**256, exercising the carry paths.
+		new(Int).SetBytes(hex2Bytes("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff43")),
+		// The secp256k1 field.
+		new(Int).SetBytes(hex2Bytes("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")),
+		{0xffffffff00000001},
+		{3},
+	}
+	for i := 0; i < 10; i++ {
+		_, m, _ := randHighNums()
+		m[0] |= 1
+		moduli = append(moduli, m)
+	}
+	for _, m := range moduli {
+		testField(t, NewField(m))
+	}
+}
+
+func TestNewFieldPanics(t *testing.T) {
+	for _, m := range []*Int{{}, {1}, {4}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for modulus %v", m.Hex())
+				}
+			}()
+			NewField(m)
+		}()
+	}
+}
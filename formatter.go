@@ -0,0 +1,107 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+// Formatter is a custom rendering of Ints, which can be registered with
+// SetFormatter to be used by String and Format, e.g. to group digits
+// according to a locale with golang.org/x/text/message.
+type Formatter interface {
+	// FormatInt returns the rendering of x for the fmt verb ('v' for
+	// String), or false to fall back to the default rendering. Width and
+	// the '-' flag are applied by Format to the returned string.
+	FormatInt(x *Int, verb rune) (string, bool)
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(x *Int, verb rune) (string, bool)
+
+// FormatInt calls f(x, verb).
+func (f FormatterFunc) FormatInt(x *Int, verb rune) (string, bool) {
+	return f(x, verb)
+}
+
+// formatterBox wraps the registered Formatter, since an atomic.Value cannot
+// hold nil or values of different types.
+type formatterBox struct{ f Formatter }
+
+var registeredFormatter atomic.Value
+
+// SetFormatter registers f to be used by String and Format, or restores the
+// default rendering if f is nil, and returns the previously registered
+// Formatter. It is safe for concurrent use, but since it affects every Int
+// in the program, it is intended to be called during initialization.
+func SetFormatter(f Formatter) Formatter {
+	old, _ := registeredFormatter.Load().(formatterBox)
+	registeredFormatter.Store(formatterBox{f})
+	return old.f
+}
+
+// customFormat returns the rendering of z by the registered Formatter, if
+// there is one and it handles the verb.
+func (z *Int) customFormat(verb rune) (string, bool) {
+	box, _ := registeredFormatter.Load().(formatterBox)
+	if box.f == nil {
+		return "", false
+	}
+	return box.f.FormatInt(z, verb)
+}
+
+// String returns the decimal representation of z, or the rendering of the
+// registered Formatter for the 'v' verb.
+func (z *Int) String() string {
+	if s, ok := z.customFormat('v'); ok {
+		return s
+	}
+	return z.Dec()
+}
+
+// writePadded writes str to s, padded to the width of s, if any.
+func writePadded(s fmt.State, str string) {
+	width, ok := s.Width()
+	if !ok || len(str) >= width {
+		fmt.Fprint(s, str)
+		return
+	}
+	format := "%" + strconv.Itoa(width) + "s"
+	if s.Flag('-') {
+		format = "%-" + strconv.Itoa(width) + "s"
+	}
+	fmt.Fprintf(s, format, str)
+}
+
+// DigitGrouping is a Formatter which renders the 'v', 's' and 'd' verbs in
+// decimal, with Separator inserted between groups of three digits, e.g.
+// "1,000,000" for a Separator of ",".
+type DigitGrouping struct {
+	Separator string
+}
+
+// FormatInt implements Formatter.
+func (g DigitGrouping) FormatInt(x *Int, verb rune) (string, bool) {
+	switch verb {
+	case 'v', 's', 'd':
+	default:
+		return "", false
+	}
+	digits := x.Dec()
+	first := len(digits) % 3
+	if first == 0 {
+		first = 3
+	}
+	out := make([]byte, 0, len(digits)+(len(digits)-1)/3*len(g.Separator))
+	out = append(out, digits[:first]...)
+	for i := first; i < len(digits); i += 3 {
+		out = append(out, g.Separator...)
+		out = append(out, digits[i:i+3]...)
+	}
+	return string(out), true
+}
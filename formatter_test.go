@@ -0,0 +1,72 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestStringDefault(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		b, x, _ := randNums()
+		if got, exp := x.String(), b.String(); got != exp {
+			t.Fatalf("got %s, exp %s", got, exp)
+		}
+	}
+	if got := fmt.Sprintf("%v|%x|%5d", new(Int).SetUint64(255), new(Int).SetUint64(255), new(Int).SetUint64(7)); got != "255|ff|    7" {
+		t.Errorf("default format: got %q", got)
+	}
+}
+
+func TestDigitGrouping(t *testing.T) {
+	g := DigitGrouping{Separator: ","}
+	for _, tc := range []struct {
+		x   uint64
+		exp string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1000, "1,000"},
+		{123456, "123,456"},
+		{1234567, "1,234,567"},
+	} {
+		if got, ok := g.FormatInt(new(Int).SetUint64(tc.x), 'd'); !ok || got != tc.exp {
+			t.Errorf("%d: got %q, %v, exp %q", tc.x, got, ok, tc.exp)
+		}
+	}
+	if _, ok := g.FormatInt(new(Int), 'x'); ok {
+		t.Errorf("DigitGrouping handled %%x")
+	}
+}
+
+func TestSetFormatter(t *testing.T) {
+	old := SetFormatter(DigitGrouping{Separator: "_"})
+	defer SetFormatter(old)
+
+	x := new(Int).SetUint64(1234567)
+	if got := x.String(); got != "1_234_567" {
+		t.Errorf("String: got %q", got)
+	}
+	if got := fmt.Sprintf("%v|%d|%x|%12d|%-12s|", x, x, x, x, x); got != "1_234_567|1_234_567|12d687|   1_234_567|1_234_567   |" {
+		t.Errorf("Sprintf: got %q", got)
+	}
+
+	prev := SetFormatter(FormatterFunc(func(x *Int, verb rune) (string, bool) {
+		return "custom", verb == 'v'
+	}))
+	if _, ok := prev.(DigitGrouping); !ok {
+		t.Errorf("SetFormatter returned %T, exp DigitGrouping", prev)
+	}
+	if got := fmt.Sprintf("%v %d", x, x); got != "custom 1234567" {
+		t.Errorf("FormatterFunc: got %q", got)
+	}
+
+	SetFormatter(nil)
+	if got := x.String(); got != "1234567" {
+		t.Errorf("after reset: got %q", got)
+	}
+}
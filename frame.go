@@ -0,0 +1,115 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "errors"
+
+// FrameVersion identifies the payload encoding of a frame, as written by
+// EncodeFrame. Values are never reused, so frames stay decodable as new
+// versions are added.
+type FrameVersion byte
+
+const (
+	// FrameFixed is the 32-byte big-endian form.
+	FrameFixed FrameVersion = 1
+	// FrameCompact is the big-endian form without leading zero bytes, so
+	// zero has an empty payload.
+	FrameCompact FrameVersion = 2
+	// FrameUvarint is the unsigned LEB128 form of PutUvarint.
+	FrameUvarint FrameVersion = 3
+)
+
+// frameHeaderLen is the length of the version and length bytes of a frame.
+const frameHeaderLen = 2
+
+var (
+	errFrameShort    = errors.New("uint256: truncated frame")
+	errFrameVersion  = errors.New("uint256: unknown frame version")
+	errFramePayload  = errors.New("uint256: invalid frame payload")
+	errFrameNoncanon = errors.New("uint256: non-canonical frame payload")
+	errFrameTooLarge = errors.New("uint256: frame payload too large")
+)
+
+// EncodeFrame returns z encoded as a self-describing frame: a version byte, a
+// byte holding the length of the payload, and the payload in the encoding of
+// the version. Stored frames can be decoded unambiguously by DecodeFrame
+// after the preferred version changes.
+func (z *Int) EncodeFrame(v FrameVersion) ([]byte, error) {
+	return z.AppendFrame(make([]byte, 0, frameHeaderLen+32), v)
+}
+
+// AppendFrame appends the frame encoding of z in version v to dst, and returns
+// the extended buffer.
+func (z *Int) AppendFrame(dst []byte, v FrameVersion) ([]byte, error) {
+	var (
+		buf [MaxVarintLen256]byte
+		n   int
+	)
+	switch v {
+	case FrameFixed:
+		z.PutBytes(buf[:32], 32, BigEndian)
+		n = 32
+	case FrameCompact:
+		n = z.ByteLen()
+		z.PutBytes(buf[:n], n, BigEndian)
+	case FrameUvarint:
+		n = z.PutUvarint(buf[:])
+	default:
+		return dst, errFrameVersion
+	}
+	dst = append(dst, byte(v), byte(n))
+	return append(dst, buf[:n]...), nil
+}
+
+// DecodeFrame sets z from the frame at the start of data, and returns the
+// length of the frame. Payloads must be canonical, e.g. without leading
+// zeros in the compact form. For a frame of an unknown version, it returns
+// an error along with the length of the frame, so callers can skip it.
+func (z *Int) DecodeFrame(data []byte) (int, error) {
+	if z == nil {
+		panic(nilReceiver("DecodeFrame"))
+	}
+	if len(data) < frameHeaderLen {
+		return 0, errFrameShort
+	}
+	v, n := FrameVersion(data[0]), int(data[1])
+	size := frameHeaderLen + n
+	if len(data) < size {
+		return 0, errFrameShort
+	}
+	payload := data[frameHeaderLen:size]
+	switch v {
+	case FrameFixed:
+		if n != 32 {
+			return size, errFramePayload
+		}
+		z.SetBytes(payload)
+	case FrameCompact:
+		if n > 32 {
+			return size, errFrameTooLarge
+		}
+		if n > 0 && payload[0] == 0 {
+			return size, errFrameNoncanon
+		}
+		z.SetBytes(payload)
+	case FrameUvarint:
+		var x Int
+		m := x.SetUvarint(payload)
+		if m < 0 {
+			return size, errFrameTooLarge
+		}
+		if m != n {
+			return size, errFramePayload
+		}
+		if x.uvarintLen() != n {
+			return size, errFrameNoncanon
+		}
+		z.Copy(&x)
+	default:
+		return size, errFrameVersion
+	}
+	return size, nil
+}
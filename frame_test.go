@@ -0,0 +1,100 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	values := []*Int{
+		new(Int),
+		NewInt().SetOne(),
+		NewInt().SetUint64(0x1234),
+		{0, 0, 0, 1},
+		new(Int).SetAllOne(),
+	}
+	for _, v := range []FrameVersion{FrameFixed, FrameCompact, FrameUvarint} {
+		var stream []byte
+		for _, x := range values {
+			var err error
+			if stream, err = x.AppendFrame(stream, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Frames of a stream decode one after the other.
+		for _, x := range values {
+			var z Int
+			n, err := z.DecodeFrame(stream)
+			if err != nil {
+				t.Fatalf("version %d: DecodeFrame(%x): %v", v, stream, err)
+			}
+			if !z.Eq(x) {
+				t.Errorf("version %d: have %v, want %v", v, &z, x)
+			}
+			stream = stream[n:]
+		}
+		if len(stream) != 0 {
+			t.Errorf("version %d: %d bytes left", v, len(stream))
+		}
+	}
+}
+
+func TestEncodeFrame(t *testing.T) {
+	x := NewInt().SetUint64(0x1234)
+	tests := []struct {
+		v    FrameVersion
+		want string
+	}{
+		{FrameFixed, "0120" + "00000000000000000000000000000000000000000000000000000000" + "00001234"},
+		{FrameCompact, "02021234"},
+		{FrameUvarint, "0302b424"},
+	}
+	for _, tc := range tests {
+		have, err := x.EncodeFrame(tc.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := hex2Bytes(tc.want); !bytes.Equal(have, want) {
+			t.Errorf("version %d: have %x, want %x", tc.v, have, want)
+		}
+	}
+	if _, err := x.EncodeFrame(0); !errors.Is(err, errFrameVersion) {
+		t.Errorf("version 0: have %v, want %v", err, errFrameVersion)
+	}
+	if have, _ := new(Int).EncodeFrame(FrameCompact); !bytes.Equal(have, []byte{2, 0}) {
+		t.Errorf("compact zero: have %x, want 0200", have)
+	}
+}
+
+func TestDecodeFrameErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want error
+	}{
+		{"", 0, errFrameShort},
+		{"01", 0, errFrameShort},
+		{"0203aabb", 0, errFrameShort},
+		{"0102aabb", 4, errFramePayload},
+		{"020200ff", 4, errFrameNoncanon},
+		{"0221" + "01" + "0000000000000000000000000000000000000000000000000000000000000000", 35, errFrameTooLarge},
+		{"030280", 0, errFrameShort},
+		{"03028000", 4, errFrameNoncanon},
+		{"030201ff", 4, errFramePayload},
+		// Unknown versions can be skipped.
+		{"ff03aabbcc01", 5, errFrameVersion},
+	}
+	for _, tc := range tests {
+		var z Int
+		n, err := z.DecodeFrame(hex2Bytes(tc.in))
+		if !errors.Is(err, tc.want) || n != tc.n {
+			t.Errorf("DecodeFrame(%s): have %d %v, want %d %v", tc.in, n, err, tc.n, tc.want)
+		}
+	}
+}
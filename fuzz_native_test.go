@@ -0,0 +1,150 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// fuzzSeeds are operand pairs for the tricky cases of the division, added to
+// the corpus of every fuzz target.
+var fuzzSeeds = [][2]string{
+	{"0x0", "0x0"},
+	{"0x1", "0x0"},
+	{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0x1"},
+	{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	// Divisors with the high bit of their top word set, which need no
+	// normalization shift.
+	{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0x8000000000000000"},
+	{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "0x80000000000000000000000000000001"},
+	{"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe", "0xffffffffffffffffffffffffffffffffffffffffffffffff"},
+	{"0x8000000000000000000000000000000000000000000000000000000000000000", "0x8000000000000000000000000000000000000000000000000000000000000001"},
+	// Operands for which the quotient digit estimate of Knuth's division
+	// is one too large, taking the add-back path.
+	{"0x8000000000000000000000000000000000000000000000000000000000000000", "0x800000000000000000000000000000000000000000000001"},
+	{"0x800000000000000000000000000000050000000000000000ffffffffffffffff", "0x800000000000000000000000000000050000000000000001"},
+	// Negative operands, for the signed operations.
+	{"0x8000000000000000000000000000000000000000000000000000000000000000", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	{"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff9", "0x2"},
+}
+
+func seedBytes(s string) []byte {
+	x, err := FromHex(s)
+	if err != nil {
+		panic(err)
+	}
+	return x.Bytes()
+}
+
+func addFuzzSeeds(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seedBytes(seed[0]), seedBytes(seed[1]))
+	}
+}
+
+// fuzzInt interprets up to 32 bytes of data as a big-endian number.
+func fuzzInt(data []byte) *Int {
+	if len(data) > 32 {
+		data = data[:32]
+	}
+	return new(Int).SetBytes(data)
+}
+
+// toSignedBig returns x interpreted as a two's complement signed integer.
+func toSignedBig(x *Int) *big.Int {
+	return S256(x.ToBig())
+}
+
+func checkFuzzResult(t *testing.T, op string, have *Int, want *big.Int, x ...*Int) {
+	t.Helper()
+	if !checkEq(U256(new(big.Int).Set(want)), have) {
+		t.Fatalf("%s%v: have %v, want %#x", op, x, have.Hex(), want)
+	}
+}
+
+func FuzzMod(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, xb, yb []byte) {
+		x, y := fuzzInt(xb), fuzzInt(yb)
+		bx, by := x.ToBig(), y.ToBig()
+		var quo, rem big.Int
+		if by.Sign() != 0 {
+			quo.QuoRem(bx, by, &rem)
+		}
+		checkFuzzResult(t, "Div", new(Int).Div(x, y), &quo, x, y)
+		checkFuzzResult(t, "Mod", new(Int).Mod(x, y), &rem, x, y)
+		// Aliased operands.
+		checkFuzzResult(t, "Div", x.Clone().Div(x.Clone(), y), &quo, x, y)
+		z := y.Clone()
+		checkFuzzResult(t, "Mod", z.Mod(x, z), &rem, x, y)
+	})
+}
+
+func FuzzMulMod(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		x := seedBytes(seed[0])
+		f.Add(x, x, seedBytes(seed[1]))
+	}
+	f.Fuzz(func(t *testing.T, xb, yb, mb []byte) {
+		x, y, m := fuzzInt(xb), fuzzInt(yb), fuzzInt(mb)
+		if m.IsZero() {
+			return
+		}
+		bx, by, bm := x.ToBig(), y.ToBig(), m.ToBig()
+		want := new(big.Int).Mul(bx, by)
+		checkFuzzResult(t, "MulMod", new(Int).MulMod(x, y, m), want.Mod(want, bm), x, y, m)
+		want = new(big.Int).Add(bx, by)
+		checkFuzzResult(t, "AddMod", new(Int).AddMod(x, y, m), want.Mod(want, bm), x, y, m)
+	})
+}
+
+func FuzzExp(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Add([]byte{2}, []byte{255})
+	f.Add([]byte{3}, []byte{1, 0})
+	f.Fuzz(func(t *testing.T, xb, yb []byte) {
+		base, exp := fuzzInt(xb), fuzzInt(yb)
+		want := new(big.Int).Exp(base.ToBig(), exp.ToBig(), bigtt256)
+		checkFuzzResult(t, "Exp", new(Int).Exp(base, exp), want, base, exp)
+	})
+}
+
+func FuzzSigned(f *testing.F) {
+	addFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, xb, yb []byte) {
+		x, y := fuzzInt(xb), fuzzInt(yb)
+		sx, sy := toSignedBig(x), toSignedBig(y)
+		var quo, rem big.Int
+		if sy.Sign() != 0 {
+			quo.QuoRem(sx, sy, &rem)
+		}
+		// Sdiv and Smod modify their operands, so they get copies.
+		checkFuzzResult(t, "Sdiv", new(Int).Sdiv(x.Clone(), y.Clone()), &quo, x, y)
+		checkFuzzResult(t, "Smod", new(Int).Smod(x.Clone(), y.Clone()), &rem, x, y)
+		if have, want := x.Slt(y), sx.Cmp(sy) < 0; have != want {
+			t.Fatalf("Slt%v: have %v, want %v", []*Int{x, y}, have, want)
+		}
+		if have, want := x.Sgt(y), sx.Cmp(sy) > 0; have != want {
+			t.Fatalf("Sgt%v: have %v, want %v", []*Int{x, y}, have, want)
+		}
+		n := uint(y.Uint64() % 256)
+		want := new(big.Int).Rsh(sx, n)
+		checkFuzzResult(t, "Srsh", new(Int).Srsh(x, n), want, x, y)
+		z := x.Clone()
+		checkFuzzResult(t, "Srsh", z.Srsh(z, n), want, x, y)
+		back := y.Uint64() % 32
+		bit := uint(back*8 + 7)
+		want = new(big.Int).And(x.ToBig(), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), bit+1), big.NewInt(1)))
+		if want.Bit(int(bit)) == 1 {
+			want.Sub(want, new(big.Int).Lsh(big.NewInt(1), bit+1))
+		}
+		checkFuzzResult(t, "ExtendSign", new(Int).ExtendSign(x, new(Int).SetUint64(back)), want, x, new(Int).SetUint64(back))
+	})
+}
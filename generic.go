@@ -0,0 +1,41 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+// Integer is the set of Go integer types, equivalent to
+// golang.org/x/exp/constraints.Integer, which this package does not depend on.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// FromInteger returns a new Int set to v, for code which is generic over the
+// integer types. Like a Go conversion of a negative integer to an unsigned
+// type, a negative v yields its two's complement, i.e. 2**256 + v.
+func FromInteger[T Integer](v T) *Int {
+	z := new(Int).SetUint64(uint64(v))
+	if v < 0 {
+		z[1], z[2], z[3] = ^uint64(0), ^uint64(0), ^uint64(0)
+	}
+	return z
+}
+
+// To returns z as a T, and whether it fits, with z interpreted as unsigned
+// as in the As methods; if it does not fit, the value returned is 0. It is
+// the generic counterpart of AsUint64, AsInt64, AsUint32 and AsInt.
+func To[T Integer](z *Int) (T, bool) {
+	if !z.IsUint64() {
+		return 0, false
+	}
+	v := T(z.Uint64())
+	if v < 0 || uint64(v) != z.Uint64() {
+		return 0, false
+	}
+	return v, true
+}
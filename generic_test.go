@@ -0,0 +1,72 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+import (
+	"math"
+	"testing"
+)
+
+type myUint16 uint16
+
+func TestFromInteger(t *testing.T) {
+	for _, tc := range []struct {
+		got *Int
+		exp *Int
+	}{
+		{FromInteger(0), new(Int)},
+		{FromInteger(int8(127)), new(Int).SetUint64(127)},
+		{FromInteger(uint64(math.MaxUint64)), new(Int).SetUint64(math.MaxUint64)},
+		{FromInteger(myUint16(65535)), new(Int).SetUint64(65535)},
+		{FromInteger(-1), new(Int).SetAllOne()},
+		{FromInteger(int64(math.MinInt64)), new(Int).Sub(new(Int), new(Int).SetUint64(1<<63))},
+	} {
+		if !tc.got.Eq(tc.exp) {
+			t.Errorf("got %v, exp %v", tc.got.Hex(), tc.exp.Hex())
+		}
+	}
+}
+
+func TestTo(t *testing.T) {
+	if v, ok := To[uint8](new(Int).SetUint64(255)); !ok || v != 255 {
+		t.Errorf("To[uint8](255): got %d, %v", v, ok)
+	}
+	if v, ok := To[uint8](new(Int).SetUint64(256)); ok || v != 0 {
+		t.Errorf("To[uint8](256): got %d, %v", v, ok)
+	}
+	if v, ok := To[int8](new(Int).SetUint64(127)); !ok || v != 127 {
+		t.Errorf("To[int8](127): got %d, %v", v, ok)
+	}
+	if v, ok := To[int8](new(Int).SetUint64(128)); ok || v != 0 {
+		t.Errorf("To[int8](128): got %d, %v", v, ok)
+	}
+	if v, ok := To[int64](new(Int).SetUint64(math.MaxInt64)); !ok || v != math.MaxInt64 {
+		t.Errorf("To[int64](MaxInt64): got %d, %v", v, ok)
+	}
+	if v, ok := To[int64](new(Int).SetUint64(math.MaxInt64 + 1)); ok || v != 0 {
+		t.Errorf("To[int64](MaxInt64+1): got %d, %v", v, ok)
+	}
+	if v, ok := To[myUint16](new(Int).SetUint64(42)); !ok || v != 42 {
+		t.Errorf("To[myUint16](42): got %d, %v", v, ok)
+	}
+	if _, ok := To[uint64](&Int{0, 1}); ok {
+		t.Errorf("To[uint64](2**64): expected !ok")
+	}
+	if _, ok := To[int](FromInteger(-1)); ok {
+		t.Errorf("To[int](2**256-1): expected !ok")
+	}
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		v, ok := To[uint64](x)
+		exp, expOk := x.AsUint64()
+		if v != exp || ok != expOk {
+			t.Fatalf("To[uint64](%v): got %d, %v, exp %d, %v", x.Hex(), v, ok, exp, expOk)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var errGraphQLValue = errors.New("uint256: unsupported graphql value")
+
+// GQLMarshaler is the gqlgen graphql.Marshaler interface, which the
+// marshaling functions below return. It is declared here so that this
+// package does not depend on gqlgen.
+type GQLMarshaler interface {
+	MarshalGQL(w io.Writer)
+}
+
+// gqlString writes a string value as a (quoted) GraphQL/JSON string.
+type gqlString string
+
+func (s gqlString) MarshalGQL(w io.Writer) {
+	io.WriteString(w, strconv.Quote(string(s)))
+}
+
+// MarshalUint256 is a gqlgen scalar marshaling function, rendering x as a
+// decimal string. gqlgen picks up the MarshalUint256 and UnmarshalUint256
+// pair when a custom scalar is bound in gqlgen.yml with
+//
+//	models:
+//	  Uint256:
+//	    model: github.com/holiman/uint256.Uint256
+func MarshalUint256(x Int) GQLMarshaler {
+	return gqlString(x.Dec())
+}
+
+// MarshalUint256Hex is like MarshalUint256, but renders x as a 0x-prefixed
+// hex string.
+func MarshalUint256Hex(x Int) GQLMarshaler {
+	return gqlString(x.ToHex())
+}
+
+// UnmarshalUint256 is a gqlgen scalar unmarshaling function. It accepts a
+// decimal or 0x-prefixed hex string, a json.Number, or a non-negative Go
+// integer, as produced by the GraphQL input decoder.
+func UnmarshalUint256(v interface{}) (Int, error) {
+	var z Int
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return z, z.SetFromHex(v)
+		}
+		return z, z.SetFromDecimal(v)
+	case json.Number:
+		return z, z.SetFromDecimal(string(v))
+	case int:
+		if v >= 0 {
+			return *z.SetUint64(uint64(v)), nil
+		}
+	case int32:
+		if v >= 0 {
+			return *z.SetUint64(uint64(v)), nil
+		}
+	case int64:
+		if v >= 0 {
+			return *z.SetUint64(uint64(v)), nil
+		}
+	case uint64:
+		return *z.SetUint64(v), nil
+	}
+	return z, errGraphQLValue
+}
+
+// MarshalGQL implements the gqlgen graphql.Marshaler interface, so that Int
+// can also be used directly as a custom scalar model. It writes z as a
+// decimal string.
+func (z *Int) MarshalGQL(w io.Writer) {
+	z = orZero(z)
+	MarshalUint256(*z).MarshalGQL(w)
+}
+
+// UnmarshalGQL implements the gqlgen graphql.Unmarshaler interface, with the
+// same accepted inputs as UnmarshalUint256.
+func (z *Int) UnmarshalGQL(v interface{}) error {
+	if z == nil {
+		panic(nilReceiver("UnmarshalGQL"))
+	}
+	x, err := UnmarshalUint256(v)
+	if err != nil {
+		return err
+	}
+	z.Copy(&x)
+	return nil
+}
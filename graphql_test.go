@@ -0,0 +1,60 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalGraphQL(t *testing.T) {
+	x := MustFromDecimal("1000000000000000000000")
+	var buf bytes.Buffer
+	MarshalUint256(*x).MarshalGQL(&buf)
+	if got := buf.String(); got != `"1000000000000000000000"` {
+		t.Errorf("decimal: got %s", got)
+	}
+	buf.Reset()
+	MarshalUint256Hex(*x).MarshalGQL(&buf)
+	if got := buf.String(); got != `"0x3635c9adc5dea00000"` {
+		t.Errorf("hex: got %s", got)
+	}
+	buf.Reset()
+	x.MarshalGQL(&buf)
+	if got := buf.String(); got != `"1000000000000000000000"` {
+		t.Errorf("method: got %s", got)
+	}
+}
+
+func TestUnmarshalGraphQL(t *testing.T) {
+	for _, tc := range []struct {
+		v   interface{}
+		exp uint64
+	}{
+		{"12345", 12345},
+		{"0x3039", 12345},
+		{json.Number("12345"), 12345},
+		{12345, 12345},
+		{int32(12345), 12345},
+		{int64(12345), 12345},
+		{uint64(12345), 12345},
+	} {
+		got, err := UnmarshalUint256(tc.v)
+		if err != nil || got.Uint64() != tc.exp {
+			t.Errorf("%#v: got %v (err %v)", tc.v, got.Uint64(), err)
+		}
+		var z Int
+		if err := z.UnmarshalGQL(tc.v); err != nil || z.Uint64() != tc.exp {
+			t.Errorf("%#v: UnmarshalGQL got %v (err %v)", tc.v, z.Uint64(), err)
+		}
+	}
+	for _, v := range []interface{}{-1, int64(-1), 1.5, nil, true, "-1", "1e3", "0x"} {
+		if _, err := UnmarshalUint256(v); err == nil {
+			t.Errorf("%#v: expected error", v)
+		}
+	}
+}
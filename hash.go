@@ -0,0 +1,117 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// deriveSalt is the HKDF-extract salt used by DeriveUniform, which separates
+// its outputs from other uses of the same seed.
+var deriveSalt = []byte("uint256/DeriveUniform")
+
+// SetFromHash sets z to the big-endian value of h reduced modulo 2**256,
+// i.e. the last 32 bytes of h, and returns z.
+//
+// Note that truncating a hash is only unbiased modulo a power of two; use
+// SetFromHashMod to map a hash onto the range [0, m).
+func (z *Int) SetFromHash(h []byte) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromHash"))
+	}
+	if len(h) > 32 {
+		h = h[len(h)-32:]
+	}
+	return z.SetBytes(h)
+}
+
+// SetFromHashMod sets z to the big-endian value of h (of any length) reduced
+// modulo m, and returns z. If m == 0, z is set to 0.
+//
+// The result is statistically close to uniform when h is uniformly random and
+// at least 128 bits longer than m, which is why hashes should be reduced from
+// 512 bits ("wide reduction") rather than truncated to the size of m.
+func (z *Int) SetFromHashMod(h []byte, m *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromHashMod"))
+	}
+	if m.IsZero() {
+		return z.Clear()
+	}
+	var (
+		mod = *m // z may alias m
+		rem Int
+	)
+	// Process h in 32-byte chunks, with a leading partial chunk, as
+	// rem = (rem * 2**256 + chunk) mod m.
+	first := len(h) % 32
+	if first == 0 && len(h) > 0 {
+		first = 32
+	}
+	rem.SetBytes(h[:first]).Mod(&rem, &mod)
+	for h = h[first:]; len(h) > 0; h = h[32:] {
+		if rem.IsZero() {
+			rem.SetBytes(h[:32]).Mod(&rem, &mod)
+			continue
+		}
+		u := [8]uint64{
+			binary.BigEndian.Uint64(h[24:32]),
+			binary.BigEndian.Uint64(h[16:24]),
+			binary.BigEndian.Uint64(h[8:16]),
+			binary.BigEndian.Uint64(h[0:8]),
+			rem[0], rem[1], rem[2], rem[3],
+		}
+		var quot [8]uint64
+		rem = udivrem(quot[:], u[:], &mod)
+	}
+	return z.Copy(&rem)
+}
+
+// HashToInt hashes data with SHA-512, and returns the 512-bit digest reduced
+// modulo m, which is suitable for mapping data (such as commitments) onto
+// scalars modulo any m of up to 256 bits with negligible bias.
+// If m == 0, the result is 0.
+func HashToInt(data []byte, m *Int) *Int {
+	h := sha512.Sum512(data)
+	return new(Int).SetFromHashMod(h[:], m)
+}
+
+// DeriveUniform deterministically derives a value uniformly distributed in
+// [0, max) from seed, e.g. for nonce derivation or shard assignment.
+// If max == 0, the result is 0.
+//
+// The seed is condensed into a pseudo-random key as in HKDF-Extract
+// (HMAC-SHA256), which is then expanded in counter mode into candidates of
+// max.BitLen() bits; candidates not below max are rejected. On average at
+// most two candidates are needed.
+func DeriveUniform(seed []byte, max *Int) *Int {
+	z := new(Int)
+	if max.IsZero() {
+		return z
+	}
+	extract := hmac.New(sha256.New, deriveSalt)
+	extract.Write(seed)
+	expand := hmac.New(sha256.New, extract.Sum(nil))
+
+	var (
+		shift = uint(256 - max.BitLen())
+		ctr   [4]byte
+		block [sha256.Size]byte
+	)
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(ctr[:], i)
+		expand.Reset()
+		expand.Write(ctr[:])
+		z.SetBytes(expand.Sum(block[:0]))
+		z.Rsh(z, shift)
+		if z.Lt(max) {
+			return z
+		}
+	}
+}
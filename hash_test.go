@@ -0,0 +1,86 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"math/big"
+	"testing"
+)
+
+func TestSetFromHash(t *testing.T) {
+	h := hex2Bytes("ff00000000000000000000000000000000000000000000000000000000000000000001")
+	if z := new(Int).SetFromHash(h); !z.Eq(&Int{1}) {
+		t.Errorf("got %v", z.Hex())
+	}
+	if z := new(Int).SetFromHash(h[:3]); !z.Eq(&Int{0xff0000}) {
+		t.Errorf("got %v", z.Hex())
+	}
+}
+
+func TestSetFromHashMod(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		bm, m, _ := randNums()
+		h := make([]byte, i%100)
+		rand.Read(h)
+		z := new(Int).SetFromHashMod(h, m)
+		if m.IsZero() {
+			if !z.IsZero() {
+				t.Fatalf("expected zero for zero modulus")
+			}
+			continue
+		}
+		exp := new(big.Int).Mod(new(big.Int).SetBytes(h), bm)
+		requireEq(t, exp, z, "hash mod")
+		// Aliasing the modulus.
+		if m.SetFromHashMod(h, m); !m.Eq(z) {
+			t.Fatalf("aliased: got %v exp %v", m.Hex(), z.Hex())
+		}
+	}
+	// Leading zero chunks.
+	h := make([]byte, 96)
+	h[95] = 5
+	if z := new(Int).SetFromHashMod(h, &Int{3}); !z.Eq(&Int{2}) {
+		t.Errorf("got %v", z.Hex())
+	}
+}
+
+func TestHashToInt(t *testing.T) {
+	m := new(Int).SetBytes(hex2Bytes("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"))
+	data := []byte("commitment")
+	h := sha512.Sum512(data)
+	exp := new(big.Int).Mod(new(big.Int).SetBytes(h[:]), m.ToBig())
+	requireEq(t, exp, HashToInt(data, m), "hash to int")
+}
+
+func TestDeriveUniform(t *testing.T) {
+	max := new(Int).SetUint64(1000)
+	a, b := DeriveUniform([]byte("seed"), max), DeriveUniform([]byte("seed"), max)
+	if !a.Eq(b) {
+		t.Fatalf("not deterministic: %v != %v", a.Hex(), b.Hex())
+	}
+	for _, max := range []*Int{{1}, {2}, {1000}, {0, 0, 1}, MinInt256(), new(Int).SetAllOne()} {
+		for i := 0; i < 100; i++ {
+			if z := DeriveUniform([]byte{byte(i)}, max); !z.Lt(max) {
+				t.Fatalf("got %v, not below %v", z.Hex(), max.Hex())
+			}
+		}
+	}
+	// All residues of a small bound are hit.
+	var seen [10]bool
+	for i := 0; i < 1000; i++ {
+		seen[DeriveUniform([]byte{byte(i), byte(i >> 8)}, &Int{10}).Uint64()] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("value %d never derived", i)
+		}
+	}
+	if !DeriveUniform([]byte("seed"), new(Int)).IsZero() {
+		t.Errorf("expected zero for zero bound")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// HexFlag selects the variant of the hex form produced by FormatHex.
+type HexFlag uint
+
+const (
+	// HexPrefix adds a 0x prefix.
+	HexPrefix HexFlag = 1 << iota
+	// HexPad pads the digits with leading zeros to the full 64 nibbles.
+	HexPad
+	// HexUpper uses upper-case digits; the prefix stays lower-case.
+	HexUpper
+)
+
+const hexDigitsUpper = "0123456789ABCDEF"
+
+// FormatHex returns the hex form of z, as selected by flags. Without HexPad,
+// there are no leading zeros, and 0 has a single digit. ToHex is the same as
+// FormatHex(HexPrefix).
+func (z *Int) FormatHex(flags HexFlag) string {
+	var (
+		buf     [2 + 64]byte
+		digits  = hexDigits
+		nibbles = 64
+		pos     = 0
+	)
+	if flags&HexUpper != 0 {
+		digits = hexDigitsUpper
+	}
+	if flags&HexPad == 0 {
+		if nibbles = (z.BitLen() + 3) / 4; nibbles == 0 {
+			nibbles = 1
+		}
+	}
+	if flags&HexPrefix != 0 {
+		buf[0], buf[1] = '0', 'x'
+		pos = 2
+	}
+	out := buf[:pos+nibbles]
+	var x Int
+	if z != nil {
+		x = *z
+	}
+	for i := 0; i < nibbles; i++ {
+		out[len(out)-1-i] = digits[x.Window(uint(4*i), 4)]
+	}
+	return string(out)
+}
+
+// Hex64 returns the 0x-prefixed hex form of z, zero-padded to the full 64
+// nibbles, as used for storage keys.
+func (z *Int) Hex64() string {
+	return z.FormatHex(HexPrefix | HexPad)
+}
+
+// HexNoPrefix returns the hex form of z without the 0x prefix, in lower case
+// and without leading zeros.
+func (z *Int) HexNoPrefix() string {
+	return z.FormatHex(0)
+}
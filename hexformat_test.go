@@ -0,0 +1,46 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormatHex(t *testing.T) {
+	x := &Int{0xabcdef, 0, 0, 0}
+	for _, tc := range []struct {
+		x     *Int
+		flags HexFlag
+		exp   string
+	}{
+		{x, 0, "abcdef"},
+		{x, HexPrefix, "0xabcdef"},
+		{x, HexUpper, "ABCDEF"},
+		{x, HexPrefix | HexUpper, "0xABCDEF"},
+		{x, HexPad, "0000000000000000000000000000000000000000000000000000000000abcdef"},
+		{x, HexPad | HexPrefix | HexUpper, "0x0000000000000000000000000000000000000000000000000000000000ABCDEF"},
+		{new(Int), 0, "0"},
+		{new(Int), HexPrefix | HexPad, "0x0000000000000000000000000000000000000000000000000000000000000000"},
+		{nil, HexPrefix, "0x0"},
+	} {
+		if got := tc.x.FormatHex(tc.flags); got != tc.exp {
+			t.Errorf("FormatHex(%v, %d): got %s, exp %s", tc.x, tc.flags, got, tc.exp)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randNums()
+		if got, exp := x.Hex64(), fmt.Sprintf("0x%064x", b); got != exp {
+			t.Fatalf("Hex64: got %s, exp %s", got, exp)
+		}
+		if got, exp := x.HexNoPrefix(), fmt.Sprintf("%x", b); got != exp {
+			t.Fatalf("HexNoPrefix: got %s, exp %s", got, exp)
+		}
+		if got, exp := x.FormatHex(HexPrefix), x.ToHex(); got != exp {
+			t.Fatalf("FormatHex(HexPrefix): got %s, exp %s", got, exp)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "sort"
+
+// Bucketize counts values into the buckets delimited by bounds, which must be
+// sorted in ascending order. The result has len(bounds)+1 counts: counts[0]
+// is the number of values below bounds[0], counts[i] the number in
+// [bounds[i-1], bounds[i]), and counts[len(bounds)] the number at or above
+// the last bound.
+func Bucketize(values []Int, bounds []Int) []int {
+	counts := make([]int, len(bounds)+1)
+	for i := range values {
+		x := &values[i]
+		counts[sort.Search(len(bounds), func(j int) bool { return x.Lt(&bounds[j]) })]++
+	}
+	return counts
+}
+
+// Log2Buckets is the number of buckets of a Log2Histogram.
+const Log2Buckets = 257
+
+// Log2Histogram counts values in buckets of powers of two: bucket 0 holds
+// zero, and bucket n > 0 holds the values in [2**(n-1), 2**n), i.e. those of
+// bit length n. It is not safe for concurrent use. The zero value is an empty
+// histogram ready to use.
+type Log2Histogram struct {
+	counts [Log2Buckets]uint64
+	total  uint64
+}
+
+// Add counts x in its bucket.
+func (h *Log2Histogram) Add(x *Int) {
+	h.counts[x.BitLen()]++
+	h.total++
+}
+
+// Count returns the number of values counted in bucket n.
+func (h *Log2Histogram) Count(n int) uint64 {
+	return h.counts[n]
+}
+
+// Total returns the number of values counted in all buckets.
+func (h *Log2Histogram) Total() uint64 {
+	return h.total
+}
+
+// Merge adds the counts of other to h.
+func (h *Log2Histogram) Merge(other *Log2Histogram) {
+	for i, c := range other.counts {
+		h.counts[i] += c
+	}
+	h.total += other.total
+}
+
+// Log2BucketBounds returns the smallest and largest value of bucket n of a
+// Log2Histogram.
+func Log2BucketBounds(n int) (lo, hi *Int) {
+	if n == 0 {
+		return new(Int), new(Int)
+	}
+	lo = new(Int).Lsh(&Int{1}, uint(n-1))
+	hi = new(Int).Lsh(&Int{1}, uint(n))
+	return lo, hi.SubOne(hi)
+}
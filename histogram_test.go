@@ -0,0 +1,72 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBucketize(t *testing.T) {
+	n := func(vs ...uint64) []Int {
+		s := make([]Int, len(vs))
+		for i, v := range vs {
+			s[i].SetUint64(v)
+		}
+		return s
+	}
+	for _, tc := range []struct {
+		values, bounds []Int
+		want           []int
+	}{
+		{nil, nil, []int{0}},
+		{n(1, 2, 3), nil, []int{3}},
+		{n(0, 9, 10, 11, 99, 100, 1000), n(10, 100), []int{2, 3, 2}},
+		{n(5, 5, 5), n(5), []int{0, 3}},
+		{[]Int{*MaxUint256(), {}}, []Int{*MaxUint256()}, []int{1, 1}},
+	} {
+		if have := Bucketize(tc.values, tc.bounds); !reflect.DeepEqual(have, tc.want) {
+			t.Errorf("Bucketize(%v, %v): have %v, want %v", tc.values, tc.bounds, have, tc.want)
+		}
+	}
+}
+
+func TestLog2Histogram(t *testing.T) {
+	var h, other Log2Histogram
+	values := randSlice(1000)
+	values = append(values, Int{}, *MaxUint256())
+	for i := range values {
+		h.Add(&values[i])
+	}
+	other.Add(new(Int))
+	h.Merge(&other)
+	if h.Total() != uint64(len(values)+1) {
+		t.Fatalf("have total %d, want %d", h.Total(), len(values)+1)
+	}
+	var sum uint64
+	for n := 0; n < Log2Buckets; n++ {
+		lo, hi := Log2BucketBounds(n)
+		var want uint64
+		if n == 0 {
+			want++ // merged
+		}
+		for i := range values {
+			if x := &values[i]; !x.Lt(lo) && !x.Gt(hi) {
+				want++
+			}
+		}
+		if have := h.Count(n); have != want {
+			t.Errorf("bucket %d [%v, %v]: have %d, want %d", n, lo.Hex(), hi.Hex(), have, want)
+		}
+		sum += h.Count(n)
+	}
+	if sum != h.Total() {
+		t.Fatalf("bucket counts sum to %d, want %d", sum, h.Total())
+	}
+	if _, hi := Log2BucketBounds(256); !hi.Eq(MaxUint256()) {
+		t.Fatalf("last bucket ends at %v", hi.Hex())
+	}
+}
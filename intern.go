@@ -0,0 +1,95 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// Interner maps values to shared Ints, so that decoders which materialize
+// many copies of the same values can return one pointer for each, instead
+// of allocating. The shared Ints must never be modified, since every holder
+// of the pointer would see the change. An Interner is immutable once
+// created, and safe for concurrent use.
+type Interner struct {
+	values map[Int]*Int
+}
+
+// NewInterner returns an Interner sharing the given values.
+func NewInterner(values ...*Int) *Interner {
+	in := &Interner{values: make(map[Int]*Int, len(values))}
+	for _, v := range values {
+		if _, ok := in.values[*v]; !ok {
+			in.values[*v] = v.Clone()
+		}
+	}
+	return in
+}
+
+// Lookup returns the shared Int equal to x, if there is one.
+func (in *Interner) Lookup(x *Int) (*Int, bool) {
+	v, ok := in.values[*x]
+	return v, ok
+}
+
+// Intern returns the shared Int equal to x if there is one, or else a new
+// copy of x.
+func (in *Interner) Intern(x *Int) *Int {
+	if v, ok := in.values[*x]; ok {
+		return v
+	}
+	return x.Clone()
+}
+
+// Len returns the number of shared values.
+func (in *Interner) Len() int {
+	return len(in.values)
+}
+
+// smallInts holds the values 0 to 256, which Intern shares without a map
+// lookup.
+var smallInts = func() (t [257]Int) {
+	for i := range t {
+		t[i][0] = uint64(i)
+	}
+	return t
+}()
+
+// commonInterner holds the values other than small ones that Intern shares.
+var commonInterner = func() *Interner {
+	var values []*Int
+	// All powers of ten: denominations such as gwei and ether in wei, and
+	// round amounts.
+	p := NewInt().SetOne()
+	for i := 0; i < maxDecimalLen; i++ {
+		values = append(values, p.Clone())
+		p.Mul(p, NewInt().SetUint64(10))
+	}
+	// Common EVM gas amounts and limits.
+	for _, g := range []uint64{
+		2300, 5000, 20000, 21000, 32000, 53000, 100000,
+		200000, 300000, 500000, 1000000, 8000000, 15000000, 30000000,
+	} {
+		values = append(values, NewInt().SetUint64(g))
+	}
+	values = append(values, new(Int).SetAllOne())
+	return NewInterner(values...)
+}()
+
+// Intern returns a shared Int equal to x if x is a commonly occurring value,
+// or else a new copy of x. The shared values are 0 to 256, the powers of ten,
+// common gas amounts and 2**256 - 1. The returned Int must not be modified.
+func Intern(x *Int) *Int {
+	if v, ok := Interned(x); ok {
+		return v
+	}
+	return x.Clone()
+}
+
+// Interned returns the shared Int equal to x, if x is one of the values that
+// Intern shares. The returned Int must not be modified.
+func Interned(x *Int) (*Int, bool) {
+	if x.IsUint64() && x[0] < uint64(len(smallInts)) {
+		return &smallInts[x[0]], true
+	}
+	return commonInterner.Lookup(x)
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestIntern(t *testing.T) {
+	gwei := NewInt().SetUint64(1000000000)
+	ether := new(Int).Mul(gwei, gwei)
+	for _, x := range []*Int{
+		new(Int),
+		NewInt().SetUint64(256),
+		gwei,
+		ether,
+		NewInt().SetUint64(21000),
+		new(Int).SetAllOne(),
+	} {
+		a, b := Intern(x), Intern(x.Clone())
+		if a != b {
+			t.Errorf("Intern(%v): different pointers", x)
+		}
+		if !a.Eq(x) {
+			t.Errorf("Intern(%v): have %v", x, a)
+		}
+		if a == x {
+			t.Errorf("Intern(%v): returned its argument", x)
+		}
+	}
+	x := NewInt().SetUint64(257)
+	if _, ok := Interned(x); ok {
+		t.Errorf("Interned(%v): ok", x)
+	}
+	if a := Intern(x); a == x || !a.Eq(x) {
+		t.Errorf("Intern(%v): want a new copy, have %v", x, a)
+	}
+}
+
+func TestInterner(t *testing.T) {
+	a, b := NewInt().SetUint64(7), NewInt().SetUint64(7)
+	in := NewInterner(a, b, NewInt().SetUint64(8))
+	if in.Len() != 2 {
+		t.Errorf("Len: have %d, want 2", in.Len())
+	}
+	v, ok := in.Lookup(NewInt().SetUint64(7))
+	if !ok || v == a || !v.Eq(a) {
+		t.Errorf("Lookup: have %v %v", v, ok)
+	}
+	// The Interner holds its own copies.
+	a.SetOne()
+	if v, _ := in.Lookup(NewInt().SetUint64(7)); v.Uint64() != 7 {
+		t.Errorf("shared value changed with argument: %v", v)
+	}
+	if v := in.Intern(NewInt().SetUint64(9)); v.Uint64() != 9 {
+		t.Errorf("Intern: have %v, want 9", v)
+	}
+}
+
+func BenchmarkIntern(b *testing.B) {
+	x := NewInt().SetUint64(1000000000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Intern(x)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// IntRange is the sequence start, start+step, start+2*step, ... of the values
+// not greater than end. It ends correctly near the top of the 256-bit range,
+// where the next value would overflow.
+type IntRange struct {
+	start, end, step Int
+}
+
+// NewRange returns the range from start up to and including end, in
+// increments of step. The range is empty if start > end. It panics if step
+// is zero.
+func NewRange(start, end, step *Int) *IntRange {
+	if step.IsZero() {
+		panic("uint256: zero range step")
+	}
+	return &IntRange{start: *start, end: *end, step: *step}
+}
+
+// Iterate calls yield with each value of the range in order, until yield
+// returns false. yield must not retain its argument, which is reused.
+//
+// It has the signature of an iterator, so the method value can be ranged
+// over with Go 1.23 and later:
+//
+//	for v := range r.Iterate {
+//		...
+//	}
+func (r *IntRange) Iterate(yield func(v *Int) bool) {
+	it := r.Iterator()
+	var v Int
+	for it.Next(&v) {
+		if !yield(&v) {
+			return
+		}
+	}
+}
+
+// Iterator returns an iterator over the values of the range.
+func (r *IntRange) Iterator() *RangeIterator {
+	return &RangeIterator{next: r.start, end: r.end, step: r.step, done: r.start.Gt(&r.end)}
+}
+
+// RangeIterator steps through the values of an IntRange.
+type RangeIterator struct {
+	next, end, step Int
+	done            bool
+}
+
+// Next sets z to the next value of the range, and reports whether there was
+// one. z is left unchanged at the end of the range.
+func (it *RangeIterator) Next(z *Int) bool {
+	if it.done {
+		return false
+	}
+	z.Copy(&it.next)
+	if it.next.AddOverflow(&it.next, &it.step) || it.next.Gt(&it.end) {
+		it.done = true
+	}
+	return true
+}
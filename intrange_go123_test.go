@@ -0,0 +1,25 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.23
+// +build go1.23
+
+package uint256
+
+import "testing"
+
+func TestIntRangeRangeFunc(t *testing.T) {
+	r := NewRange(new(Int), NewInt().SetUint64(9), NewInt().SetUint64(3))
+	var sum uint64
+	for v := range r.Iterate {
+		sum += v.Uint64()
+		if v.Uint64() == 6 {
+			break
+		}
+	}
+	if sum != 9 {
+		t.Errorf("have %d, want 9", sum)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+// rangeValues returns the values of r.
+func rangeValues(r *IntRange) []Int {
+	var vals []Int
+	r.Iterate(func(v *Int) bool {
+		vals = append(vals, *v)
+		return true
+	})
+	return vals
+}
+
+func TestIntRange(t *testing.T) {
+	max := new(Int).SetAllOne()
+	maxMinus := func(n uint64) *Int { return new(Int).Sub(max, NewInt().SetUint64(n)) }
+	u := func(n uint64) *Int { return NewInt().SetUint64(n) }
+	tests := []struct {
+		start, end, step *Int
+		want             []*Int
+	}{
+		{u(0), u(4), u(2), []*Int{u(0), u(2), u(4)}},
+		{u(0), u(5), u(2), []*Int{u(0), u(2), u(4)}},
+		{u(3), u(3), u(1), []*Int{u(3)}},
+		{u(4), u(3), u(1), nil},
+		// The last value is max, and incrementing it wraps.
+		{maxMinus(2), max, u(1), []*Int{maxMinus(2), maxMinus(1), max}},
+		// The step overflows past end.
+		{maxMinus(5), max, u(4), []*Int{maxMinus(5), maxMinus(1)}},
+		{u(1), max, max, []*Int{u(1)}},
+		{u(0), max, max, []*Int{u(0), max}},
+	}
+	for i, tc := range tests {
+		have := rangeValues(NewRange(tc.start, tc.end, tc.step))
+		if len(have) != len(tc.want) {
+			t.Errorf("test %d: have %d values, want %d", i, len(have), len(tc.want))
+			continue
+		}
+		for j := range have {
+			if !have[j].Eq(tc.want[j]) {
+				t.Errorf("test %d: value %d: have %v, want %v", i, j, &have[j], tc.want[j])
+			}
+		}
+	}
+}
+
+func TestIntRangeIterator(t *testing.T) {
+	r := NewRange(NewInt().SetUint64(10), NewInt().SetUint64(12), NewInt().SetOne())
+	// Iterators are independent.
+	a, b := r.Iterator(), r.Iterator()
+	var x, y Int
+	for want := uint64(10); want <= 12; want++ {
+		if !a.Next(&x) || x.Uint64() != want {
+			t.Fatalf("have %v, want %d", &x, want)
+		}
+	}
+	if a.Next(&x) {
+		t.Errorf("Next after end returned true")
+	}
+	if x.Uint64() != 12 {
+		t.Errorf("Next at end changed z: %v", &x)
+	}
+	if !b.Next(&y) || y.Uint64() != 10 {
+		t.Errorf("second iterator: have %v, want 10", &y)
+	}
+
+	var n int
+	r.Iterate(func(v *Int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Iterate continued after yield returned false")
+	}
+}
+
+func TestIntRangeZeroStep(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("no panic")
+		}
+	}()
+	NewRange(new(Int), new(Int), new(Int))
+}
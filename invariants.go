@@ -0,0 +1,66 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var (
+	errInvariantRem = errors.New("uint256: division remainder not less than divisor")
+	errInvariantSum = errors.New("uint256: quotient*divisor+remainder differs from dividend")
+)
+
+// CheckInvariants checks the defining properties of the division of x by y,
+// as computed by Div and Mod: that quot*y + rem == x without overflow, and
+// rem < y. If y is zero, quot and rem must be zero as well. It checks the
+// results using only multiplication and addition, so it can be used to
+// verify the division, and is what builds with the uint256debug tag run
+// after every division, panicking on failure.
+func CheckInvariants(x, y, quot, rem *Int) error {
+	if y.IsZero() {
+		if !quot.IsZero() || !rem.IsZero() {
+			return errInvariantSum
+		}
+		return nil
+	}
+	return checkDivrem(quot[:], x[:], y, rem)
+}
+
+// checkDivrem checks the results of udivrem(quot, u, d): that
+// quot*d + rem == u and rem < d.
+func checkDivrem(quot, u []uint64, d, rem *Int) error {
+	if !rem.Lt(d) {
+		return errInvariantRem
+	}
+	// acc = quot*d + rem, computed one row of the schoolbook
+	// multiplication at a time.
+	acc := make([]uint64, len(quot)+len(d)+1)
+	copy(acc, rem[:])
+	for i, q := range quot {
+		var carry uint64
+		for j := range d {
+			acc[i+j], carry = umulStep(acc[i+j], q, d[j], carry)
+		}
+		for k := i + len(d); carry != 0; k++ {
+			acc[k], carry = bits.Add64(acc[k], carry, 0)
+		}
+	}
+	for i, w := range acc {
+		if i < len(u) && w != u[i] || i >= len(u) && w != 0 {
+			return errInvariantSum
+		}
+	}
+	return nil
+}
+
+// mustDivrem panics if the results of udivrem(quot, u, d) are wrong.
+func mustDivrem(quot, u []uint64, d, rem *Int) {
+	if err := checkDivrem(quot, u, d, rem); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build uint256debug
+// +build uint256debug
+
+package uint256
+
+// With the uint256debug build tag, every division underlying Div, Mod,
+// AddMod and MulMod checks its results with CheckInvariants, and panics if
+// they are wrong. This costs more than the division itself, so the tag is
+// meant for testing and fuzzing builds, not production ones.
+const checkInvariants = true
@@ -0,0 +1,14 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build !uint256debug
+// +build !uint256debug
+
+package uint256
+
+// checkInvariants reports whether divisions check their results, which
+// requires the uint256debug build tag. Without it, the checks are compiled
+// out.
+const checkInvariants = false
@@ -0,0 +1,41 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestCheckInvariants(t *testing.T) {
+	n := func(v uint64) *Int { return new(Int).SetUint64(v) }
+	max := new(Int).SetAllOne()
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randNums()
+		y.Rsh(y, uint(i%256))
+		quot, rem := new(Int).Div(x, y), new(Int).Mod(x, y)
+		if err := CheckInvariants(x, y, quot, rem); err != nil {
+			t.Fatalf("%v / %v: %v", x.Hex(), y.Hex(), err)
+		}
+	}
+	tests := []struct {
+		x, y, quot, rem *Int
+		want            error
+	}{
+		{n(7), n(2), n(3), n(1), nil},
+		{n(7), new(Int), new(Int), new(Int), nil},
+		{max, n(1), max, new(Int), nil},
+		{n(7), n(2), n(2), n(3), errInvariantRem},
+		{n(7), n(2), n(3), n(2), errInvariantRem},
+		{n(7), n(2), n(2), n(1), errInvariantSum},
+		{n(7), new(Int), new(Int), n(7), errInvariantSum},
+		// The product overflows 256 bits.
+		{n(6), n(2), new(Int).Add(new(Int).Lsh(n(1), 255), n(3)), new(Int), errInvariantSum},
+	}
+	for i, tc := range tests {
+		if err := CheckInvariants(tc.x, tc.y, tc.quot, tc.rem); err != tc.want {
+			t.Errorf("test %d: have %v, want %v", i, err, tc.want)
+		}
+	}
+}
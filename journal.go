@@ -0,0 +1,72 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// journalEntry is the value of an Int before a recorded mutation.
+type journalEntry struct {
+	ptr *Int
+	old Int
+}
+
+// Journal records the values of Ints before they are mutated, so that the
+// mutations since a snapshot can be reverted, in time proportional to their
+// number, as for the nested call frames of the EVM:
+//
+//	id := j.Snapshot()
+//	j.Record(&balance).Sub(&balance, value)
+//	...
+//	j.RevertToSnapshot(id) // on failure
+//
+// Ints are tracked by pointer, so they must not move while recorded, e.g. by
+// reallocation of the slice holding them. The zero value is an empty Journal.
+type Journal struct {
+	entries []journalEntry
+}
+
+// Record saves the current value of z, which is about to be mutated, and
+// returns z.
+func (j *Journal) Record(z *Int) *Int {
+	j.entries = append(j.entries, journalEntry{ptr: z, old: *z})
+	return z
+}
+
+// Set records z, then sets it to x, and returns z.
+func (j *Journal) Set(z, x *Int) *Int {
+	return j.Record(z).Copy(x)
+}
+
+// Snapshot returns an identifier of the current state, for RevertToSnapshot.
+func (j *Journal) Snapshot() int {
+	return len(j.entries)
+}
+
+// RevertToSnapshot restores the values of all Ints recorded since the
+// snapshot id was taken, and forgets those records, and any snapshots taken
+// after id. It panics if id is not a valid snapshot.
+func (j *Journal) RevertToSnapshot(id int) {
+	if id < 0 || id > len(j.entries) {
+		panic("uint256: invalid journal snapshot")
+	}
+	for i := len(j.entries) - 1; i >= id; i-- {
+		e := &j.entries[i]
+		*e.ptr = e.old
+		e.ptr = nil // Do not keep the Int alive.
+	}
+	j.entries = j.entries[:id]
+}
+
+// Length returns the number of recorded mutations.
+func (j *Journal) Length() int {
+	return len(j.entries)
+}
+
+// Reset forgets all records, making the current values permanent.
+func (j *Journal) Reset() {
+	for i := range j.entries {
+		j.entries[i].ptr = nil
+	}
+	j.entries = j.entries[:0]
+}
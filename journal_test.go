@@ -0,0 +1,74 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestJournal(t *testing.T) {
+	var (
+		j     Journal
+		state = make([]Int, 4)
+		one   = NewInt().SetOne()
+	)
+	for i := range state {
+		state[i].SetUint64(uint64(i) * 100)
+	}
+	orig := append([]Int(nil), state...)
+
+	outer := j.Snapshot()
+	j.Record(&state[0]).Add(&state[0], one)
+	j.Set(&state[1], new(Int).SetAllOne())
+	afterOuter := append([]Int(nil), state...)
+
+	inner := j.Snapshot()
+	// Mutating the same Int twice restores the oldest value.
+	j.Record(&state[0]).Add(&state[0], one)
+	j.Record(&state[0]).Add(&state[0], one)
+	j.Record(&state[3]).Clear()
+	if have, want := j.Length(), 5; have != want {
+		t.Fatalf("Length: have %d, want %d", have, want)
+	}
+
+	j.RevertToSnapshot(inner)
+	for i := range state {
+		if !state[i].Eq(&afterOuter[i]) {
+			t.Errorf("inner revert: state[%d] = %v, want %v", i, &state[i], &afterOuter[i])
+		}
+	}
+	if have, want := j.Length(), inner; have != want {
+		t.Errorf("Length after revert: have %d, want %d", have, want)
+	}
+
+	j.RevertToSnapshot(outer)
+	for i := range state {
+		if !state[i].Eq(&orig[i]) {
+			t.Errorf("outer revert: state[%d] = %v, want %v", i, &state[i], &orig[i])
+		}
+	}
+
+	// Reset makes the current values permanent.
+	j.Record(&state[2]).SetOne()
+	j.Reset()
+	j.RevertToSnapshot(0)
+	if !state[2].IsOne() {
+		t.Errorf("after Reset: state[2] = %v, want 1", &state[2])
+	}
+}
+
+func TestJournalInvalidSnapshot(t *testing.T) {
+	var j Journal
+	j.Record(new(Int))
+	for _, id := range []int{-1, 2} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RevertToSnapshot(%d): no panic", id)
+				}
+			}()
+			j.RevertToSnapshot(id)
+		}()
+	}
+}
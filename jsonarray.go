@@ -0,0 +1,65 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	errJSONArrayStart = errors.New("uint256: expected JSON array")
+	errJSONArrayLong  = errors.New("uint256: JSON array longer than destination")
+	errJSONArrayFloat = errors.New("uint256: JSON number decoded as float64; call UseNumber on the decoder")
+	errJSONArrayValue = errors.New("uint256: JSON array element is not a number or string")
+)
+
+// DecodeJSONArray reads the next value from dec, which must be an array of
+// decimal or 0x-prefixed hex strings or of numbers, into dst, and returns
+// the number of elements read. It consumes the array token by token, without
+// reflection or an intermediate []string, so large arrays such as RPC batch
+// results can be decoded into a preallocated slice. The decoder must be set
+// to UseNumber, since numbers decoded as float64 lose precision. Errors for
+// invalid elements wrap the element error with its index; if the array is
+// longer than dst, the elements that fit are decoded before the error.
+func DecodeJSONArray(dec *json.Decoder, dst []Int) (int, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return 0, errJSONArrayStart
+	}
+	n := 0
+	for dec.More() {
+		if n == len(dst) {
+			return n, errJSONArrayLong
+		}
+		if tok, err = dec.Token(); err != nil {
+			return n, err
+		}
+		switch v := tok.(type) {
+		case json.Number:
+			err = dst[n].SetFromJSONNumber(v)
+		case string:
+			err = dst[n].setFromDecimalOrHex(v)
+		case float64:
+			err = errJSONArrayFloat
+		default:
+			err = errJSONArrayValue
+		}
+		if err != nil {
+			return n, fmt.Errorf("uint256: element %d: %w", n, err)
+		}
+		n++
+	}
+	// Consume the closing bracket.
+	if _, err := dec.Token(); err != nil {
+		return n, err
+	}
+	return n, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// numberDecoder returns a json.Decoder reading s, with UseNumber set.
+func numberDecoder(s string) *json.Decoder {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	return dec
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	dec := numberDecoder(`["0x10", "17", 18, ` + maxUint256Dec + `] [] {"next": 1}`)
+	dst := make([]Int, 5)
+	n, err := DecodeJSONArray(dec, dst)
+	if err != nil || n != 4 {
+		t.Fatalf("have %d %v, want 4 elements", n, err)
+	}
+	for i, want := range []uint64{16, 17, 18} {
+		if dst[i].Uint64() != want {
+			t.Errorf("element %d: have %v, want %d", i, &dst[i], want)
+		}
+	}
+	if !dst[3].Eq(new(Int).SetAllOne()) {
+		t.Errorf("element 3: have %v", &dst[3])
+	}
+	// The decoder is left after the array.
+	if n, err := DecodeJSONArray(dec, dst); n != 0 || err != nil {
+		t.Errorf("empty array: have %d %v", n, err)
+	}
+	if _, err := DecodeJSONArray(dec, dst); !errors.Is(err, errJSONArrayStart) {
+		t.Errorf("object: have %v, want %v", err, errJSONArrayStart)
+	}
+}
+
+func TestDecodeJSONArrayErrors(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want error
+	}{
+		{`[1, 2, 3]`, 2, errJSONArrayLong},
+		{`[1, "x"]`, 1, errDecimalSyntax},
+		{`[1, 1.5]`, 1, errJSONNumberFraction},
+		{`[null]`, 0, errJSONArrayValue},
+		{`[[1]]`, 0, errJSONArrayValue},
+	}
+	for _, tc := range tests {
+		n, err := DecodeJSONArray(numberDecoder(tc.in), make([]Int, 2))
+		if n != tc.n || !errors.Is(err, tc.want) {
+			t.Errorf("%s: have %d %v, want %d %v", tc.in, n, err, tc.n, tc.want)
+		}
+	}
+	// Element errors name the element.
+	if _, err := DecodeJSONArray(numberDecoder(`[1, null]`), make([]Int, 2)); err == nil || !strings.HasPrefix(err.Error(), "uint256: element 1: ") {
+		t.Errorf("element error: have %v", err)
+	}
+	// Without UseNumber, numbers are rejected rather than rounded.
+	dec := json.NewDecoder(strings.NewReader(`[1]`))
+	if _, err := DecodeJSONArray(dec, make([]Int, 1)); !errors.Is(err, errJSONArrayFloat) {
+		t.Errorf("float64: have %v, want %v", err, errJSONArrayFloat)
+	}
+	if _, err := DecodeJSONArray(numberDecoder(`[1, 2`), make([]Int, 2)); err == nil {
+		t.Errorf("truncated array: no error")
+	}
+}
+
+func BenchmarkDecodeJSONArray(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`"0x` + strconv.FormatUint(uint64(i)*0x123456789, 16) + `"`)
+	}
+	sb.WriteByte(']')
+	data := sb.String()
+	dst := make([]Int, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DecodeJSONArray(numberDecoder(data), dst); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const expectJSONNumber = "a non-negative integral JSON number"
+
+var (
+	errJSONNumberSyntax   = errors.New("uint256: invalid JSON number")
+	errJSONNumberNegative = errors.New("uint256: negative JSON number")
+	errJSONNumberFraction = errors.New("uint256: JSON number is not an integer")
+)
+
+// jsonDigits returns the length of the run of decimal digits at the start of
+// s.
+func jsonDigits(s string) int {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// SetFromJSONNumber sets z from n, a JSON number literal as found by a
+// json.Decoder with UseNumber, or in a json.RawMessage, without going
+// through float64, so all values up to 2**256 - 1 are exact. Fractions and
+// exponents are accepted as long as the value is an integer, e.g. "1e18" or
+// "2.50e1". On error, z is left unchanged. Errors are of type *ParseError.
+func (z *Int) SetFromJSONNumber(n json.Number) error {
+	if z == nil {
+		panic(nilReceiver("SetFromJSONNumber"))
+	}
+	s := string(n)
+	fail := func(offset int, err error) error {
+		return newParseError(s, offset, expectJSONNumber, err)
+	}
+	if len(s) > 0 && s[0] == '-' {
+		return fail(0, errJSONNumberNegative)
+	}
+	// The grammar of RFC 8259: int [frac] [exp].
+	pos := jsonDigits(s)
+	switch {
+	case pos == 0:
+		return fail(0, errJSONNumberSyntax)
+	case pos > 1 && s[0] == '0':
+		return fail(1, errJSONNumberSyntax)
+	}
+	digits := s[:pos]
+	var frac string
+	if pos < len(s) && s[pos] == '.' {
+		n := jsonDigits(s[pos+1:])
+		if n == 0 {
+			return fail(pos+1, errJSONNumberSyntax)
+		}
+		frac = s[pos+1 : pos+1+n]
+		pos += 1 + n
+	}
+	exp := 0
+	if pos < len(s) && (s[pos] == 'e' || s[pos] == 'E') {
+		pos++
+		neg := false
+		if pos < len(s) && (s[pos] == '+' || s[pos] == '-') {
+			neg = s[pos] == '-'
+			pos++
+		}
+		n := jsonDigits(s[pos:])
+		if n == 0 {
+			return fail(pos, errJSONNumberSyntax)
+		}
+		// Saturate the exponent: any larger one overflows, or leaves a
+		// fraction, unless the significand is zero.
+		for _, c := range s[pos : pos+n] {
+			if exp < 2*len(s)+maxDecimalLen {
+				exp = exp*10 + int(c-'0')
+			}
+		}
+		if neg {
+			exp = -exp
+		}
+		pos += n
+	}
+	if pos != len(s) {
+		return fail(pos, errJSONNumberSyntax)
+	}
+	// The value is digits.frac * 10**exp, i.e. mant * 10**exp with the
+	// decimal point dropped.
+	mant := strings.TrimLeft(digits+frac, "0")
+	exp -= len(frac)
+	for exp < 0 && strings.HasSuffix(mant, "0") {
+		mant, exp = mant[:len(mant)-1], exp+1
+	}
+	switch {
+	case mant == "":
+		z.Clear()
+		return nil
+	case exp < 0:
+		return fail(-1, errJSONNumberFraction)
+	case len(mant)+exp > maxDecimalLen:
+		return fail(-1, errOverflow)
+	}
+	var res Int
+	if err := res.SetText(mant+strings.Repeat("0", exp), 10); err != nil {
+		return fail(-1, errOverflow)
+	}
+	z.Copy(&res)
+	return nil
+}
+
+// FromJSONNumber is a convenience-constructor for SetFromJSONNumber.
+func FromJSONNumber(n json.Number) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromJSONNumber(n); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// JSONNumber is an Int which is encoded in JSON as a bare number, such as
+// 115792089237316195423570985008687907853269984665640564039457584007913129639935,
+// for APIs which use numbers rather than strings for large integers. Since
+// most JSON decoders in other languages parse numbers as floating point,
+// which loses precision above 2**53, strings are preferable where there is a
+// choice. An *Int can be converted for use with encoding/json:
+//
+//	json.Unmarshal(data, (*uint256.JSONNumber)(x))
+type JSONNumber Int
+
+// MarshalJSON implements json.Marshaler, writing a bare decimal number.
+func (n *JSONNumber) MarshalJSON() ([]byte, error) {
+	return []byte((*Int)(n).Dec()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a number as for
+// SetFromJSONNumber, or, for interoperability, a string holding a decimal or
+// 0x-prefixed hex value. As usual for encoding/json, null leaves the value
+// unchanged.
+func (n *JSONNumber) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	switch {
+	case s == "null":
+		return nil
+	case len(s) > 0 && s[0] == '"':
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		return (*Int)(n).setFromDecimalOrHex(str)
+	}
+	return (*Int)(n).SetFromJSONNumber(json.Number(s))
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const maxUint256Dec = "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+
+func TestSetFromJSONNumber(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // Decimal, or empty if an error is expected.
+		err  error
+	}{
+		{"0", "0", nil},
+		{"123", "123", nil},
+		{maxUint256Dec, maxUint256Dec, nil},
+		{"1e18", "1000000000000000000", nil},
+		{"1E+2", "100", nil},
+		{"2.50e1", "25", nil},
+		{"100e-2", "1", nil},
+		{"10.000", "10", nil},
+		{"0.0e999999999999999999999", "0", nil},
+		{"0e-5", "0", nil},
+		{"1.15792089237316195423570985008687907853269984665640564039457584007913129639935e77", maxUint256Dec, nil},
+		{"115792089237316195423570985008687907853269984665640564039457584007913129639936", "", errOverflow},
+		{"1e78", "", errOverflow},
+		{"1e999999999999999999999", "", errOverflow},
+		{"1.5", "", errJSONNumberFraction},
+		{"1e-1", "", errJSONNumberFraction},
+		{"-1", "", errJSONNumberNegative},
+		{"-0", "", errJSONNumberNegative},
+		{"", "", errJSONNumberSyntax},
+		{"01", "", errJSONNumberSyntax},
+		{"1.", "", errJSONNumberSyntax},
+		{".5", "", errJSONNumberSyntax},
+		{"1e", "", errJSONNumberSyntax},
+		{"1e+", "", errJSONNumberSyntax},
+		{"0x10", "", errJSONNumberSyntax},
+		{"1 ", "", errJSONNumberSyntax},
+	}
+	for _, tc := range tests {
+		z := NewInt().SetUint64(7)
+		err := z.SetFromJSONNumber(json.Number(tc.in))
+		if tc.err != nil {
+			var pe *ParseError
+			if !errors.Is(err, tc.err) || !errors.As(err, &pe) {
+				t.Errorf("%q: have error %v, want %v", tc.in, err, tc.err)
+			}
+			if z.Uint64() != 7 {
+				t.Errorf("%q: z changed on error", tc.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %v", tc.in, err)
+		} else if z.Dec() != tc.want {
+			t.Errorf("%q: have %s, want %s", tc.in, z.Dec(), tc.want)
+		}
+	}
+}
+
+func TestJSONNumberDecoder(t *testing.T) {
+	// A json.Decoder with UseNumber keeps the literal, which a float64
+	// would round.
+	dec := json.NewDecoder(strings.NewReader(`{"value": ` + maxUint256Dec + `}`))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	z, err := FromJSONNumber(m["value"].(json.Number))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !z.Eq(new(Int).SetAllOne()) {
+		t.Errorf("have %v, want 2**256 - 1", z)
+	}
+}
+
+func TestJSONNumber(t *testing.T) {
+	type payload struct {
+		Amount *JSONNumber `json:"amount"`
+		Fee    JSONNumber  `json:"fee"`
+	}
+	var p payload
+	in := `{"amount": ` + maxUint256Dec + `, "fee": "0x10"}`
+	if err := json.Unmarshal([]byte(in), &p); err != nil {
+		t.Fatal(err)
+	}
+	if !(*Int)(p.Amount).Eq(new(Int).SetAllOne()) || (*Int)(&p.Fee).Uint64() != 16 {
+		t.Errorf("have %v %v", (*Int)(p.Amount), (*Int)(&p.Fee))
+	}
+	out, err := json.Marshal(&p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"amount":` + maxUint256Dec + `,"fee":16}`; string(out) != want {
+		t.Errorf("Marshal: have %s, want %s", out, want)
+	}
+	if err := json.Unmarshal([]byte(`{"amount": 1.5}`), &p); !errors.Is(err, errJSONNumberFraction) {
+		t.Errorf("have %v, want %v", err, errJSONNumberFraction)
+	}
+	// null leaves the value unchanged.
+	p.Fee = JSONNumber{5}
+	if err := json.Unmarshal([]byte(`{"fee": null}`), &p); err != nil || p.Fee[0] != 5 {
+		t.Errorf("null: have %v %v", (*Int)(&p.Fee), err)
+	}
+}
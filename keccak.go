@@ -0,0 +1,124 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sync/atomic"
+)
+
+// keccakBox wraps the registered Keccak-256 function, since an atomic.Value
+// cannot hold nil.
+type keccakBox struct{ f func(data []byte) [32]byte }
+
+var registeredKeccak atomic.Value
+
+// SetKeccak256 registers f as the Keccak-256 implementation used by Keccak256
+// and ToChecksumAddress, e.g. a faster one based on
+// golang.org/x/crypto/sha3.NewLegacyKeccak256, or restores the built-in one if
+// f is nil, and returns the previously registered function, or nil for the
+// built-in one. It is safe for concurrent use.
+func SetKeccak256(f func(data []byte) [32]byte) func(data []byte) [32]byte {
+	old, _ := registeredKeccak.Load().(keccakBox)
+	registeredKeccak.Store(keccakBox{f})
+	return old.f
+}
+
+// Keccak256 returns the legacy Keccak-256 hash of data, as used by Ethereum.
+// It uses the function registered with SetKeccak256, if any, or else a small,
+// portable implementation.
+func Keccak256(data []byte) [32]byte {
+	if box, _ := registeredKeccak.Load().(keccakBox); box.f != nil {
+		return box.f(data)
+	}
+	return keccak256(data)
+}
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations holds the ρ step rotation offsets, indexed as [x+5*y].
+var keccakRotations = [25]int{
+	0, 1, 62, 28, 27,
+	36, 44, 6, 55, 20,
+	3, 10, 43, 25, 39,
+	41, 45, 15, 21, 8,
+	18, 2, 61, 56, 14,
+}
+
+// keccakF1600 applies the Keccak-f[1600] permutation to the state a, which is
+// indexed as a[x+5*y].
+func keccakF1600(a *[25]uint64) {
+	var c, d [5]uint64
+	var b [25]uint64
+	for round := 0; round < 24; round++ {
+		// θ step
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d[x] = c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+		}
+		for i := range a {
+			a[i] ^= d[i%5]
+		}
+		// ρ and π steps
+		for x := 0; x < 5; x++ {
+			for y := 0; y < 5; y++ {
+				b[y+5*((2*x+3*y)%5)] = bits.RotateLeft64(a[x+5*y], keccakRotations[x+5*y])
+			}
+		}
+		// χ step
+		for y := 0; y < 25; y += 5 {
+			for x := 0; x < 5; x++ {
+				a[y+x] = b[y+x] ^ (^b[y+(x+1)%5] & b[y+(x+2)%5])
+			}
+		}
+		// ι step
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// keccak256 computes the legacy Keccak-256 hash (with the original 0x01
+// padding, not the SHA3 0x06 padding) of data.
+func keccak256(data []byte) [32]byte {
+	return keccakSponge256(data, 0x01)
+}
+
+// keccakSponge256 absorbs data into a Keccak sponge with a 1088-bit rate,
+// using the given domain separation byte, and squeezes out 32 bytes.
+func keccakSponge256(data []byte, domain byte) [32]byte {
+	const rate = 136
+	var a [25]uint64
+	for len(data) >= rate {
+		for i := 0; i < rate/8; i++ {
+			a[i] ^= binary.LittleEndian.Uint64(data[8*i:])
+		}
+		keccakF1600(&a)
+		data = data[rate:]
+	}
+	var block [rate]byte
+	copy(block[:], data)
+	block[len(data)] ^= domain
+	block[rate-1] ^= 0x80
+	for i := 0; i < rate/8; i++ {
+		a[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+	keccakF1600(&a)
+
+	var out [32]byte
+	for i := 0; i < 4; i++ {
+		binary.LittleEndian.PutUint64(out[8*i:], a[i])
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// Key is the value of an Int in a form meant for map keys. Like Int, it is
+// comparable, with equal values giving equal Keys, but it has no arithmetic
+// methods that could mutate it in place by mistake. Its layout, the four
+// 64-bit words of the value, least significant first, is stable, so Keys
+// may be persisted and compared across versions.
+type Key [4]uint64
+
+// Key returns z as a Key.
+func (z *Int) Key() Key {
+	z = orZero(z)
+	return Key(*z)
+}
+
+// FromInt sets k to the value of x, and returns k.
+func (k *Key) FromInt(x *Int) *Key {
+	*k = Key(*x)
+	return k
+}
+
+// ToInt returns the value of k as a new Int.
+func (k Key) ToInt() *Int {
+	z := Int(k)
+	return &z
+}
+
+// String returns the value of k in decimal.
+func (k Key) String() string {
+	return k.ToInt().Dec()
+}
+
+// IntMap maps Ints to Ints, such as account indexes to balances, using
+// Keys internally. The zero value is an empty IntMap. It is not safe for
+// concurrent modification.
+type IntMap struct {
+	m map[Key]Int
+}
+
+// Get returns the value of k, and whether it is present.
+func (m *IntMap) Get(k *Int) (Int, bool) {
+	v, ok := m.m[Key(*k)]
+	return v, ok
+}
+
+// Put sets the value of k to v.
+func (m *IntMap) Put(k, v *Int) {
+	if m.m == nil {
+		m.m = make(map[Key]Int)
+	}
+	m.m[Key(*k)] = *v
+}
+
+// Delete removes k.
+func (m *IntMap) Delete(k *Int) {
+	delete(m.m, Key(*k))
+}
+
+// Len returns the number of keys present.
+func (m *IntMap) Len() int {
+	return len(m.m)
+}
+
+// Range calls f for each key and value, in unspecified order, until f
+// returns false.
+func (m *IntMap) Range(f func(k, v *Int) bool) {
+	for key, v := range m.m {
+		k, v := Int(key), v
+		if !f(&k, &v) {
+			return
+		}
+	}
+}
+
+// KeySet is a set of Ints, using Keys internally. The zero value is an
+// empty KeySet. It is not safe for concurrent modification.
+type KeySet struct {
+	m map[Key]struct{}
+}
+
+// Add adds x to the set.
+func (s *KeySet) Add(x *Int) {
+	if s.m == nil {
+		s.m = make(map[Key]struct{})
+	}
+	s.m[Key(*x)] = struct{}{}
+}
+
+// Has reports whether x is in the set.
+func (s *KeySet) Has(x *Int) bool {
+	_, ok := s.m[Key(*x)]
+	return ok
+}
+
+// Remove removes x from the set.
+func (s *KeySet) Remove(x *Int) {
+	delete(s.m, Key(*x))
+}
+
+// Len returns the number of elements of the set.
+func (s *KeySet) Len() int {
+	return len(s.m)
+}
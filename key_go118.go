@@ -0,0 +1,52 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+// Map maps Ints to values of type V, using Keys internally. It is only
+// available with Go 1.18 and later; IntMap covers Int values on older
+// versions. The zero value is an empty Map. It is not safe for concurrent
+// modification.
+type Map[V any] struct {
+	m map[Key]V
+}
+
+// Get returns the value of k, and whether it is present.
+func (m *Map[V]) Get(k *Int) (V, bool) {
+	v, ok := m.m[Key(*k)]
+	return v, ok
+}
+
+// Put sets the value of k to v.
+func (m *Map[V]) Put(k *Int, v V) {
+	if m.m == nil {
+		m.m = make(map[Key]V)
+	}
+	m.m[Key(*k)] = v
+}
+
+// Delete removes k.
+func (m *Map[V]) Delete(k *Int) {
+	delete(m.m, Key(*k))
+}
+
+// Len returns the number of keys present.
+func (m *Map[V]) Len() int {
+	return len(m.m)
+}
+
+// Range calls f for each key and value, in unspecified order, until f
+// returns false.
+func (m *Map[V]) Range(f func(k *Int, v V) bool) {
+	for key, v := range m.m {
+		k := Int(key)
+		if !f(&k, v) {
+			return
+		}
+	}
+}
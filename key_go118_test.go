@@ -0,0 +1,35 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+import "testing"
+
+func TestMap(t *testing.T) {
+	var m Map[string]
+	m.Put(NewInt().SetUint64(1), "one")
+	m.Put(new(Int).SetAllOne(), "max")
+	if v, ok := m.Get(NewInt().SetOne()); !ok || v != "one" {
+		t.Errorf("Get: have %q %v, want one", v, ok)
+	}
+	if v, ok := m.Get(new(Int)); ok || v != "" {
+		t.Errorf("Get of missing key: have %q %v", v, ok)
+	}
+	n := 0
+	m.Range(func(k *Int, v string) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Errorf("Range continued after false")
+	}
+	m.Delete(NewInt().SetOne())
+	if m.Len() != 1 {
+		t.Errorf("Len: have %d, want 1", m.Len())
+	}
+}
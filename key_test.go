@@ -0,0 +1,74 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestKey(t *testing.T) {
+	x := &Int{1, 2, 3, 4}
+	k := x.Key()
+	var k2 Key
+	if *k2.FromInt(x.Clone()) != k {
+		t.Errorf("equal values give different keys")
+	}
+	if k != (Key{1, 2, 3, 4}) {
+		t.Errorf("layout changed: %v", [4]uint64(k))
+	}
+	if y := k.ToInt(); !y.Eq(x) {
+		t.Errorf("ToInt: have %v, want %v", y, x)
+	}
+	// ToInt returns a copy.
+	k.ToInt().SetOne()
+	if k != x.Key() {
+		t.Errorf("ToInt result aliases the key")
+	}
+	if have, want := NewInt().SetUint64(12345).Key().String(), "12345"; have != want {
+		t.Errorf("String: have %s, want %s", have, want)
+	}
+	m := map[Key]int{x.Key(): 1}
+	if m[x.Clone().Key()] != 1 {
+		t.Errorf("map lookup failed")
+	}
+}
+
+func TestIntMap(t *testing.T) {
+	var m IntMap
+	if _, ok := m.Get(new(Int)); ok {
+		t.Errorf("Get on empty map: ok")
+	}
+	k := new(Int).SetAllOne()
+	m.Put(k, NewInt().SetUint64(5))
+	m.Put(new(Int), NewInt().SetUint64(6))
+	if v, ok := m.Get(k.Clone()); !ok || v.Uint64() != 5 {
+		t.Errorf("Get: have %v %v, want 5", &v, ok)
+	}
+	sum := new(Int)
+	m.Range(func(_, v *Int) bool {
+		sum.Add(sum, v)
+		return true
+	})
+	if sum.Uint64() != 11 {
+		t.Errorf("Range: sum %v, want 11", sum)
+	}
+	m.Delete(k)
+	if m.Len() != 1 {
+		t.Errorf("Len: have %d, want 1", m.Len())
+	}
+}
+
+func TestKeySet(t *testing.T) {
+	var s KeySet
+	x := NewInt().SetUint64(42)
+	s.Add(x)
+	s.Add(x.Clone())
+	if s.Len() != 1 || !s.Has(NewInt().SetUint64(42)) || s.Has(new(Int)) {
+		t.Errorf("unexpected set contents")
+	}
+	s.Remove(x)
+	if s.Len() != 0 {
+		t.Errorf("Len after Remove: %d", s.Len())
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"unicode"
+)
+
+var errSeparator = errors.New("uint256: misplaced digit separator")
+
+// digitValue returns the value of the decimal digit r, of any script, or -1
+// if r is not a decimal digit. Unicode encodes decimal digits in contiguous
+// runs of whole 0-9 sequences, so the value is the offset from the start of
+// the run, modulo 10.
+func digitValue(r rune) int {
+	if r >= '0' && r <= '9' {
+		return int(r - '0')
+	}
+	if !unicode.IsDigit(r) {
+		return -1
+	}
+	start := r
+	for unicode.IsDigit(start - 1) {
+		start--
+	}
+	return int(r-start) % 10
+}
+
+// SetFromLocalized sets z from the decimal string s as exported by
+// spreadsheets and other locale-aware tools: surrounding white space is
+// ignored, digits may be of any script (e.g. Arabic-Indic or full-width
+// digits), leading zeros are allowed, and sep may appear between digits as a
+// grouping separator, in any positions (so both "1,234,567" and Indian
+// "12,34,567" are accepted) but not at either end nor twice in a row. A sep
+// of 0 disallows separators. On error, z is left unchanged. Errors are of
+// type *ParseError. The policy is LocalizedOptions, with the given
+// Separator.
+func (z *Int) SetFromLocalized(s string, sep rune) error {
+	if z == nil {
+		panic(nilReceiver("SetFromLocalized"))
+	}
+	opts := localizedOptions
+	opts.Separator = sep
+	return z.parse(s, &opts)
+}
+
+// ParseLocalized is a convenience-constructor for SetFromLocalized.
+func ParseLocalized(s string, sep rune) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromLocalized(s, sep); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
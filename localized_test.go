@@ -0,0 +1,65 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseLocalized(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		sep rune
+		exp string
+		err error
+	}{
+		{"1,234,567", ',', "1234567", nil},
+		{"12,34,567", ',', "1234567", nil},
+		{"1.234.567", '.', "1234567", nil},
+		{"1\u202f234\u202f567", '\u202f', "1234567", nil}, // narrow no-break space
+		{"  42\t", ',', "42", nil},
+		{"007", 0, "7", nil},
+		{"0,000", ',', "0", nil},
+		{"١٬٢٣٤", '٬', "1234", nil},            // Arabic-Indic digits and separator
+		{"１２３", ',', "123", nil},               // full-width digits
+		{"४२", 0, "42", nil},                   // Devanagari digits
+		{"\U0001d7d9\U0001d7f0", 0, "14", nil}, // mathematical double-struck and sans-serif bold digits
+		{"115,792,089,237,316,195,423,570,985,008,687,907,853,269,984,665,640,564,039,457,584,007,913,129,639,935", ',',
+			"115792089237316195423570985008687907853269984665640564039457584007913129639935", nil},
+		{"115,792,089,237,316,195,423,570,985,008,687,907,853,269,984,665,640,564,039,457,584,007,913,129,639,936", ',',
+			"", errOverflow},
+		{"", ',', "", errEmptyString},
+		{"   ", ',', "", errEmptyString},
+		{",1", ',', "", errSeparator},
+		{"1,", ',', "", errSeparator},
+		{"1,,2", ',', "", errSeparator},
+		{",", ',', "", errSeparator},
+		{"1,234", 0, "", errDecimalSyntax},
+		{"1.5", ',', "", errDecimalSyntax},
+		{"-1", ',', "", errDecimalSyntax},
+		{"1 234", ',', "", errDecimalSyntax},
+	} {
+		z := new(Int).SetUint64(42)
+		err := z.SetFromLocalized(tc.s, tc.sep)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%q: got err %v, exp %v", tc.s, err, tc.err)
+			continue
+		}
+		if err != nil {
+			if z.Uint64() != 42 {
+				t.Errorf("%q: modified z on error", tc.s)
+			}
+			continue
+		}
+		if z.Dec() != tc.exp {
+			t.Errorf("%q: got %s, exp %s", tc.s, z.Dec(), tc.exp)
+		}
+	}
+	if z, err := ParseLocalized("1,000", ','); err != nil || z.Uint64() != 1000 {
+		t.Errorf("ParseLocalized: got %v, %v", z, err)
+	}
+}
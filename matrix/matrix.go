@@ -0,0 +1,239 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package matrix implements small dense matrices over a prime field with
+// uint256.Int entries, for the linear-algebra steps of secret sharing and
+// erasure coding. All entries are expected to be reduced modulo the prime.
+package matrix
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	errDimensions = errors.New("matrix: dimension mismatch")
+	errSingular   = errors.New("matrix: matrix is singular")
+)
+
+// Matrix is a dense rows x cols matrix, stored in row-major order.
+type Matrix struct {
+	rows, cols int
+	data       []uint256.Int
+}
+
+// New returns a zero matrix with the given dimensions.
+func New(rows, cols int) *Matrix {
+	if rows < 0 || cols < 0 {
+		panic("matrix: negative dimension")
+	}
+	return &Matrix{rows: rows, cols: cols, data: make([]uint256.Int, rows*cols)}
+}
+
+// Identity returns the n x n identity matrix.
+func Identity(n int) *Matrix {
+	a := New(n, n)
+	for i := 0; i < n; i++ {
+		a.At(i, i).SetOne()
+	}
+	return a
+}
+
+// Vandermonde returns the len(xs) x cols matrix with entries xs[i]**j mod p,
+// as used to encode Reed-Solomon codes and secret shares.
+func Vandermonde(xs []uint256.Int, cols int, p *uint256.Int) *Matrix {
+	a := New(len(xs), cols)
+	for i := range xs {
+		row := a.Row(i)
+		for j := range row {
+			if j == 0 {
+				row[j].SetOne()
+			} else {
+				row[j].MulMod(&row[j-1], &xs[i], p)
+			}
+		}
+	}
+	return a
+}
+
+// Rows returns the number of rows of a.
+func (a *Matrix) Rows() int { return a.rows }
+
+// Cols returns the number of columns of a.
+func (a *Matrix) Cols() int { return a.cols }
+
+// At returns a pointer to the entry at row i and column j, which can be used
+// to read or modify it.
+func (a *Matrix) At(i, j int) *uint256.Int {
+	if i < 0 || i >= a.rows || j < 0 || j >= a.cols {
+		panic("matrix: index out of range")
+	}
+	return &a.data[i*a.cols+j]
+}
+
+// Row returns row i of a, sharing the storage of a.
+func (a *Matrix) Row(i int) []uint256.Int {
+	return a.data[i*a.cols : (i+1)*a.cols]
+}
+
+// Clone returns a deep copy of a.
+func (a *Matrix) Clone() *Matrix {
+	b := &Matrix{rows: a.rows, cols: a.cols, data: make([]uint256.Int, len(a.data))}
+	copy(b.data, a.data)
+	return b
+}
+
+// Equal reports whether a and b have the same dimensions and entries.
+func (a *Matrix) Equal(b *Matrix) bool {
+	if a.rows != b.rows || a.cols != b.cols {
+		return false
+	}
+	for i := range a.data {
+		if !a.data[i].Eq(&b.data[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// dot returns the inner product of x and y modulo p.
+func dot(x, y []uint256.Int, p *uint256.Int) uint256.Int {
+	var acc, t uint256.Int
+	for i := range x {
+		t.MulMod(&x[i], &y[i], p)
+		acc.AddMod(&acc, &t, p)
+	}
+	return acc
+}
+
+// MulVec returns the matrix-vector product a * v modulo p.
+func (a *Matrix) MulVec(v []uint256.Int, p *uint256.Int) ([]uint256.Int, error) {
+	if len(v) != a.cols {
+		return nil, errDimensions
+	}
+	out := make([]uint256.Int, a.rows)
+	for i := range out {
+		out[i] = dot(a.Row(i), v, p)
+	}
+	return out, nil
+}
+
+// Mul returns the matrix product a * b modulo p.
+func Mul(a, b *Matrix, p *uint256.Int) (*Matrix, error) {
+	if a.cols != b.rows {
+		return nil, errDimensions
+	}
+	c := New(a.rows, b.cols)
+	var t uint256.Int
+	for i := 0; i < a.rows; i++ {
+		out := c.Row(i)
+		for k, x := range a.Row(i) {
+			if x.IsZero() {
+				continue
+			}
+			// Accumulate x * row k of b, streaming through both rows.
+			for j, y := range b.Row(k) {
+				t.MulMod(&x, &y, p)
+				out[j].AddMod(&out[j], &t, p)
+			}
+		}
+	}
+	return c, nil
+}
+
+// reduce brings a into reduced row echelon form modulo p, using Gauss-Jordan
+// elimination on the first n columns, and returns the number of pivots.
+func (a *Matrix) reduce(n int, p *uint256.Int) int {
+	var inv, t uint256.Int
+	rank := 0
+	for col := 0; col < n && rank < a.rows; col++ {
+		pivot := -1
+		for i := rank; i < a.rows; i++ {
+			if !a.At(i, col).IsZero() {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		if pivot != rank {
+			pr, rr := a.Row(pivot), a.Row(rank)
+			for j := range pr {
+				pr[j], rr[j] = rr[j], pr[j]
+			}
+		}
+		row := a.Row(rank)
+		if _, ok := inv.ModInverse(&row[col], p); !ok {
+			// Only possible if p is not prime.
+			continue
+		}
+		for j := range row {
+			row[j].MulMod(&row[j], &inv, p)
+		}
+		for i := 0; i < a.rows; i++ {
+			if i == rank || a.At(i, col).IsZero() {
+				continue
+			}
+			other := a.Row(i)
+			f := other[col]
+			for j := range other {
+				t.MulMod(&f, &row[j], p)
+				other[j].SubMod(&other[j], &t, p)
+			}
+		}
+		rank++
+	}
+	return rank
+}
+
+// Rank returns the rank of a modulo the prime p.
+func (a *Matrix) Rank(p *uint256.Int) int {
+	return a.Clone().reduce(a.cols, p)
+}
+
+// Solve returns the solution x of the square system a * x = b modulo the
+// prime p, using Gaussian elimination.
+func Solve(a *Matrix, b []uint256.Int, p *uint256.Int) ([]uint256.Int, error) {
+	if a.rows != a.cols || len(b) != a.rows {
+		return nil, errDimensions
+	}
+	n := a.rows
+	aug := New(n, n+1)
+	for i := 0; i < n; i++ {
+		copy(aug.Row(i), a.Row(i))
+		aug.At(i, n).Copy(&b[i])
+	}
+	if aug.reduce(n, p) < n {
+		return nil, errSingular
+	}
+	x := make([]uint256.Int, n)
+	for i := range x {
+		x[i] = *aug.At(i, n)
+	}
+	return x, nil
+}
+
+// Inverse returns the inverse of the square matrix a modulo the prime p.
+func (a *Matrix) Inverse(p *uint256.Int) (*Matrix, error) {
+	if a.rows != a.cols {
+		return nil, errDimensions
+	}
+	n := a.rows
+	aug := New(n, 2*n)
+	for i := 0; i < n; i++ {
+		copy(aug.Row(i), a.Row(i))
+		aug.At(i, n+i).SetOne()
+	}
+	if aug.reduce(n, p) < n {
+		return nil, errSingular
+	}
+	inv := New(n, n)
+	for i := 0; i < n; i++ {
+		copy(inv.Row(i), aug.Row(i)[n:])
+	}
+	return inv, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+var prime = uint256.BN254Scalar.Modulus()
+
+func randMatrix(rng *rand.Rand, rows, cols int, p *uint256.Int) *Matrix {
+	a := New(rows, cols)
+	for i := range a.data {
+		a.data[i] = uint256.Int{rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64()}
+		a.data[i].Mod(&a.data[i], p)
+	}
+	return a
+}
+
+func TestMulVec(t *testing.T) {
+	// [1 2; 3 4] * [5, 6] = [17, 39], and mod 7 = [3, 4].
+	a := New(2, 2)
+	for i, v := range []uint64{1, 2, 3, 4} {
+		a.data[i].SetUint64(v)
+	}
+	got, err := a.MulVec([]uint256.Int{{5}, {6}}, new(uint256.Int).SetUint64(7))
+	if err != nil || got[0][0] != 3 || got[1][0] != 4 {
+		t.Errorf("got %v (err %v)", got, err)
+	}
+	if _, err := a.MulVec([]uint256.Int{{5}}, prime); err != errDimensions {
+		t.Errorf("expected errDimensions, got %v", err)
+	}
+}
+
+func TestSolveAndInverse(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 1; n <= 8; n++ {
+		a := randMatrix(rng, n, n, prime)
+		x := randMatrix(rng, 1, n, prime).Row(0)
+		b, err := a.MulVec(x, prime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Solve(a, b, prime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range x {
+			if !got[i].Eq(&x[i]) {
+				t.Fatalf("n=%d: solution mismatch at %d", n, i)
+			}
+		}
+		inv, err := a.Inverse(prime)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if prod, _ := Mul(a, inv, prime); !prod.Equal(Identity(n)) {
+			t.Fatalf("n=%d: a * a^-1 is not the identity", n)
+		}
+		if r := a.Rank(prime); r != n {
+			t.Fatalf("n=%d: rank %d", n, r)
+		}
+	}
+}
+
+func TestSingular(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	a := randMatrix(rng, 4, 4, prime)
+	// Make row 3 = row 0 + row 1.
+	for j := 0; j < 4; j++ {
+		a.At(3, j).AddMod(a.At(0, j), a.At(1, j), prime)
+	}
+	if r := a.Rank(prime); r != 3 {
+		t.Errorf("rank: got %d, exp 3", r)
+	}
+	if _, err := Solve(a, make([]uint256.Int, 4), prime); err != errSingular {
+		t.Errorf("expected errSingular, got %v", err)
+	}
+	if _, err := a.Inverse(prime); err != errSingular {
+		t.Errorf("expected errSingular, got %v", err)
+	}
+	if _, err := New(2, 3).Inverse(prime); err != errDimensions {
+		t.Errorf("expected errDimensions, got %v", err)
+	}
+}
+
+func TestVandermonde(t *testing.T) {
+	// Any k rows of a k-column Vandermonde matrix with distinct points are
+	// invertible, which is what makes erasure decoding possible.
+	xs := []uint256.Int{{1}, {2}, {3}, {4}, {5}}
+	v := Vandermonde(xs, 3, prime)
+	if v.Rows() != 5 || v.Cols() != 3 || v.At(4, 2).Uint64() != 25 {
+		t.Fatalf("unexpected matrix: %v", v.data)
+	}
+	sub := New(3, 3)
+	for i, r := range []int{0, 2, 4} {
+		copy(sub.Row(i), v.Row(r))
+	}
+	if _, err := sub.Inverse(prime); err != nil {
+		t.Errorf("sub-matrix not invertible: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "math/bits"
+
+// SubMod sets z to the difference (x - y) mod m, and returns z.
+// If m == 0, z is set to 0.
+func (z *Int) SubMod(x, y, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	var a, b Int
+	a.Mod(x, m)
+	b.Mod(y, m)
+	if a.Lt(&b) {
+		// a - b + m, where m - b > 0 does not overflow.
+		b.Sub(m, &b)
+		return z.Add(&a, &b)
+	}
+	return z.Sub(&a, &b)
+}
+
+// ExpMod sets z to base**exponent mod m, and returns z.
+// If m == 0, z is set to 0 (OBS: differs from the big.Int).
+func (z *Int) ExpMod(base, exponent, m *Int) *Int {
+	if m.IsZero() {
+		return z.Clear()
+	}
+	var (
+		mod = *m // z may alias m
+		exp = *exponent
+		res = Int{1}
+		b   Int
+	)
+	b.Mod(base, &mod)
+	for n := exp.BitLen() - 1; n >= 0; n-- {
+		res.MulMod(&res, &res, &mod)
+		if exp.isBitSet(uint(n)) {
+			res.MulMod(&res, &b, &mod)
+		}
+	}
+	return z.Mod(&res, &mod)
+}
+
+// ModInverse sets z to the multiplicative inverse of x modulo m, and returns
+// z and true. If x and m are not relatively prime, or m is 0 or 1, no inverse
+// exists, and z is left unchanged and false is returned.
+func (z *Int) ModInverse(x, m *Int) (*Int, bool) {
+	if m.IsZero() || m.IsOne() {
+		return z, false
+	}
+	// Extended Euclidean algorithm. The Bézout coefficients alternate in
+	// sign, so only their magnitudes are tracked: t1 is positive on odd
+	// iterations.
+	var (
+		r0, r1 Int
+		t0, t1 = Int{}, Int{1}
+		q, tmp Int
+		odd    = true
+	)
+	r0.Copy(m)
+	r1.Mod(x, m)
+	for !r1.IsZero() {
+		q.Div(&r0, &r1)
+		tmp.Mul(&q, &r1)
+		r0, r1 = r1, *tmp.Sub(&r0, &tmp)
+		tmp.Mul(&q, &t1)
+		t0, t1 = t1, *tmp.Add(&t0, &tmp)
+		odd = !odd
+	}
+	if !r0.IsOne() {
+		return z, false
+	}
+	// t0 holds the magnitude of the coefficient of x; it is negative if the
+	// loop ended on an odd iteration.
+	if odd {
+		return z.Sub(m, &t0), true
+	}
+	return z.Copy(&t0), true
+}
+
+// TrailingZeroBits returns the number of consecutive least significant zero
+// bits of z. If z is 0, the result is 256.
+func (z *Int) TrailingZeroBits() uint {
+	z = orZero(z)
+	for i, w := range z {
+		if w != 0 {
+			return uint(i*64 + bits.TrailingZeros64(w))
+		}
+	}
+	return 256
+}
+
+// OddPart writes x as 2**k * m with m odd, sets z to m and returns z and k.
+// If x is 0, z is set to 0 and k is 256.
+func (z *Int) OddPart(x *Int) (*Int, uint) {
+	k := x.TrailingZeroBits()
+	return z.Rsh(x, k), k
+}
+
+// MultiplicativeOrder sets z to the multiplicative order of x modulo the prime
+// m, i.e. the smallest k > 0 such that x**k = 1 mod m, and returns z and true.
+// The factors must hold the distinct prime factors of m-1 (duplicates are
+// allowed). If x = 0 mod m, or the factors do not fully factorize m-1, z is
+// left unchanged and false is returned.
+func (z *Int) MultiplicativeOrder(x, m *Int, factors []Int) (*Int, bool) {
+	if m.LtUint64(2) {
+		return z, false
+	}
+	var n, rest, q, r, t, base Int
+	base.Mod(x, m)
+	if base.IsZero() {
+		return z, false
+	}
+	n.SubOne(m)
+	rest.Copy(&n)
+	for i := range factors {
+		p := &factors[i]
+		if p.LtUint64(2) {
+			return z, false
+		}
+		for {
+			q.Div(&rest, p)
+			r.Mod(&rest, p)
+			if !r.IsZero() {
+				break
+			}
+			rest.Copy(&q)
+		}
+	}
+	if !rest.IsOne() {
+		return z, false
+	}
+	if !t.ExpMod(&base, &n, m).IsOne() {
+		return z, false // m is not prime
+	}
+	// Strip each prime factor from the order as long as x**(order/p) = 1.
+	for i := range factors {
+		p := &factors[i]
+		for {
+			q.Div(&n, p)
+			r.Mod(&n, p)
+			if !r.IsZero() || !t.ExpMod(&base, &q, m).IsOne() {
+				break
+			}
+			n.Copy(&q)
+		}
+	}
+	return z.Copy(&n), true
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRandomSubMod(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		b1, f1, _ := randNums()
+		b2, f2, _ := randNums()
+		b3, f3, _ := randNums()
+		z := new(Int).SubMod(f1, f2, f3)
+		if b3.Sign() == 0 {
+			if !z.IsZero() {
+				t.Fatalf("expected zero for zero modulus")
+			}
+			continue
+		}
+		exp := new(big.Int).Sub(b1, b2)
+		requireEq(t, exp.Mod(exp, b3), z, "submod")
+	}
+}
+
+func TestRandomExpMod(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		b1, f1, _ := randNums()
+		b2, f2, _ := randNums()
+		b3, f3, _ := randNums()
+		z := new(Int).ExpMod(f1, f2, f3)
+		if b3.Sign() == 0 {
+			if !z.IsZero() {
+				t.Fatalf("expected zero for zero modulus")
+			}
+			continue
+		}
+		requireEq(t, new(big.Int).Exp(b1, b2, b3), z, "expmod")
+		// Receiver aliasing the modulus.
+		if f3.ExpMod(f1, f2, f3); !f3.Eq(z) {
+			t.Fatalf("aliased: got %v exp %v", f3.Hex(), z.Hex())
+		}
+	}
+}
+
+func TestRandomModInverse(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		b1, f1, _ := randNums()
+		b2, f2, _ := randNums()
+		z, ok := new(Int).ModInverse(f1, f2)
+		exp := new(big.Int)
+		if b2.Sign() == 0 || b2.Cmp(big.NewInt(1)) == 0 {
+			exp = nil
+		} else {
+			exp = exp.ModInverse(b1, b2)
+		}
+		if ok != (exp != nil) {
+			t.Fatalf("inverse of %v mod %v: ok=%v, exp %v", f1.Hex(), f2.Hex(), ok, exp)
+		}
+		if ok {
+			requireEq(t, exp, z, "modinverse")
+		}
+	}
+	// Inverses modulo the maximum value and a large prime.
+	p := new(Int).SetBytes(hex2Bytes("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff43"))
+	for i := 0; i < 1000; i++ {
+		b, f, _ := randHighNums()
+		for _, m := range []*Int{p, new(Int).SetAllOne()} {
+			z, ok := new(Int).ModInverse(f, m)
+			exp := new(big.Int).ModInverse(b, m.ToBig())
+			if ok != (exp != nil) {
+				t.Fatalf("inverse of %v mod %v: ok=%v, exp %v", f.Hex(), m.Hex(), ok, exp)
+			}
+			if ok {
+				requireEq(t, exp, z, "modinverse")
+			}
+		}
+	}
+}
+
+func TestTrailingZeroBits(t *testing.T) {
+	if n := new(Int).TrailingZeroBits(); n != 256 {
+		t.Errorf("zero: got %d, exp 256", n)
+	}
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randHighNums()
+		if x.IsZero() {
+			continue
+		}
+		exp := b.TrailingZeroBits()
+		if got := x.TrailingZeroBits(); got != exp {
+			t.Fatalf("%v: got %d, exp %d", x.Hex(), got, exp)
+		}
+		var m Int
+		if _, k := m.OddPart(x); k != exp || m[0]&1 != 1 || !new(Int).Lsh(&m, k).Eq(x) {
+			t.Fatalf("OddPart(%v): got %v, k=%d", x.Hex(), m.Hex(), k)
+		}
+	}
+	for n := uint(0); n < 256; n++ {
+		x := new(Int).Lsh(new(Int).SetAllOne(), n)
+		if got := x.TrailingZeroBits(); got != n {
+			t.Fatalf("bit %d: got %d", n, got)
+		}
+	}
+}
+
+func TestMultiplicativeOrderSmall(t *testing.T) {
+	// Brute force over small primes.
+	for _, tc := range []struct {
+		p       uint64
+		factors []Int
+	}{
+		{2, nil},
+		{3, []Int{{2}}},
+		{7, []Int{{2}, {3}}},
+		{97, []Int{{2}, {3}}},
+		{257, []Int{{2}}},
+		{1019, []Int{{2}, {509}}},
+	} {
+		m := new(Int).SetUint64(tc.p)
+		for x := uint64(1); x < tc.p; x++ {
+			exp, acc := uint64(1), x
+			for acc != 1 {
+				acc = acc * x % tc.p
+				exp++
+			}
+			got, ok := new(Int).MultiplicativeOrder(new(Int).SetUint64(x), m, tc.factors)
+			if !ok || got.Uint64() != exp {
+				t.Fatalf("order of %d mod %d: got %d (ok %v), exp %d", x, tc.p, got.Uint64(), ok, exp)
+			}
+		}
+	}
+}
+
+func TestMultiplicativeOrderBN254(t *testing.T) {
+	r := BN254Scalar.Modulus()
+	b, _ := new(big.Int).SetString("13818364434197438864469338081", 10)
+	large, _ := FromBig(b)
+	factors := []Int{
+		{2}, {3}, {13}, {29}, {983}, {11003}, {237073}, {405928799},
+		{1670836401704629}, *large,
+	}
+	rMinus1 := new(Int).Sub(r, &Int{1})
+	// 5 is a generator of the multiplicative group.
+	g := new(Int).SetUint64(5)
+	if got, ok := new(Int).MultiplicativeOrder(g, r, factors); !ok || !got.Eq(rMinus1) {
+		t.Fatalf("order of generator: got %v (ok %v)", got.Hex(), ok)
+	}
+	// The 2-adicity of r-1 is 28, so g**((r-1)/2**28) is a primitive 2**28-th
+	// root of unity.
+	if tz := rMinus1.TrailingZeroBits(); tz != 28 {
+		t.Fatalf("two-adicity: got %d, exp 28", tz)
+	}
+	var odd, root Int
+	odd.OddPart(rMinus1)
+	root.ExpMod(g, &odd, r)
+	if got, ok := new(Int).MultiplicativeOrder(&root, r, factors); !ok || !got.Eq(new(Int).Lsh(&Int{1}, 28)) {
+		t.Fatalf("order of root of unity: got %v (ok %v)", got.Hex(), ok)
+	}
+	// Failure cases.
+	if _, ok := new(Int).MultiplicativeOrder(g, r, factors[:9]); ok {
+		t.Errorf("expected failure for incomplete factorization")
+	}
+	if _, ok := new(Int).MultiplicativeOrder(r, r, factors); ok {
+		t.Errorf("expected failure for x = 0 mod m")
+	}
+	if _, ok := new(Int).MultiplicativeOrder(&Int{2}, &Int{9}, []Int{{2}}); ok {
+		t.Errorf("expected failure for composite modulus")
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// ToGray sets z to the binary reflected Gray code of x, and returns z.
+func (z *Int) ToGray(x *Int) *Int {
+	var t Int
+	t.Rsh(x, 1)
+	return z.Xor(x, &t)
+}
+
+// FromGray sets z to the value whose binary reflected Gray code is x, and
+// returns z.
+func (z *Int) FromGray(x *Int) *Int {
+	var t Int
+	z.Copy(x)
+	for shift := uint(1); shift < 256; shift <<= 1 {
+		z.Xor(z, t.Rsh(z, shift))
+	}
+	return z
+}
+
+// spread32 spaces out the bits of x, so that bit i of x becomes bit 2*i.
+func spread32(x uint64) uint64 {
+	x &= 0x00000000ffffffff
+	x = (x | x<<16) & 0x0000ffff0000ffff
+	x = (x | x<<8) & 0x00ff00ff00ff00ff
+	x = (x | x<<4) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+// compact32 is the inverse of spread32: it gathers the even bits of x.
+func compact32(x uint64) uint64 {
+	x &= 0x5555555555555555
+	x = (x | x>>1) & 0x3333333333333333
+	x = (x | x>>2) & 0x0f0f0f0f0f0f0f0f
+	x = (x | x>>4) & 0x00ff00ff00ff00ff
+	x = (x | x>>8) & 0x0000ffff0000ffff
+	x = (x | x>>16) & 0x00000000ffffffff
+	return x
+}
+
+// Interleave sets z to the Morton (Z-order) code of the 128-bit coordinates
+// x and y, such that bit i of x becomes bit 2*i of z and bit i of y becomes
+// bit 2*i+1 of z. Bits of x and y above 128 are ignored. Returns z.
+func (z *Int) Interleave(x, y *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("Interleave"))
+	}
+	x0, x1, y0, y1 := x[0], x[1], y[0], y[1]
+	z[0] = spread32(x0) | spread32(y0)<<1
+	z[1] = spread32(x0>>32) | spread32(y0>>32)<<1
+	z[2] = spread32(x1) | spread32(y1)<<1
+	z[3] = spread32(x1>>32) | spread32(y1>>32)<<1
+	return z
+}
+
+// Deinterleave splits the Morton (Z-order) code z into its 128-bit
+// coordinates, which are stored in x and y. It is the inverse of Interleave.
+func (z *Int) Deinterleave(x, y *Int) {
+	z = orZero(z)
+	z0, z1, z2, z3 := z[0], z[1], z[2], z[3]
+	x[0] = compact32(z0) | compact32(z1)<<32
+	x[1] = compact32(z2) | compact32(z3)<<32
+	x[2], x[3] = 0, 0
+	y[0] = compact32(z0>>1) | compact32(z1>>1)<<32
+	y[1] = compact32(z2>>1) | compact32(z3>>1)<<32
+	y[2], y[3] = 0, 0
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestGray(t *testing.T) {
+	// Consecutive values have Gray codes differing in exactly one bit.
+	var prev, cur, diff Int
+	for i := uint64(0); i < 1000; i++ {
+		cur.ToGray(new(Int).SetUint64(i))
+		if i > 0 {
+			diff.Xor(&prev, &cur)
+			if diff.BitLen() == 0 || !diff.Eq(new(Int).Lsh(new(Int).SetOne(), uint(diff.BitLen()-1))) {
+				t.Fatalf("gray(%d) and gray(%d) differ in more than one bit", i-1, i)
+			}
+		}
+		prev = cur
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randHighNums()
+		var z Int
+		if z.FromGray(z.ToGray(x)); !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v", x.Hex(), z.Hex())
+		}
+		// Aliased input.
+		z.Copy(x)
+		if z.ToGray(&z).FromGray(&z); !z.Eq(x) {
+			t.Fatalf("aliased round trip %v: got %v", x.Hex(), z.Hex())
+		}
+	}
+}
+
+func TestMorton(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randHighNums()
+		_, y, _ := randHighNums()
+		var z, gx, gy Int
+		z.Interleave(x, y)
+		for n := uint(0); n < 128; n++ {
+			if z.Bit(2*n) != x.Bit(n) || z.Bit(2*n+1) != y.Bit(n) {
+				t.Fatalf("bit %d: interleave(%v, %v) = %v", n, x.Hex(), y.Hex(), z.Hex())
+			}
+		}
+		z.Deinterleave(&gx, &gy)
+		x[2], x[3], y[2], y[3] = 0, 0, 0, 0
+		if !gx.Eq(x) || !gy.Eq(y) {
+			t.Fatalf("round trip: got %v, %v exp %v, %v", gx.Hex(), gy.Hex(), x.Hex(), y.Hex())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+import "net/netip"
+
+// SetFromNetipAddr sets z to the 128-bit big-endian value of addr, and
+// returns z. IPv4 addresses are converted to their IPv4-mapped IPv6 form
+// (::ffff:a.b.c.d). The zero Addr is converted to 0.
+func (z *Int) SetFromNetipAddr(addr netip.Addr) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromNetipAddr"))
+	}
+	if !addr.IsValid() {
+		return z.Clear()
+	}
+	return z.SetFromUUID(addr.As16())
+}
+
+// FromNetipAddr is a convenience-constructor for SetFromNetipAddr.
+func FromNetipAddr(addr netip.Addr) *Int {
+	return new(Int).SetFromNetipAddr(addr)
+}
+
+// ToNetipAddr returns z as an IPv6 address, and whether z fits in 128 bits.
+// If not, the low 128 bits are used. IPv4-mapped addresses can be converted
+// back to IPv4 using netip.Addr.Unmap.
+func (z *Int) ToNetipAddr() (netip.Addr, bool) {
+	return netip.AddrFrom16(z.ToUUID()), z.IsUint128()
+}
+
+// PrefixRange returns the first and last address of the prefix p, as
+// converted by FromNetipAddr, and whether p is valid.
+func PrefixRange(p netip.Prefix) (first, last *Int, ok bool) {
+	if !p.IsValid() {
+		return nil, nil, false
+	}
+	hostBits := uint(p.Addr().BitLen() - p.Bits())
+	first = FromNetipAddr(p.Masked().Addr())
+	mask := new(Int).Lsh(new(Int).SetOne(), hostBits)
+	mask.Sub(mask, new(Int).SetOne())
+	last = new(Int).Or(first, mask)
+	return first, last, true
+}
+
+// IteratePrefix calls fn for each address in the prefix p, in ascending
+// order, until fn returns false. Addresses of an IPv4 prefix are passed as
+// IPv4 addresses. Nothing is iterated if p is invalid.
+func IteratePrefix(p netip.Prefix, fn func(addr netip.Addr) bool) {
+	first, last, ok := PrefixRange(p)
+	if !ok {
+		return
+	}
+	var (
+		one = new(Int).SetOne()
+		is4 = p.Addr().Is4()
+	)
+	for cur := first; ; cur.Add(cur, one) {
+		addr, _ := cur.ToNetipAddr()
+		if is4 {
+			addr = addr.Unmap()
+		}
+		if !fn(addr) || cur.Eq(last) {
+			return
+		}
+	}
+}
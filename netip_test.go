@@ -0,0 +1,80 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build go1.18
+// +build go1.18
+
+package uint256
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestNetipAddr(t *testing.T) {
+	addr := netip.MustParseAddr("2001:db8::1")
+	z := FromNetipAddr(addr)
+	if exp := (&Int{1, 0x20010db800000000}); !z.Eq(exp) {
+		t.Errorf("got %v exp %v", z.Hex(), exp.Hex())
+	}
+	if got, ok := z.ToNetipAddr(); !ok || got != addr {
+		t.Errorf("got %v (ok=%v) exp %v", got, ok, addr)
+	}
+	z = FromNetipAddr(netip.MustParseAddr("10.0.0.1"))
+	if exp := (&Int{0xffff0a000001}); !z.Eq(exp) {
+		t.Errorf("got %v exp %v", z.Hex(), exp.Hex())
+	}
+	if got, _ := z.ToNetipAddr(); got.Unmap() != netip.MustParseAddr("10.0.0.1") {
+		t.Errorf("got %v", got)
+	}
+	if _, ok := new(Int).SetAllOne().ToNetipAddr(); ok {
+		t.Errorf("expected overflow")
+	}
+	if z := FromNetipAddr(netip.Addr{}); !z.IsZero() {
+		t.Errorf("got %v", z.Hex())
+	}
+}
+
+func TestIteratePrefix(t *testing.T) {
+	var got []string
+	IteratePrefix(netip.MustParsePrefix("192.168.1.5/30"), func(addr netip.Addr) bool {
+		got = append(got, addr.String())
+		return true
+	})
+	exp := []string{"192.168.1.4", "192.168.1.5", "192.168.1.6", "192.168.1.7"}
+	if len(got) != len(exp) {
+		t.Fatalf("got %v exp %v", got, exp)
+	}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("got %v exp %v", got, exp)
+		}
+	}
+	// The top of the address space must not wrap around.
+	count := 0
+	IteratePrefix(netip.MustParsePrefix("ffff:ffff:ffff:ffff:ffff:ffff:ffff:fff0/124"), func(addr netip.Addr) bool {
+		count++
+		return count < 100
+	})
+	if count != 16 {
+		t.Errorf("got %d addresses, exp 16", count)
+	}
+	// Early termination.
+	count = 0
+	IteratePrefix(netip.MustParsePrefix("::/0"), func(addr netip.Addr) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Errorf("got %d addresses, exp 3", count)
+	}
+	first, last, ok := PrefixRange(netip.MustParsePrefix("::/0"))
+	if !ok || !first.IsZero() || !last.Eq(&Int{^uint64(0), ^uint64(0)}) {
+		t.Errorf("got %v - %v", first, last)
+	}
+	if _, _, ok := PrefixRange(netip.Prefix{}); ok {
+		t.Errorf("expected invalid prefix")
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package ntt implements radix-2 number-theoretic transforms (FFTs over prime
+// fields) on vectors of uint256.Int, as used for polynomial multiplication in
+// proving systems.
+package ntt
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	errUnsupportedField = errors.New("ntt: unsupported field")
+	errDomainSize       = errors.New("ntt: domain size must be a power of two within the field's two-adicity")
+	errLength           = errors.New("ntt: input length does not match domain size")
+	errProductTooLarge  = errors.New("ntt: product does not fit in the domain")
+)
+
+// fieldParams describes the 2-power roots of unity of a supported field.
+type fieldParams struct {
+	modulus    uint256.Int
+	twoAdicity uint        // largest k such that 2**k divides modulus-1
+	root       uint256.Int // primitive 2**twoAdicity-th root of unity
+}
+
+// newFieldParams derives the parameters of a prime field from a generator of
+// its multiplicative group.
+func newFieldParams(modulus *uint256.Int, generator uint64) fieldParams {
+	var odd, pm1 uint256.Int
+	pm1.Sub(modulus, &uint256.Int{1})
+	_, k := odd.OddPart(&pm1)
+	p := fieldParams{modulus: *modulus, twoAdicity: k}
+	p.root.ExpMod(new(uint256.Int).SetUint64(generator), &odd, modulus)
+	return p
+}
+
+// supported lists the fields for which NewDomain can find roots of unity.
+var supported = []fieldParams{
+	// BN254 scalar field, generator 5, two-adicity 28.
+	newFieldParams(uint256.BN254Scalar.Modulus(), 5),
+	// Goldilocks field 2**64 - 2**32 + 1, generator 7, two-adicity 32.
+	newFieldParams(new(uint256.Int).SetUint64(0xffffffff00000001), 7),
+}
+
+func lookup(modulus *uint256.Int) (*fieldParams, bool) {
+	for i := range supported {
+		if supported[i].modulus.Eq(modulus) {
+			return &supported[i], true
+		}
+	}
+	return nil, false
+}
+
+// Domain is an evaluation domain of a power-of-two size over a prime field,
+// holding the precomputed twiddle factors for the transforms.
+type Domain struct {
+	field   *uint256.Field
+	size    int
+	omega   uint256.Int   // primitive size-th root of unity
+	tw      []uint256.Int // Montgomery forms of the forward twiddles, per stage
+	invTw   []uint256.Int // Montgomery forms of the inverse twiddles, per stage
+	sizeInv uint256.Int   // Montgomery form of 1/size
+}
+
+// NewDomain returns a Domain of the given size over the field f, which must
+// be one of the supported fields (currently uint256.BN254Scalar, or a field
+// over the Goldilocks prime 2**64 - 2**32 + 1). The size must be a power of
+// two, no larger than 2**twoAdicity of the field.
+func NewDomain(f *uint256.Field, size int) (*Domain, error) {
+	params, ok := lookup(f.Modulus())
+	if !ok {
+		return nil, errUnsupportedField
+	}
+	if size <= 0 || size&(size-1) != 0 || uint64(size) > uint64(1)<<params.twoAdicity {
+		return nil, errDomainSize
+	}
+	var omega uint256.Int
+	omega.Copy(&params.root)
+	for n := uint64(1) << params.twoAdicity; n > uint64(size); n >>= 1 {
+		omega.MulF(&omega, &omega, f)
+	}
+	return NewDomainWithRoot(f, &omega, size)
+}
+
+// NewDomainWithRoot returns a Domain of the given size over the prime field
+// f, using omega as the primitive size-th root of unity. This allows the
+// transforms to be used over fields for which NewDomain has no parameters.
+// The size must be a power of two, and omega**(size/2) must equal -1.
+func NewDomainWithRoot(f *uint256.Field, omega *uint256.Int, size int) (*Domain, error) {
+	if size <= 0 || size&(size-1) != 0 {
+		return nil, errDomainSize
+	}
+	m := f.Modulus()
+	var check uint256.Int
+	check.ExpMod(omega, new(uint256.Int).SetUint64(uint64(size/2)), m)
+	if size > 1 && !check.Eq(new(uint256.Int).Sub(m, &uint256.Int{1})) {
+		return nil, errDomainSize
+	}
+	if size == 1 && !omega.IsOne() {
+		return nil, errDomainSize
+	}
+	d := &Domain{field: f, size: size}
+	d.omega.Copy(omega)
+	var omegaInv, sizeInv uint256.Int
+	omegaInv.ModInverse(omega, m)
+	sizeInv.ModInverse(new(uint256.Int).SetUint64(uint64(size)), m)
+	d.tw = twiddles(f, omega, size)
+	d.invTw = twiddles(f, &omegaInv, size)
+	f.ToMont(&d.sizeInv, &sizeInv)
+	return d, nil
+}
+
+// twiddles returns the Montgomery forms of the twiddle factors for all stages
+// of a size-point transform with root omega. The factors of the stage with
+// half-size h are stored contiguously at [h-1, 2h-1), so that the butterflies
+// read them sequentially.
+func twiddles(f *uint256.Field, omega *uint256.Int, size int) []uint256.Int {
+	if size < 2 {
+		return nil
+	}
+	tw := make([]uint256.Int, size-1)
+	var w, step uint256.Int
+	for h := 1; h < size; h <<= 1 {
+		// The stage root is omega**(size/(2h)).
+		step.ExpMod(omega, new(uint256.Int).SetUint64(uint64(size/(2*h))), f.Modulus())
+		w.SetOne()
+		for j := 0; j < h; j++ {
+			f.ToMont(&tw[h-1+j], &w)
+			w.MulF(&w, &step, f)
+		}
+	}
+	return tw
+}
+
+// Size returns the number of points of the domain.
+func (d *Domain) Size() int {
+	return d.size
+}
+
+// Root returns a copy of the primitive size-th root of unity of the domain.
+// The forward transform evaluates at the points Root()**i.
+func (d *Domain) Root() *uint256.Int {
+	return new(uint256.Int).Copy(&d.omega)
+}
+
+// bitReverse permutes values into bit-reversed index order.
+func bitReverse(values []uint256.Int) {
+	n := len(values)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			values[i], values[j] = values[j], values[i]
+		}
+	}
+}
+
+// transform performs an in-place iterative decimation-in-time transform.
+func (d *Domain) transform(values, tw []uint256.Int) {
+	f := d.field
+	bitReverse(values)
+	var u, v uint256.Int
+	for h := 1; h < len(values); h <<= 1 {
+		stage := tw[h-1 : 2*h-1]
+		for start := 0; start < len(values); start += 2 * h {
+			lo, hi := values[start:start+h], values[start+h:start+2*h]
+			for j := range stage {
+				// The twiddle is in Montgomery form, so MulMont yields the
+				// product in standard form.
+				f.MulMont(&v, &stage[j], &hi[j])
+				u = lo[j]
+				lo[j].AddF(&u, &v, f)
+				hi[j].SubF(&u, &v, f)
+			}
+		}
+	}
+}
+
+// Forward replaces the coefficients in values (constant term first) with the
+// evaluations at the points Root()**i, for i in [0, Size()). The values must
+// be reduced modulo the field, and their number must equal Size().
+func (d *Domain) Forward(values []uint256.Int) error {
+	if len(values) != d.size {
+		return errLength
+	}
+	d.transform(values, d.tw)
+	return nil
+}
+
+// Inverse is the inverse of Forward: it replaces the evaluations in values
+// with the coefficients of the interpolating polynomial.
+func (d *Domain) Inverse(values []uint256.Int) error {
+	if len(values) != d.size {
+		return errLength
+	}
+	d.transform(values, d.invTw)
+	for i := range values {
+		d.field.MulMont(&values[i], &d.sizeInv, &values[i])
+	}
+	return nil
+}
+
+// Multiply returns the coefficients of the product of the polynomials with
+// coefficients a and b (constant term first). The coefficients must be
+// reduced modulo the field, and len(a)+len(b)-1 must not exceed Size().
+func (d *Domain) Multiply(a, b []uint256.Int) ([]uint256.Int, error) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, nil
+	}
+	n := len(a) + len(b) - 1
+	if n > d.size {
+		return nil, errProductTooLarge
+	}
+	fa := make([]uint256.Int, d.size)
+	fb := make([]uint256.Int, d.size)
+	copy(fa, a)
+	copy(fb, b)
+	d.transform(fa, d.tw)
+	d.transform(fb, d.tw)
+	for i := range fa {
+		fa[i].MulF(&fa[i], &fb[i], d.field)
+	}
+	if err := d.Inverse(fa); err != nil {
+		return nil, err
+	}
+	return fa[:n], nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package ntt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+var goldilocks = uint256.NewField(new(uint256.Int).SetUint64(0xffffffff00000001))
+
+func randVector(rng *rand.Rand, f *uint256.Field, n int) []uint256.Int {
+	m := f.Modulus()
+	v := make([]uint256.Int, n)
+	for i := range v {
+		v[i] = uint256.Int{rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64()}
+		v[i].Mod(&v[i], m)
+	}
+	return v
+}
+
+// naiveEval evaluates the polynomial with coefficients c at x.
+func naiveEval(c []uint256.Int, x *uint256.Int, m *uint256.Int) *uint256.Int {
+	acc := new(uint256.Int)
+	for i := len(c) - 1; i >= 0; i-- {
+		acc.MulMod(acc, x, m)
+		acc.AddMod(acc, &c[i], m)
+	}
+	return acc
+}
+
+func TestForwardMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, f := range []*uint256.Field{uint256.BN254Scalar, goldilocks} {
+		m := f.Modulus()
+		for size := 1; size <= 64; size <<= 1 {
+			d, err := NewDomain(f, size)
+			if err != nil {
+				t.Fatal(err)
+			}
+			coeffs := randVector(rng, f, size)
+			values := append([]uint256.Int{}, coeffs...)
+			if err := d.Forward(values); err != nil {
+				t.Fatal(err)
+			}
+			x := new(uint256.Int).SetUint64(1)
+			for i := range values {
+				if exp := naiveEval(coeffs, x, m); !values[i].Eq(exp) {
+					t.Fatalf("size %d, point %d: got %v, exp %v", size, i, values[i].Hex(), exp.Hex())
+				}
+				x.MulMod(x, d.Root(), m)
+			}
+			if err := d.Inverse(values); err != nil {
+				t.Fatal(err)
+			}
+			for i := range values {
+				if !values[i].Eq(&coeffs[i]) {
+					t.Fatalf("size %d: inverse mismatch at %d", size, i)
+				}
+			}
+		}
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	f := uint256.BN254Scalar
+	m := f.Modulus()
+	d, err := NewDomain(f, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sizes := range [][2]int{{1, 1}, {1, 100}, {37, 91}, {128, 129}} {
+		a := randVector(rng, f, sizes[0])
+		b := randVector(rng, f, sizes[1])
+		got, err := d.Multiply(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := make([]uint256.Int, len(a)+len(b)-1)
+		var p uint256.Int
+		for i := range a {
+			for j := range b {
+				p.MulMod(&a[i], &b[j], m)
+				exp[i+j].AddMod(&exp[i+j], &p, m)
+			}
+		}
+		if len(got) != len(exp) {
+			t.Fatalf("%v: got %d coefficients, exp %d", sizes, len(got), len(exp))
+		}
+		for i := range exp {
+			if !got[i].Eq(&exp[i]) {
+				t.Fatalf("%v: coefficient %d: got %v, exp %v", sizes, i, got[i].Hex(), exp[i].Hex())
+			}
+		}
+	}
+	if _, err := d.Multiply(make([]uint256.Int, 200), make([]uint256.Int, 58)); err != errProductTooLarge {
+		t.Errorf("expected errProductTooLarge, got %v", err)
+	}
+}
+
+func TestDomainErrors(t *testing.T) {
+	if _, err := NewDomain(uint256.NewField(new(uint256.Int).SetUint64(97)), 4); err != errUnsupportedField {
+		t.Errorf("expected errUnsupportedField, got %v", err)
+	}
+	for _, size := range []int{0, 3, 1 << 29} {
+		if _, err := NewDomain(uint256.BN254Scalar, size); err != errDomainSize {
+			t.Errorf("size %d: expected errDomainSize, got %v", size, err)
+		}
+	}
+	d, _ := NewDomain(uint256.BN254Scalar, 8)
+	if err := d.Forward(make([]uint256.Int, 4)); err != errLength {
+		t.Errorf("expected errLength, got %v", err)
+	}
+	// 22 is a primitive 4th root of unity mod 97, since 22**2 = -1, but 64 is not.
+	f := uint256.NewField(new(uint256.Int).SetUint64(97))
+	if _, err := NewDomainWithRoot(f, new(uint256.Int).SetUint64(64), 4); err != errDomainSize {
+		t.Errorf("expected errDomainSize for non-primitive root, got %v", err)
+	}
+	d, err := NewDomainWithRoot(f, new(uint256.Int).SetUint64(22), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.Multiply([]uint256.Int{{1}, {1}}, []uint256.Int{{96}, {1}})
+	if err != nil || len(got) != 3 || got[0][0] != 96 || got[1][0] != 0 || got[2][0] != 1 {
+		t.Errorf("(x+1)(x-1) mod 97: got %v (err %v)", got, err)
+	}
+}
+
+func BenchmarkForward(b *testing.B) {
+	rng := rand.New(rand.NewSource(3))
+	d, _ := NewDomain(uint256.BN254Scalar, 1<<12)
+	values := randVector(rng, uint256.BN254Scalar, d.Size())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = d.Forward(values)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "errors"
+
+const (
+	// ParquetFixedLen is the length of the Parquet FIXED_LEN_BYTE_ARRAY
+	// physical type holding the big-endian form of an Int.
+	ParquetFixedLen = 32
+
+	// MaxParquetDecimalPrecision is the largest precision of a Parquet
+	// DECIMAL which fits in a FIXED_LEN_BYTE_ARRAY(32), since the unscaled
+	// value is stored in two's complement.
+	MaxParquetDecimalPrecision = 76
+)
+
+var (
+	errFixedLen         = errors.New("uint256: fixed-length byte array must be 32 bytes")
+	errDecimalPrecision = errors.New("uint256: decimal precision out of range")
+	errDecimalOverflow  = errors.New("uint256: value exceeds decimal precision")
+	errDecimalNegative  = errors.New("uint256: negative decimal")
+)
+
+// pow10 sets z to 10**n, which must be smaller than 2**256, and returns z.
+func (z *Int) pow10(n int) *Int {
+	ten := Int{10}
+	z.SetOne()
+	for i := 0; i < n; i++ {
+		z.Mul(z, &ten)
+	}
+	return z
+}
+
+// ParquetDecimalLen returns the minimal number of bytes of the
+// FIXED_LEN_BYTE_ARRAY backing a Parquet DECIMAL of the given precision,
+// which must be in the range [1, MaxParquetDecimalPrecision].
+func ParquetDecimalLen(precision int) int {
+	if precision < 1 || precision > MaxParquetDecimalPrecision {
+		panic("uint256: decimal precision out of range")
+	}
+	// The largest value 10**precision - 1 must fit in 8n-1 bits.
+	var max Int
+	max.pow10(precision).SubOne(&max)
+	return max.BitLen()/8 + 1
+}
+
+// ToFixedLenByteArray returns the 32-byte big-endian form of z, as stored in
+// a Parquet FIXED_LEN_BYTE_ARRAY(32) column.
+func (z *Int) ToFixedLenByteArray() []byte {
+	b := z.Bytes32()
+	return b[:]
+}
+
+// SetFromFixedLenByteArray sets z from the value of a Parquet
+// FIXED_LEN_BYTE_ARRAY(32) column, which must be exactly 32 bytes.
+func (z *Int) SetFromFixedLenByteArray(b []byte) error {
+	if z == nil {
+		panic(nilReceiver("SetFromFixedLenByteArray"))
+	}
+	if len(b) != ParquetFixedLen {
+		return errFixedLen
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// ToParquetDecimal returns z as the unscaled value of a Parquet DECIMAL with
+// the given precision, backed by a FIXED_LEN_BYTE_ARRAY of length
+// ParquetDecimalLen(precision): big-endian two's complement. The scale is
+// column metadata, and does not affect the encoding. Returns an error if z
+// has more than precision digits.
+func (z *Int) ToParquetDecimal(precision int) ([]byte, error) {
+	z = orZero(z)
+	if precision < 1 || precision > MaxParquetDecimalPrecision {
+		return nil, errDecimalPrecision
+	}
+	var limit Int
+	if !z.Lt(limit.pow10(precision)) {
+		return nil, errDecimalOverflow
+	}
+	b := z.Bytes32()
+	return b[32-ParquetDecimalLen(precision):], nil
+}
+
+// SetFromParquetDecimal sets z to the unscaled value b of a Parquet DECIMAL
+// backed by a FIXED_LEN_BYTE_ARRAY (or BYTE_ARRAY), stored as big-endian two's
+// complement. Negative values are rejected.
+func (z *Int) SetFromParquetDecimal(b []byte) error {
+	if z == nil {
+		panic(nilReceiver("SetFromParquetDecimal"))
+	}
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		return errDecimalNegative
+	}
+	// Strip sign-extension bytes, which may make the input longer than 32
+	// bytes.
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	if len(b) > 32 {
+		return errDecimalOverflow
+	}
+	z.SetBytes(b)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestParquetDecimalLen(t *testing.T) {
+	// From the Parquet format specification.
+	for precision, exp := range map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 9: 4, 10: 5, 18: 8, 19: 9, 38: 16, 76: 32} {
+		if got := ParquetDecimalLen(precision); got != exp {
+			t.Errorf("precision %d: got %d, exp %d", precision, got, exp)
+		}
+	}
+}
+
+func TestParquetFixedLen(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		_, x, _ := randNums()
+		b := x.ToFixedLenByteArray()
+		var z Int
+		if err := z.SetFromFixedLenByteArray(b); err != nil || !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v (err %v)", x.Hex(), z.Hex(), err)
+		}
+	}
+	if err := new(Int).SetFromFixedLenByteArray(make([]byte, 31)); err != errFixedLen {
+		t.Errorf("expected errFixedLen, got %v", err)
+	}
+}
+
+func TestParquetDecimal(t *testing.T) {
+	for precision := 1; precision <= MaxParquetDecimalPrecision; precision++ {
+		max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+		max.Sub(max, big.NewInt(1))
+		x, _ := FromBig(max)
+		b, err := x.ToParquetDecimal(precision)
+		if err != nil {
+			t.Fatalf("precision %d: %v", precision, err)
+		}
+		exp := make([]byte, ParquetDecimalLen(precision))
+		copy(exp[len(exp)-len(max.Bytes()):], max.Bytes())
+		if !bytes.Equal(b, exp) {
+			t.Fatalf("precision %d: got %x", precision, b)
+		}
+		var z Int
+		if err := z.SetFromParquetDecimal(b); err != nil || !z.Eq(x) {
+			t.Fatalf("precision %d: decoded %v (err %v)", precision, z.Hex(), err)
+		}
+		x.Add(x, &Int{1})
+		if _, err := x.ToParquetDecimal(precision); err != errDecimalOverflow {
+			t.Fatalf("precision %d: expected errDecimalOverflow, got %v", precision, err)
+		}
+	}
+	for _, p := range []int{0, 77} {
+		if _, err := new(Int).ToParquetDecimal(p); err != errDecimalPrecision {
+			t.Errorf("precision %d: expected errDecimalPrecision, got %v", p, err)
+		}
+	}
+	var z Int
+	if err := z.SetFromParquetDecimal([]byte{0xff, 0x00}); err != errDecimalNegative {
+		t.Errorf("expected errDecimalNegative, got %v", err)
+	}
+	// Sign extension beyond 32 bytes is accepted.
+	if err := z.SetFromParquetDecimal(append(make([]byte, 8), 0x01, 0x02)); err != nil || z.Uint64() != 0x102 {
+		t.Errorf("got %v (err %v)", z.Hex(), err)
+	}
+	if err := z.SetFromParquetDecimal(append([]byte{1}, make([]byte, 32)...)); err != errDecimalOverflow {
+		t.Errorf("expected errDecimalOverflow, got %v", err)
+	}
+}
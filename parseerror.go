@@ -0,0 +1,68 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Descriptions of the formats accepted by the string parsers, for
+// ParseError.Expected.
+const (
+	expectDecimal   = "decimal digits without leading zeros"
+	expectFixed     = "decimal digits with an optional fraction"
+	expectHex       = "0x-prefixed hex digits"
+	expectQuantity  = "0x-prefixed hex digits without leading zeros"
+	expectLocalized = "decimal digits with optional separators"
+	expectBase58    = "base58 digits"
+	expectCheck     = "base58 digits of a version byte, up to 32 bytes and a checksum"
+	expectBech32    = "bech32 characters of 32 bytes and a checksum"
+	expectSegwit    = "bech32 or bech32m segwit address of a 32-byte program"
+	expectBase64    = "padded base64 of up to 32 bytes"
+	expectBase32    = "padded base32 of up to 32 bytes"
+	expectBytes32   = "32 bytes"
+	expectUvarint   = "uvarint of up to 256 bits"
+	expectULID      = "26 Crockford base32 digits"
+)
+
+// ParseError is the error returned by the parsers, such as SetFromDecimal,
+// SetFromHex, SetFromQuantity, SetText, SetFromLocalized, SetFromBase58,
+// SetFromBech32, SetFromBase64, SetFromBytes32, ReadUvarint and SetFromULID,
+// and the functions built on them, describing what was wrong with the input
+// and where. The input of the binary parsers is given as a string of bytes.
+type ParseError struct {
+	Input    string // The input, as given to the parser.
+	Offset   int    // Byte offset of the error in Input, or -1 if there is no particular position, e.g. on overflow.
+	Rune     rune   // The rune at Offset, or utf8.RuneError if Offset is -1 or at the end of Input.
+	Expected string // A description of the expected format.
+	Err      error  // The reason for the failure.
+}
+
+// newParseError returns a ParseError for the input, with the rune found at
+// the offset.
+func newParseError(input string, offset int, expected string, err error) *ParseError {
+	e := &ParseError{Input: input, Offset: offset, Rune: utf8.RuneError, Expected: expected, Err: err}
+	if offset >= 0 && offset < len(input) {
+		e.Rune, _ = utf8.DecodeRuneInString(input[offset:])
+	}
+	return e
+}
+
+func (e *ParseError) Error() string {
+	switch {
+	case e.Offset < 0:
+		return fmt.Sprintf("%v: parsing %q (expected %s)", e.Err, e.Input, e.Expected)
+	case e.Offset >= len(e.Input):
+		return fmt.Sprintf("%v: unexpected end of input in %q (expected %s)", e.Err, e.Input, e.Expected)
+	}
+	return fmt.Sprintf("%v: unexpected %q at offset %d in %q (expected %s)", e.Err, e.Rune, e.Offset, e.Input, e.Expected)
+}
+
+// Unwrap returns the reason for the failure.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
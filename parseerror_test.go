@@ -0,0 +1,113 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseErrorPosition(t *testing.T) {
+	overflow := "0x1" + strings.Repeat("0", 64)
+	version17, _ := bech32Encode("bc", append([]byte{17}, make([]byte, 52)...), bech32mConst)
+	var (
+		base58Check = func(s string) error { _, err := new(Int).SetFromBase58Check(s); return err }
+		bech32      = func(s string) error { _, err := new(Int).SetFromBech32(s); return err }
+		segwit      = func(s string) error { _, err := new(Int).SetFromSegwitAddress("bc", s); return err }
+		bytes32     = func(s string) error { return new(Int).SetFromBytes32([]byte(s)) }
+		uvarint     = func(s string) error { return new(Int).ReadUvarint(strings.NewReader(s)) }
+	)
+	for _, tc := range []struct {
+		name   string
+		parse  func(string) error
+		s      string
+		offset int
+		r      rune
+		err    error
+	}{
+		{"decimal", new(Int).SetFromDecimal, "12a4", 2, 'a', errDecimalSyntax},
+		{"decimal", new(Int).SetFromDecimal, "", 0, utf8.RuneError, errEmptyString},
+		{"decimal", new(Int).SetFromDecimal, "012", 0, '0', errLeadingZero},
+		{"hex", new(Int).SetFromHex, "0x12g4", 4, 'g', errHexSyntax},
+		{"hex", new(Int).SetFromHex, "12", 0, '1', errMissingPrefix},
+		{"hex", new(Int).SetFromHex, "0x", 2, utf8.RuneError, errEmptyString},
+		{"hex", new(Int).SetFromHex, overflow, -1, utf8.RuneError, errOverflow},
+		{"quantity", new(Int).SetFromQuantity, "0x0400", 2, '0', errLeadingZero},
+		{"base36", func(s string) error { return new(Int).SetText(s, 36) }, "zz!", 2, '!', errDigit},
+		{"localized", func(s string) error { return new(Int).SetFromLocalized(s, ',') }, " 1,,2", 3, ',', errSeparator},
+		{"localized", func(s string) error { return new(Int).SetFromLocalized(s, '\u00a0') }, "1\u00a0x", 3, 'x', errDecimalSyntax},
+		{"localized", func(s string) error { return new(Int).SetFromLocalized(s, ',') }, "12,", 2, ',', errSeparator},
+		{"base58", func(s string) error { _, err := FromBase58(s); return err }, "1I1", 1, 'I', errInvalidBase58},
+		{"ulid", func(s string) error { _, err := FromULID(s); return err }, "01ARZ3NDEKTSV4RRFFQ69G5FAU", 25, 'U', errInvalidULID},
+		{"base58check", base58Check, "1I1", 1, 'I', errInvalidBase58},
+		{"base58check", base58Check, "1111", -1, utf8.RuneError, errBase58CheckSize},
+		{"base58check", base58Check, "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", -1, utf8.RuneError, errBase58Checksum},
+		{"bech32", bech32, "a12UEL5L", 3, 'U', errBech32Format},
+		{"bech32", bech32, "pzry9x0s0muk", 12, utf8.RuneError, errBech32Format},
+		{"bech32", bech32, "1pzry9x0s0muk", 0, '1', errBech32Hrp},
+		{"bech32", bech32, "\x201nwldj5", 0, ' ', errBech32Hrp},
+		{"bech32", bech32, "x1b4n0q5v", 2, 'b', errBech32Format},
+		{"bech32", bech32, "li1dgmt3", 8, utf8.RuneError, errBech32Format},
+		{"bech32", bech32, "A1G7SGD8", -1, utf8.RuneError, errBech32Checksum},
+		{"bech32", bech32, "a1lqfn3a", -1, utf8.RuneError, errBech32Checksum},
+		{"bech32", bech32, "a12uel5l", -1, utf8.RuneError, errBech32Program},
+		{"segwit", segwit, "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7", 0, 't', errBech32Hrp},
+		{"segwit", segwit, version17, 3, '3', errBech32Program},
+		{"segwit", segwit, "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd", -1, utf8.RuneError, errBech32Checksum},
+		{"base64", func(s string) error { return new(Int).SetFromBase64(s) }, "AA!A", 2, '!', errInvalidBase64},
+		{"base64", func(s string) error { return new(Int).SetFromBase64(s) }, strings.Repeat("A", 44), -1, utf8.RuneError, errEncodingTooLong},
+		{"base64", func(s string) error { return new(Int).SetFromBase64(s) }, strings.Repeat("A", 48), -1, utf8.RuneError, errEncodingTooLong},
+		{"base32", func(s string) error { return new(Int).SetFromBase32(s) }, "A1======", 1, '1', errInvalidBase32},
+		{"base32", func(s string) error { return new(Int).SetFromBase32(s) }, strings.Repeat("A", 64), -1, utf8.RuneError, errEncodingTooLong},
+		{"bytes32", bytes32, strings.Repeat("\x00", 31), 31, utf8.RuneError, errBytes32Len},
+		{"bytes32", bytes32, strings.Repeat("\x00", 32) + "\x01", 32, '\x01', errBytes32Len},
+		{"uvarint", uvarint, strings.Repeat("\xff", 36) + "\x1f", 36, '\x1f', errVarintOverflow},
+		{"uvarint", uvarint, strings.Repeat("\x80", 37), 36, utf8.RuneError, errVarintOverflow},
+	} {
+		err := tc.parse(tc.s)
+		var perr *ParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("%s %q: got %T (%v), exp *ParseError", tc.name, tc.s, err, err)
+			continue
+		}
+		if perr.Input != tc.s || perr.Offset != tc.offset || perr.Rune != tc.r {
+			t.Errorf("%s %q: got input %q offset %d rune %q, exp offset %d rune %q",
+				tc.name, tc.s, perr.Input, perr.Offset, perr.Rune, tc.offset, tc.r)
+		}
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s %q: got err %v, exp %v", tc.name, tc.s, err, tc.err)
+		}
+		if perr.Expected == "" {
+			t.Errorf("%s %q: missing expected format", tc.name, tc.s)
+		}
+	}
+}
+
+func TestParseErrorString(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want string
+	}{
+		{
+			new(Int).SetFromDecimal("12a4"),
+			`uint256: invalid decimal string: unexpected 'a' at offset 2 in "12a4" (expected decimal digits without leading zeros)`,
+		},
+		{
+			new(Int).SetFromHex("0x"),
+			`uint256: empty string: unexpected end of input in "0x" (expected 0x-prefixed hex digits)`,
+		},
+		{
+			new(Int).SetText("100000000000000000000000000000000000000000000000000", 36),
+			`uint256: value overflows 256 bits: parsing "100000000000000000000000000000000000000000000000000" (expected base-36 digits)`,
+		},
+	} {
+		if have := tc.err.Error(); have != tc.want {
+			t.Errorf("got %s, exp %s", have, tc.want)
+		}
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	errParseBase = errors.New("uint256: parse base must be 0, or between 2 and MaxBase")
+	errOddHex    = errors.New("uint256: odd number of hex digits")
+)
+
+// ParseOptions is a parsing policy for SetFromString, and the policies of
+// the string parsers SetFromDecimal, SetFromHex, SetFromQuantity, SetText and
+// SetFromLocalized are the presets DecimalOptions, HexOptions,
+// QuantityOptions, TextOptions and LocalizedOptions. The zero value accepts
+// decimal as strictly as SetFromDecimal, and hex with a 0x or 0X prefix and
+// whole bytes of digits, without leading zero bytes; each field relaxes or
+// tightens one rule.
+type ParseOptions struct {
+	// Base is between 2 and MaxBase, with digits as for SetText, or 0 to
+	// parse input with a 0x or 0X prefix as hex and anything else as
+	// decimal.
+	Base int
+
+	// AllowPrefix accepts an optional 0x or 0X prefix on base 16 input.
+	// It is implied by Base 0 and by RequirePrefix.
+	AllowPrefix bool
+
+	// RequirePrefix rejects base 16 input without a 0x or 0X prefix.
+	RequirePrefix bool
+
+	// LowerCasePrefix accepts only the 0x prefix, treating 0X as no prefix.
+	LowerCasePrefix bool
+
+	// AllowOddLength accepts hex input with an odd number of digits, e.g.
+	// "0xa". Otherwise, the digits must form whole bytes, e.g. "0x0a".
+	AllowOddLength bool
+
+	// AllowLeadingZeros accepts zero digits beyond the shortest
+	// representation of the value. When hex input must have an even length,
+	// the zero padding a byte is not counted as leading.
+	AllowLeadingZeros bool
+
+	// AllowEmpty parses input without digits, i.e. "" or a bare prefix, as
+	// zero.
+	AllowEmpty bool
+
+	// MaxBits, if between 1 and 255, rejects values wider than MaxBits bits
+	// with an error wrapping ErrOutOfRange. Other values mean 256 bits.
+	MaxBits int
+
+	// TrimSpace ignores white space around the input.
+	TrimSpace bool
+
+	// Separator, if not 0, is accepted between digits as a grouping
+	// separator, in any positions, but not at either end nor twice in a row.
+	Separator rune
+
+	// AnyScriptDigits accepts decimal digits of any script, e.g.
+	// Arabic-Indic or full-width digits, in base 10.
+	AnyScriptDigits bool
+
+	// expected describes the format of the presets for ParseError.Expected.
+	// If empty, it is derived from the base.
+	expected string
+}
+
+// The policies of the string parsers. They are kept unexported, so that
+// changes to the exported copies do not affect the parsers.
+var (
+	decimalOptions   = ParseOptions{Base: 10, expected: expectDecimal}
+	hexOptions       = ParseOptions{Base: 16, RequirePrefix: true, AllowOddLength: true, AllowLeadingZeros: true, expected: expectHex}
+	quantityOptions  = ParseOptions{Base: 16, RequirePrefix: true, LowerCasePrefix: true, AllowOddLength: true, expected: expectQuantity}
+	textOptions      = ParseOptions{AllowOddLength: true, AllowLeadingZeros: true}
+	localizedOptions = ParseOptions{Base: 10, AllowLeadingZeros: true, TrimSpace: true, AnyScriptDigits: true, expected: expectLocalized}
+)
+
+// Policies of the existing parsers, and a lenient one, for use with
+// SetFromString.
+var (
+	// DecimalOptions is the policy of SetFromDecimal.
+	DecimalOptions = decimalOptions
+
+	// HexOptions is the policy of SetFromHex.
+	HexOptions = hexOptions
+
+	// QuantityOptions is the policy of SetFromQuantity.
+	QuantityOptions = quantityOptions
+
+	// TextOptions is the policy of SetText, which sets its Base.
+	TextOptions = textOptions
+
+	// LocalizedOptions is the policy of SetFromLocalized, which sets its
+	// Separator.
+	LocalizedOptions = localizedOptions
+
+	// LenientOptions accepts decimal, or hex with a 0x prefix, of any length,
+	// with leading zeros, and the empty string as zero.
+	LenientOptions = ParseOptions{AllowOddLength: true, AllowLeadingZeros: true, AllowEmpty: true}
+)
+
+// SetFromString sets z from s according to the policy opts. On error, z is
+// left unchanged. Errors for invalid input are of type *ParseError, and an
+// invalid Base is reported as a plain error.
+func (z *Int) SetFromString(s string, opts ParseOptions) error {
+	if z == nil {
+		panic(nilReceiver("SetFromString"))
+	}
+	if opts.Base != 0 && (opts.Base < 2 || opts.Base > MaxBase) {
+		return errParseBase
+	}
+	return z.parse(s, &opts)
+}
+
+// parse implements SetFromString and the parsers based on its presets, with
+// a valid base.
+func (z *Int) parse(s string, opts *ParseOptions) error {
+	if offset, err := z.setWithOptions(s, opts); err != nil {
+		expected := opts.expected
+		if expected == "" {
+			expected = describeBase(opts.base(s))
+		}
+		return newParseError(s, offset, expected, err)
+	}
+	return nil
+}
+
+// describeBase describes the digits of base, for ParseError.Expected.
+func describeBase(base int) string {
+	switch base {
+	case 10:
+		return "decimal digits"
+	case 16:
+		return "hex digits"
+	}
+	return "base-" + strconv.Itoa(base) + " digits"
+}
+
+// hasHexPrefix reports whether s starts with 0x or 0X.
+func hasHexPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// trim returns the bounds of s without the white space that opts ignores.
+func (opts *ParseOptions) trim(s string) (start, end int) {
+	if !opts.TrimSpace {
+		return 0, len(s)
+	}
+	start = len(s) - len(strings.TrimLeftFunc(s, unicode.IsSpace))
+	end = len(strings.TrimRightFunc(s, unicode.IsSpace))
+	if start > end {
+		start = end
+	}
+	return start, end
+}
+
+// base returns the base in which opts parses s.
+func (opts *ParseOptions) base(s string) int {
+	if opts.Base != 0 {
+		return opts.Base
+	}
+	if start, end := opts.trim(s); hasHexPrefix(s[start:end]) {
+		return 16
+	}
+	return 10
+}
+
+// syntaxError returns the error for an invalid digit of base.
+func syntaxError(base int) error {
+	switch base {
+	case 10:
+		return errDecimalSyntax
+	case 16:
+		return errHexSyntax
+	}
+	return errDigit
+}
+
+// digits validates the digits s[start:] in base, and returns them as ASCII
+// digits without separators, or the offset of the error in s.
+func (opts *ParseOptions) digits(s string, start, base int) (string, int, error) {
+	b := uint64(base)
+	if opts.Separator == 0 && !opts.AnyScriptDigits {
+		// The common bases get their own loops, for speed.
+		i := start
+		switch base {
+		case 10:
+			for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+				i++
+			}
+		case 16:
+			for i < len(s) && hexValue(s[i]) != 0xff {
+				i++
+			}
+		default:
+			for i < len(s) && textValue(s[i], b) != b {
+				i++
+			}
+		}
+		if i < len(s) {
+			return "", i, syntaxError(base)
+		}
+		return s[start:], 0, nil
+	}
+	var (
+		digits  = make([]byte, 0, len(s)-start)
+		lastSep = true // Disallows a leading separator.
+	)
+	for i := start; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case opts.Separator != 0 && r == opts.Separator:
+			if lastSep {
+				return "", i, errSeparator
+			}
+			lastSep = true
+		case r < utf8.RuneSelf && textValue(byte(r), b) != b:
+			digits = append(digits, byte(r))
+			lastSep = false
+		case opts.AnyScriptDigits && base == 10 && digitValue(r) >= 0:
+			digits = append(digits, byte('0'+digitValue(r)))
+			lastSep = false
+		default:
+			return "", i, syntaxError(base)
+		}
+		i += size
+	}
+	if lastSep {
+		return "", len(s) - utf8.RuneLen(opts.Separator), errSeparator
+	}
+	return string(digits), 0, nil
+}
+
+// setWithOptions implements parse, returning the offset of the error in s,
+// or -1 for a value out of range.
+func (z *Int) setWithOptions(s string, opts *ParseOptions) (int, error) {
+	start, end := opts.trim(s)
+	base := opts.base(s)
+	if base == 16 {
+		prefixed := hasHexPrefix(s[start:end]) && !(opts.LowerCasePrefix && s[start+1] == 'X')
+		switch {
+		case prefixed && (opts.Base == 0 || opts.AllowPrefix || opts.RequirePrefix):
+			start += 2
+		case opts.RequirePrefix:
+			return start, errMissingPrefix
+		}
+	}
+	if start == end {
+		if !opts.AllowEmpty {
+			return start, errEmptyString
+		}
+		z.Clear()
+		return 0, nil
+	}
+	digits, offset, err := opts.digits(s[:end], start, base)
+	if err != nil {
+		return offset, err
+	}
+	even := base == 16 && !opts.AllowOddLength
+	if even && len(digits)%2 != 0 {
+		return end, errOddHex
+	}
+	// The shortest representation has all significant digits, or a single
+	// zero, rounded up to whole bytes if needed.
+	zeros := 0
+	for zeros < len(digits)-1 && digits[zeros] == '0' {
+		zeros++
+	}
+	if !opts.AllowLeadingZeros {
+		shortest := len(digits) - zeros
+		if even && shortest%2 != 0 {
+			shortest++
+		}
+		if len(digits) > shortest {
+			return start, errLeadingZero
+		}
+	}
+	// The digits are valid, so only overflow can fail.
+	var res Int
+	switch base {
+	case 10:
+		offset, err = res.setDecimalDigits(digits[zeros:])
+	case 16:
+		offset, err = res.setHexDigits(digits, zeros)
+	default:
+		offset, err = res.setTextDigits(digits[zeros:], uint64(base))
+	}
+	if err != nil {
+		return offset, err
+	}
+	if opts.MaxBits > 0 && opts.MaxBits < 256 && res.BitLen() > opts.MaxBits {
+		return -1, fmt.Errorf("%w: value exceeds %d bits", ErrOutOfRange, opts.MaxBits)
+	}
+	z.Copy(&res)
+	return 0, nil
+}
+
+// ParseString is a convenience-constructor for SetFromString.
+func ParseString(s string, opts ParseOptions) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromString(s, opts); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"testing"
+)
+
+var parseCorpus = []string{
+	"", "0", "00", "1", "01", "10", "a", "0a", "ff", "+1", "-1", " 1", "1 ",
+	"0x", "0X", "0x0", "0x00", "0x000", "0x1", "0x01", "0x001", "0xa", "0X0A",
+	"0xg", "0x 1", "x1", "12a4", "1_000",
+	"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+	"115792089237316195423570985008687907853269984665640564039457584007913129639936",
+	"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"0x10000000000000000000000000000000000000000000000000000000000000000",
+	"0x0000ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	" 12 ", "1,234", "1,,2", ",1", "1,", "\u0661\u0662", "\uff11\uff12", "zZ", "0x0400", "0X1",
+}
+
+// TestParseOptionsPolicies checks that the exported presets are the policies
+// of the string parsers.
+func TestParseOptionsPolicies(t *testing.T) {
+	withBase := func(base int) ParseOptions {
+		opts := TextOptions
+		opts.Base = base
+		return opts
+	}
+	withSeparator := func(sep rune) ParseOptions {
+		opts := LocalizedOptions
+		opts.Separator = sep
+		return opts
+	}
+	for _, tc := range []struct {
+		name  string
+		opts  ParseOptions
+		parse func(*Int, string) error
+	}{
+		{"decimal", DecimalOptions, (*Int).SetFromDecimal},
+		{"hex", HexOptions, (*Int).SetFromHex},
+		{"quantity", QuantityOptions, (*Int).SetFromQuantity},
+		{"base2", withBase(2), func(z *Int, s string) error { return z.SetText(s, 2) }},
+		{"base10", withBase(10), func(z *Int, s string) error { return z.SetText(s, 10) }},
+		{"base16", withBase(16), func(z *Int, s string) error { return z.SetText(s, 16) }},
+		{"base62", withBase(62), func(z *Int, s string) error { return z.SetText(s, 62) }},
+		{"localized", withSeparator(','), func(z *Int, s string) error { return z.SetFromLocalized(s, ',') }},
+		{"localized", withSeparator(0), func(z *Int, s string) error { return z.SetFromLocalized(s, 0) }},
+	} {
+		for _, s := range parseCorpus {
+			var a, b Int
+			errA := tc.parse(&a, s)
+			errB := b.SetFromString(s, tc.opts)
+			if (errA == nil) != (errB == nil) || !a.Eq(&b) {
+				t.Errorf("%s %q: got %v (%v), exp %v (%v)", tc.name, s, &b, errB, &a, errA)
+				continue
+			}
+			if errA != nil && !errors.Is(errB, errors.Unwrap(errA)) {
+				t.Errorf("%s %q: got err %v, exp %v", tc.name, s, errB, errA)
+			}
+		}
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		opts ParseOptions
+		want string // ToHex of the result, if no error.
+		err  error
+	}{
+		{"", LenientOptions, "0x0", nil},
+		{"0x", LenientOptions, "0x0", nil},
+		{"0x", HexOptions, "", errEmptyString},
+		{"007", LenientOptions, "0x7", nil},
+		{"0x007", LenientOptions, "0x7", nil},
+		{"ff", LenientOptions, "", errDecimalSyntax},
+		{"ff", ParseOptions{Base: 16}, "0xff", nil},
+		{"0xff", ParseOptions{Base: 16}, "", errHexSyntax},
+		{"0xff", ParseOptions{Base: 16, AllowPrefix: true}, "0xff", nil},
+		{"ff", ParseOptions{Base: 16, RequirePrefix: true}, "", errMissingPrefix},
+		{"0xa", ParseOptions{Base: 16, AllowPrefix: true}, "", errOddHex},
+		{"0x0a", ParseOptions{Base: 16, AllowPrefix: true}, "0xa", nil},
+		{"0x00", ParseOptions{Base: 16, AllowPrefix: true}, "0x0", nil},
+		{"0x000a", ParseOptions{Base: 16, AllowPrefix: true}, "", errLeadingZero},
+		{"0x0a", ParseOptions{Base: 16, AllowPrefix: true, AllowOddLength: true}, "", errLeadingZero},
+		{"0x0", ParseOptions{Base: 16, AllowPrefix: true, AllowOddLength: true}, "0x0", nil},
+		{"255", ParseOptions{Base: 10, MaxBits: 8}, "0xff", nil},
+		{"256", ParseOptions{Base: 10, MaxBits: 8}, "", ErrOutOfRange},
+		{"0x0100", ParseOptions{MaxBits: 8, AllowLeadingZeros: true}, "", ErrOutOfRange},
+		{"0x" + "f" + "0000000000000000000000000000000000000000000000000000000000000000", LenientOptions, "", errOverflow},
+		// The zero value.
+		{"10", ParseOptions{}, "0xa", nil},
+		{"010", ParseOptions{}, "", errLeadingZero},
+		{"0x0a", ParseOptions{}, "0xa", nil},
+		{"0X0A", ParseOptions{}, "0xa", nil},
+		{"0xa", ParseOptions{}, "", errOddHex},
+		{"0x000a", ParseOptions{}, "", errLeadingZero},
+		{"", ParseOptions{}, "", errEmptyString},
+		// Other bases, separators, white space and scripts.
+		{"777", ParseOptions{Base: 8}, "0x1ff", nil},
+		{"8", ParseOptions{Base: 8}, "", errDigit},
+		{"Zz", ParseOptions{Base: 62}, "0xee9", nil},
+		{"0X1", ParseOptions{Base: 16, RequirePrefix: true, LowerCasePrefix: true}, "", errMissingPrefix},
+		{"0x1", ParseOptions{Base: 16, RequirePrefix: true, LowerCasePrefix: true, AllowOddLength: true}, "0x1", nil},
+		{" 12\t", ParseOptions{TrimSpace: true}, "0xc", nil},
+		{" 12", ParseOptions{}, "", errDecimalSyntax},
+		{" 0x0c ", ParseOptions{TrimSpace: true}, "0xc", nil},
+		{"1_000_000", ParseOptions{Separator: '_'}, "0xf4240", nil},
+		{"0xff_ff", ParseOptions{Separator: '_'}, "0xffff", nil},
+		{"1__0", ParseOptions{Separator: '_'}, "", errSeparator},
+		{"_1", ParseOptions{Separator: '_'}, "", errSeparator},
+		{"1_", ParseOptions{Separator: '_'}, "", errSeparator},
+		{"\u0661\u0662", ParseOptions{Base: 10, AnyScriptDigits: true}, "0xc", nil},
+		{"\u0661\u0662", ParseOptions{Base: 10}, "", errDecimalSyntax},
+		{"0x\u0661", ParseOptions{AnyScriptDigits: true, AllowOddLength: true}, "", errHexSyntax},
+	} {
+		z := new(Int).SetUint64(42)
+		err := z.SetFromString(tc.s, tc.opts)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%q %+v: got err %v, exp %v", tc.s, tc.opts, err, tc.err)
+			continue
+		}
+		if err != nil {
+			if z.Uint64() != 42 {
+				t.Errorf("%q %+v: receiver modified on error", tc.s, tc.opts)
+			}
+			continue
+		}
+		if have := z.ToHex(); have != tc.want {
+			t.Errorf("%q %+v: got %s, exp %s", tc.s, tc.opts, have, tc.want)
+		}
+	}
+	for _, base := range []int{-1, 1, MaxBase + 1} {
+		if _, err := ParseString("1", ParseOptions{Base: base}); err != errParseBase {
+			t.Errorf("base %d: got err %v, exp %v", base, err, errParseBase)
+		}
+	}
+}
+
+// TestParseOptionsPresetCopies checks that changing the exported presets does
+// not change the parsers.
+func TestParseOptionsPresetCopies(t *testing.T) {
+	saved := DecimalOptions
+	defer func() { DecimalOptions = saved }()
+	DecimalOptions = LenientOptions
+	if err := new(Int).SetFromDecimal("007"); !errors.Is(err, errLeadingZero) {
+		t.Fatalf("got err %v, exp %v", err, errLeadingZero)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package poly implements univariate polynomials with uint256.Int
+// coefficients, as used for KZG-style commitments and data-availability
+// sampling.
+//
+// All operations take a modulus m. If m is nil, the coefficients wrap around
+// modulo 2**256; otherwise they are reduced modulo m, which should be prime
+// for Div and Interpolate to be well-defined. Inputs are expected to be
+// reduced modulo m.
+package poly
+
+import (
+	"errors"
+
+	"github.com/holiman/uint256"
+)
+
+var (
+	errDivisionByZero = errors.New("poly: division by the zero polynomial")
+	errNotInvertible  = errors.New("poly: coefficient is not invertible")
+	errPoints         = errors.New("poly: interpolation points must be distinct and match the values")
+)
+
+// Poly is a polynomial, given by its coefficients in ascending order, i.e.
+// the constant term first. The zero polynomial has no coefficients.
+type Poly []uint256.Int
+
+// Degree returns the degree of p, ignoring trailing zero coefficients.
+// The degree of the zero polynomial is -1.
+func (p Poly) Degree() int {
+	d := len(p) - 1
+	for d >= 0 && p[d].IsZero() {
+		d--
+	}
+	return d
+}
+
+// trim returns p without trailing zero coefficients.
+func (p Poly) trim() Poly {
+	return p[:p.Degree()+1]
+}
+
+// Equal reports whether p and q are the same polynomial.
+func (p Poly) Equal(q Poly) bool {
+	p, q = p.trim(), q.trim()
+	if len(p) != len(q) {
+		return false
+	}
+	for i := range p {
+		if !p[i].Eq(&q[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func add(z, x, y, m *uint256.Int) {
+	if m == nil {
+		z.Add(x, y)
+	} else {
+		z.AddMod(x, y, m)
+	}
+}
+
+func sub(z, x, y, m *uint256.Int) {
+	if m == nil {
+		z.Sub(x, y)
+	} else {
+		z.SubMod(x, y, m)
+	}
+}
+
+func mul(z, x, y, m *uint256.Int) {
+	if m == nil {
+		z.Mul(x, y)
+	} else {
+		z.MulMod(x, y, m)
+	}
+}
+
+// inverse sets z to the inverse of x modulo m, or modulo 2**256 if m is nil.
+func inverse(z, x, m *uint256.Int) bool {
+	if m != nil {
+		_, ok := z.ModInverse(x, m)
+		return ok
+	}
+	if x[0]&1 == 0 {
+		return false
+	}
+	// Newton iteration: each step doubles the number of correct low bits,
+	// starting from the 3 bits that x is its own inverse modulo 8 for.
+	var inv, t uint256.Int
+	inv.Copy(x)
+	for i := 0; i < 7; i++ {
+		t.Mul(x, &inv)
+		t.Sub(&uint256.Int{2}, &t)
+		inv.Mul(&inv, &t)
+	}
+	z.Copy(&inv)
+	return true
+}
+
+// Eval returns the value of p at x, using Horner's method.
+func (p Poly) Eval(x, m *uint256.Int) *uint256.Int {
+	z := new(uint256.Int)
+	for i := len(p) - 1; i >= 0; i-- {
+		mul(z, z, x, m)
+		add(z, z, &p[i], m)
+	}
+	return z
+}
+
+// Add returns the sum a + b.
+func Add(a, b Poly, m *uint256.Int) Poly {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	z := append(Poly{}, a...)
+	for i := range b {
+		add(&z[i], &z[i], &b[i], m)
+	}
+	return z.trim()
+}
+
+// Sub returns the difference a - b.
+func Sub(a, b Poly, m *uint256.Int) Poly {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	z := make(Poly, n)
+	copy(z, a)
+	for i := range b {
+		sub(&z[i], &z[i], &b[i], m)
+	}
+	return z.trim()
+}
+
+// Mul returns the product a * b, using schoolbook multiplication. For large
+// polynomials over supported fields, the ntt package is faster.
+func Mul(a, b Poly, m *uint256.Int) Poly {
+	a, b = a.trim(), b.trim()
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	z := make(Poly, len(a)+len(b)-1)
+	var t uint256.Int
+	for i := range a {
+		for j := range b {
+			mul(&t, &a[i], &b[j], m)
+			add(&z[i+j], &z[i+j], &t, m)
+		}
+	}
+	return z.trim()
+}
+
+// Div returns the quotient q and remainder r of the long division of a by b,
+// such that a = q*b + r and the degree of r is less than that of b. The
+// leading coefficient of b must be invertible.
+func Div(a, b Poly, m *uint256.Int) (q, r Poly, err error) {
+	b = b.trim()
+	if len(b) == 0 {
+		return nil, nil, errDivisionByZero
+	}
+	var lead uint256.Int
+	if !inverse(&lead, &b[len(b)-1], m) {
+		return nil, nil, errNotInvertible
+	}
+	r = append(Poly{}, a.trim()...)
+	if len(r) < len(b) {
+		return nil, r, nil
+	}
+	q = make(Poly, len(r)-len(b)+1)
+	var t uint256.Int
+	for i := len(q) - 1; i >= 0; i-- {
+		// Eliminate the coefficient of x**(i+deg(b)).
+		c := &q[i]
+		mul(c, &r[i+len(b)-1], &lead, m)
+		for j := range b {
+			mul(&t, c, &b[j], m)
+			sub(&r[i+j], &r[i+j], &t, m)
+		}
+	}
+	return q.trim(), r.trim(), nil
+}
+
+// Interpolate returns the unique polynomial of degree less than len(xs)
+// passing through the points (xs[i], ys[i]), using Lagrange interpolation.
+// The xs must be distinct, and their pairwise differences invertible.
+func Interpolate(xs, ys []uint256.Int, m *uint256.Int) (Poly, error) {
+	if len(xs) != len(ys) {
+		return nil, errPoints
+	}
+	var (
+		n                     = len(xs)
+		t, denom, diff, scale uint256.Int
+	)
+	// The master polynomial prod (x - xs[i]), of degree n.
+	master := make(Poly, n+1)
+	master[0].SetOne()
+	for i := range xs {
+		// Multiply by (x - xs[i]), from the top down.
+		for j := i + 1; j > 0; j-- {
+			mul(&t, &master[j], &xs[i], m)
+			sub(&master[j], &master[j-1], &t, m)
+		}
+		mul(&t, &master[0], &xs[i], m)
+		sub(&master[0], &uint256.Int{}, &t, m)
+	}
+	result := make(Poly, n)
+	basis := make(Poly, n)
+	for i := range xs {
+		// The basis numerator is master / (x - xs[i]), computed by synthetic
+		// division, and the denominator is prod_{j != i} (xs[i] - xs[j]).
+		basis[n-1] = master[n]
+		for j := n - 1; j > 0; j-- {
+			mul(&basis[j-1], &basis[j], &xs[i], m)
+			add(&basis[j-1], &basis[j-1], &master[j], m)
+		}
+		denom.SetOne()
+		for j := range xs {
+			if j == i {
+				continue
+			}
+			sub(&diff, &xs[i], &xs[j], m)
+			mul(&denom, &denom, &diff, m)
+		}
+		if !inverse(&scale, &denom, m) {
+			return nil, errPoints
+		}
+		mul(&scale, &scale, &ys[i], m)
+		for j := range basis {
+			mul(&t, &basis[j], &scale, m)
+			add(&result[j], &result[j], &t, m)
+		}
+	}
+	return result.trim(), nil
+}
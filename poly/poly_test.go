@@ -0,0 +1,127 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package poly
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+var moduli = []*uint256.Int{
+	nil,
+	uint256.BN254Scalar.Modulus(),
+	new(uint256.Int).SetUint64(97),
+}
+
+func randPoly(rng *rand.Rand, n int, m *uint256.Int) Poly {
+	p := make(Poly, n)
+	for i := range p {
+		p[i] = uint256.Int{rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64()}
+		if m != nil {
+			p[i].Mod(&p[i], m)
+		}
+	}
+	return p
+}
+
+func TestEval(t *testing.T) {
+	// 3 + 2x + x**2 at x = 5 is 38.
+	p := Poly{{3}, {2}, {1}}
+	if got := p.Eval(new(uint256.Int).SetUint64(5), nil); got.Uint64() != 38 {
+		t.Errorf("got %d, exp 38", got.Uint64())
+	}
+	if got := p.Eval(new(uint256.Int).SetUint64(5), new(uint256.Int).SetUint64(7)); got.Uint64() != 3 {
+		t.Errorf("mod 7: got %d, exp 3", got.Uint64())
+	}
+	if got := Poly(nil).Eval(new(uint256.Int).SetUint64(5), nil); !got.IsZero() {
+		t.Errorf("zero polynomial: got %v", got)
+	}
+	if d := (Poly{{1}, {0}, {}}).Degree(); d != 0 {
+		t.Errorf("degree: got %d, exp 0", d)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, m := range moduli {
+		for i := 0; i < 50; i++ {
+			a := randPoly(rng, rng.Intn(10), m)
+			b := randPoly(rng, 1+rng.Intn(6), m)
+			x := randPoly(rng, 1, m)[0]
+			// Evaluation is a ring homomorphism.
+			va, vb := a.Eval(&x, m), b.Eval(&x, m)
+			var exp uint256.Int
+			add(&exp, va, vb, m)
+			if got := Add(a, b, m).Eval(&x, m); !got.Eq(&exp) {
+				t.Fatalf("m=%v: Add mismatch", m)
+			}
+			sub(&exp, va, vb, m)
+			if got := Sub(a, b, m).Eval(&x, m); !got.Eq(&exp) {
+				t.Fatalf("m=%v: Sub mismatch", m)
+			}
+			mul(&exp, va, vb, m)
+			prod := Mul(a, b, m)
+			if got := prod.Eval(&x, m); !got.Eq(&exp) {
+				t.Fatalf("m=%v: Mul mismatch", m)
+			}
+			if !Sub(a, a, m).Equal(nil) {
+				t.Fatalf("m=%v: a - a is not zero", m)
+			}
+			if b.Degree() < 0 || !inverse(new(uint256.Int), &b[b.Degree()], m) {
+				continue
+			}
+			// (a*b + r) / b = a, remainder r.
+			q, r, err := Div(prod, b, m)
+			if err != nil || !q.Equal(a) || len(r) != 0 {
+				t.Fatalf("m=%v: Div(a*b, b): q=%v r=%v err=%v", m, q, r, err)
+			}
+			q, r, err = Div(a, b, m)
+			if err != nil || r.Degree() >= b.Degree() || !Add(Mul(q, b, m), r, m).Equal(a) {
+				t.Fatalf("m=%v: a != q*b + r", m)
+			}
+		}
+	}
+}
+
+func TestDivErrors(t *testing.T) {
+	if _, _, err := Div(Poly{{1}}, Poly{{}}, nil); err != errDivisionByZero {
+		t.Errorf("expected errDivisionByZero, got %v", err)
+	}
+	if _, _, err := Div(Poly{{1}, {1}}, Poly{{1}, {2}}, nil); err != errNotInvertible {
+		t.Errorf("expected errNotInvertible, got %v", err)
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, m := range moduli[1:] {
+		for n := 0; n < 12; n++ {
+			p := randPoly(rng, n, m)
+			xs := make([]uint256.Int, n)
+			ys := make([]uint256.Int, n)
+			for i := range xs {
+				xs[i].SetUint64(uint64(3*i + 1))
+				ys[i] = *p.Eval(&xs[i], m)
+			}
+			got, err := Interpolate(xs, ys, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(p) {
+				t.Fatalf("m=%v n=%d: got %v, exp %v", m, n, got, p)
+			}
+		}
+	}
+	xs := []uint256.Int{{1}, {1}}
+	if _, err := Interpolate(xs, xs, moduli[1]); err != errPoints {
+		t.Errorf("expected errPoints for duplicate points, got %v", err)
+	}
+	if _, err := Interpolate(xs, xs[:1], moduli[1]); err != errPoints {
+		t.Errorf("expected errPoints for length mismatch, got %v", err)
+	}
+}
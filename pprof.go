@@ -0,0 +1,31 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build uint256pprof
+// +build uint256pprof
+
+package uint256
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// With the uint256pprof build tag, the heavy operations Exp, MulMod and the
+// division underlying Div, Mod, AddMod and MulMod run with the pprof
+// label uint256=<operation>, so that CPU profiles of a host application can
+// be filtered and grouped by operation, e.g. with pprof -tagfocus. The
+// innermost operation wins, so division inside MulMod counts as udivrem.
+//
+// The labels replace any labels the host has set on the calling goroutine
+// for the duration of the call, and the goroutine has no labels afterwards,
+// since pprof offers no way to read the current ones. The tag is meant for
+// profiling builds, not production ones.
+const profileLabels = true
+
+// labeled runs f with the pprof label uint256=op.
+func labeled(op string, f func()) {
+	pprof.Do(context.Background(), pprof.Labels("uint256", op), func(context.Context) { f() })
+}
@@ -0,0 +1,19 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build !uint256pprof
+// +build !uint256pprof
+
+package uint256
+
+// profileLabels reports whether heavy operations are labeled for pprof,
+// which requires the uint256pprof build tag. Without it, the labeling code
+// is compiled out.
+const profileLabels = false
+
+// labeled runs f.
+func labeled(op string, f func()) {
+	f()
+}
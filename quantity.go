@@ -0,0 +1,34 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// SetFromQuantity sets z from s, which must be a quantity as defined by the
+// Ethereum JSON-RPC specification: a lower-case 0x prefix followed by the
+// most compact hex representation of the value, i.e. "0x0" for zero and no
+// leading zeros otherwise. Unlike SetFromHex, the empty "0x", leading zeros
+// and the 0X prefix are rejected. Errors are of type *ParseError. The policy
+// is QuantityOptions.
+func (z *Int) SetFromQuantity(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromQuantity"))
+	}
+	return z.parse(s, &quantityOptions)
+}
+
+// ParseQuantity is a convenience-constructor for SetFromQuantity.
+func ParseQuantity(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromQuantity(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// FormatQuantity returns x as an Ethereum JSON-RPC quantity. It is the same
+// as x.ToHex, and provided for symmetry with ParseQuantity.
+func FormatQuantity(x *Int) string {
+	return x.ToHex()
+}
@@ -0,0 +1,61 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp *Int
+		err error
+	}{
+		{"0x0", new(Int), nil},
+		{"0x1", new(Int).SetUint64(1), nil},
+		{"0x41", new(Int).SetUint64(0x41), nil},
+		{"0x400", new(Int).SetUint64(0x400), nil},
+		{"0xaBc", new(Int).SetUint64(0xabc), nil},
+		{"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", new(Int).SetAllOne(), nil},
+		{"", nil, errMissingPrefix},
+		{"0", nil, errMissingPrefix},
+		{"400", nil, errMissingPrefix},
+		{"0X1", nil, errMissingPrefix},
+		{"0x", nil, errEmptyString},
+		{"0x00", nil, errLeadingZero},
+		{"0x0400", nil, errLeadingZero},
+		{"0xg", nil, errHexSyntax},
+		{"0x1" + "0000000000000000000000000000000000000000000000000000000000000000", nil, errOverflow},
+	} {
+		z, err := ParseQuantity(tc.s)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%q: got err %v, exp %v", tc.s, err, tc.err)
+			continue
+		}
+		if tc.exp != nil && !z.Eq(tc.exp) {
+			t.Errorf("%q: got %v, exp %v", tc.s, z, tc.exp)
+		}
+	}
+}
+
+func TestFormatQuantity(t *testing.T) {
+	if got := FormatQuantity(new(Int)); got != "0x0" {
+		t.Errorf("zero: got %s, exp 0x0", got)
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		s := FormatQuantity(x)
+		y, err := ParseQuantity(s)
+		if err != nil {
+			t.Fatalf("%s: %v", s, err)
+		}
+		if !y.Eq(x) {
+			t.Fatalf("%s: got %v, exp %v", s, y, x)
+		}
+	}
+}
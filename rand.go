@@ -0,0 +1,106 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// Rand256 is a deterministic, non-cryptographic pseudo-random generator
+// producing 256-bit values, for reproducible simulations and property tests.
+// It implements xoshiro256**, and is seeded by expanding a 256-bit seed with
+// SplitMix64. A Rand256 is not safe for concurrent use.
+//
+// Rand256 implements rand.Source64, so it can also drive a *rand.Rand.
+type Rand256 struct {
+	s [4]uint64
+}
+
+var _ rand.Source64 = (*Rand256)(nil)
+
+// splitmix64 advances the SplitMix64 state x and returns the next output.
+func splitmix64(x *uint64) uint64 {
+	*x += 0x9e3779b97f4a7c15
+	z := *x
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// NewRand256 returns a new generator seeded with seed. Distinct seeds yield
+// distinct generator states.
+func NewRand256(seed *Int) *Rand256 {
+	r := new(Rand256)
+	r.SeedInt(seed)
+	return r
+}
+
+// SeedInt resets the generator to the state derived from seed.
+func (r *Rand256) SeedInt(seed *Int) {
+	var x uint64
+	for i := range r.s {
+		x += seed[i]
+		r.s[i] = splitmix64(&x)
+	}
+	if r.s[0]|r.s[1]|r.s[2]|r.s[3] == 0 {
+		r.s[0] = 1 // The all-zero state is a fixed point.
+	}
+}
+
+// Seed resets the generator to the state derived from the seed value
+// interpreted as a 256-bit two's complement number.
+func (r *Rand256) Seed(seed int64) {
+	r.SeedInt(new(Int).setInt64(seed))
+}
+
+// Uint64 returns the next pseudo-random 64-bit value.
+func (r *Rand256) Uint64() uint64 {
+	s := &r.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (r *Rand256) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Next sets z to the next pseudo-random 256-bit value, and returns z.
+func (r *Rand256) Next(z *Int) *Int {
+	z[0] = r.Uint64()
+	z[1] = r.Uint64()
+	z[2] = r.Uint64()
+	z[3] = r.Uint64()
+	return z
+}
+
+// Below sets z to a uniformly distributed pseudo-random value in [0, max),
+// and returns z. If max is zero, z is set to 0.
+func (r *Rand256) Below(z, max *Int) *Int {
+	if max.IsZero() {
+		return z.Clear()
+	}
+	// Rejection sampling from the smallest power of two covering max; at
+	// least half of the candidates are accepted.
+	var bound Int
+	bound.Copy(max) // z may alias max
+	n := uint(max.BitLen())
+	for {
+		r.Next(z)
+		z.Rsh(z, 256-n)
+		if z.Lt(&bound) {
+			return z
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRand256Reference(t *testing.T) {
+	// Reference outputs of xoshiro256** for the state {1, 2, 3, 4}.
+	r := &Rand256{s: [4]uint64{1, 2, 3, 4}}
+	for i, exp := range []uint64{11520, 0, 1509978240, 1215971899390074240, 1216172134540287360, 607988272756665600} {
+		if got := r.Uint64(); got != exp {
+			t.Fatalf("output %d: got %d exp %d", i, got, exp)
+		}
+	}
+}
+
+func TestRand256Deterministic(t *testing.T) {
+	seed := &Int{1, 2, 3, 4}
+	a, b := NewRand256(seed), NewRand256(seed.Clone())
+	c := NewRand256(&Int{2, 1, 3, 4})
+	var x, y, w Int
+	for i := 0; i < 100; i++ {
+		a.Next(&x)
+		b.Next(&y)
+		c.Next(&w)
+		if !x.Eq(&y) {
+			t.Fatalf("step %d: same seed gave %v and %v", i, x.Hex(), y.Hex())
+		}
+		if x.Eq(&w) {
+			t.Fatalf("step %d: different seeds gave %v", i, x.Hex())
+		}
+	}
+	// Usable as a math/rand source.
+	r1, r2 := rand.New(NewRand256(seed)), rand.New(NewRand256(seed))
+	for i := 0; i < 100; i++ {
+		if r1.Intn(1000) != r2.Intn(1000) {
+			t.Fatal("math/rand streams differ")
+		}
+	}
+	// The zero seed yields a working generator.
+	if NewRand256(new(Int)).Uint64()|NewRand256(new(Int)).Uint64() == 0 {
+		t.Fatal("zero seed produced zero output")
+	}
+}
+
+func TestRand256Below(t *testing.T) {
+	r := NewRand256(&Int{42})
+	var z Int
+	for _, max := range []*Int{{1}, {2}, {3}, {1000}, {0, 1}, MinInt256(), new(Int).SetAllOne()} {
+		for i := 0; i < 100; i++ {
+			if r.Below(&z, max); !z.Lt(max) {
+				t.Fatalf("got %v, not below %v", z.Hex(), max.Hex())
+			}
+		}
+	}
+	// All residues of a small bound are hit.
+	var seen [7]bool
+	for i := 0; i < 1000; i++ {
+		seen[r.Below(&z, &Int{7}).Uint64()] = true
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("value %d never produced", i)
+		}
+	}
+	if !r.Below(&z, new(Int)).IsZero() {
+		t.Errorf("expected zero for zero bound")
+	}
+}
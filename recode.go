@@ -0,0 +1,78 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "math/bits"
+
+// NAF returns the width-w non-adjacent form of z, least significant digit
+// first, such that z = sum(naf[i] * 2**i). Every non-zero digit is odd and
+// lies in the range [-2**(w-1), 2**(w-1)), and among any w consecutive digits
+// at most one is non-zero. The width must be in the range [2, 8].
+// The result has at most 257 digits, and is empty for z == 0.
+func (z *Int) NAF(w uint) []int8 {
+	z = orZero(z)
+	if w < 2 || w > 8 {
+		panic("uint256: NAF width out of range")
+	}
+	var (
+		// The recoding may carry one bit past the 256th, so an extra word is used.
+		k    = [5]uint64{z[0], z[1], z[2], z[3], 0}
+		naf  = make([]int8, 0, 257)
+		mask = uint64(1)<<w - 1
+		half = uint64(1) << (w - 1)
+	)
+	for k[0]|k[1]|k[2]|k[3]|k[4] != 0 {
+		var d int8
+		if k[0]&1 == 1 {
+			low := k[0] & mask
+			if low < half {
+				d = int8(low)
+				k[0] -= low // Clears the low bits, no borrow.
+			} else {
+				d = int8(int64(low) - int64(mask+1))
+				// Adding 2**w-low clears the low bits and carries upwards.
+				var carry uint64
+				k[0], carry = bits.Add64(k[0], mask+1-low, 0)
+				for i := 1; i < len(k) && carry != 0; i++ {
+					k[i], carry = bits.Add64(k[i], 0, carry)
+				}
+			}
+		}
+		naf = append(naf, d)
+		k[0] = k[0]>>1 | k[1]<<63
+		k[1] = k[1]>>1 | k[2]<<63
+		k[2] = k[2]>>1 | k[3]<<63
+		k[3] = k[3]>>1 | k[4]<<63
+		k[4] >>= 1
+	}
+	return naf
+}
+
+// Booth returns the signed radix-2**w (Booth) recoding of z, least significant
+// digit first, such that z = sum(digits[i] * 2**(i*w)). Every digit lies in the
+// range [-2**(w-1), 2**(w-1)]. The number of digits is always ceil(257 / w),
+// independent of the value of z, which makes it suitable for fixed-window
+// scalar multiplication. The width must be in the range [1, 7].
+func (z *Int) Booth(w uint) []int8 {
+	z = orZero(z)
+	if w < 1 || w > 7 {
+		panic("uint256: Booth width out of range")
+	}
+	n := (257 + w - 1) / w
+	digits := make([]int8, n)
+	for i := uint(0); i < n; i++ {
+		// v holds the w+1 bits from position i*w-1 up to i*w+w-1.
+		var v uint64
+		if i == 0 {
+			v = z.Window(0, w) << 1
+		} else {
+			v = z.Window(i*w-1, w+1)
+		}
+		d := int64((v+1)>>1) - int64((v>>w)<<w)
+		digits[i] = int8(d)
+	}
+	return digits
+}
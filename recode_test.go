@@ -0,0 +1,100 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+// recompose computes sum(digits[i] * 2**(i*shift)).
+func recompose(digits []int8, shift uint) *big.Int {
+	acc := new(big.Int)
+	for i := len(digits) - 1; i >= 0; i-- {
+		acc.Lsh(acc, shift)
+		acc.Add(acc, big.NewInt(int64(digits[i])))
+	}
+	return acc
+}
+
+func checkNAF(t *testing.T, b *big.Int, x *Int, w uint) {
+	t.Helper()
+	naf := x.NAF(w)
+	if got := recompose(naf, 1); got.Cmp(b) != 0 {
+		t.Fatalf("NAF(%d) of %x: recomposed %x", w, b, got)
+	}
+	if len(naf) > 257 {
+		t.Fatalf("NAF(%d) of %x: %d digits", w, b, len(naf))
+	}
+	if len(naf) > 0 && naf[len(naf)-1] == 0 {
+		t.Fatalf("NAF(%d) of %x: leading zero digit", w, b)
+	}
+	last := -int(w)
+	for i, d := range naf {
+		if d == 0 {
+			continue
+		}
+		if d%2 == 0 || int(d) < -(1<<(w-1)) || int(d) >= 1<<(w-1) {
+			t.Fatalf("NAF(%d) of %x: invalid digit %d", w, b, d)
+		}
+		if i-last < int(w) {
+			t.Fatalf("NAF(%d) of %x: adjacent non-zero digits at %d and %d", w, b, last, i)
+		}
+		last = i
+	}
+}
+
+func checkBooth(t *testing.T, b *big.Int, x *Int, w uint) {
+	t.Helper()
+	digits := x.Booth(w)
+	if got := recompose(digits, w); got.Cmp(b) != 0 {
+		t.Fatalf("Booth(%d) of %x: recomposed %x", w, b, got)
+	}
+	if exp := int((257 + w - 1) / w); len(digits) != exp {
+		t.Fatalf("Booth(%d): got %d digits, exp %d", w, len(digits), exp)
+	}
+	for _, d := range digits {
+		if int(d) < -(1<<(w-1)) || int(d) > 1<<(w-1) {
+			t.Fatalf("Booth(%d) of %x: invalid digit %d", w, b, d)
+		}
+	}
+}
+
+func TestRecodeExhaustive(t *testing.T) {
+	for v := uint64(0); v < 1<<14; v++ {
+		b := new(big.Int).SetUint64(v)
+		x := new(Int).SetUint64(v)
+		for w := uint(2); w <= 8; w++ {
+			checkNAF(t, b, x, w)
+		}
+		for w := uint(1); w <= 7; w++ {
+			checkBooth(t, b, x, w)
+		}
+	}
+}
+
+func TestRecodeEdges(t *testing.T) {
+	edges := []*Int{
+		new(Int).SetAllOne(),
+		MaxInt256(),
+		MinInt256(),
+		new(Int).Lsh(new(Int).SetAllOne(), 128),
+		new(Int).Rsh(new(Int).SetAllOne(), 1),
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randHighNums()
+		edges = append(edges, x)
+	}
+	for _, x := range edges {
+		b := x.ToBig()
+		for w := uint(2); w <= 8; w++ {
+			checkNAF(t, b, x, w)
+		}
+		for w := uint(1); w <= 7; w++ {
+			checkBooth(t, b, x, w)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var errScanVerb = errors.New("uint256: invalid verb for Scan")
+
+// Scan is a support routine for fmt.Scanner, so that Ints can be read with
+// fmt.Sscan, fmt.Fscanf and friends. It accepts the verbs %b, %o, %d, %x and
+// %X for base 2, 8, 10 and 16 digits without prefix, and %s and %v for
+// decimal, or a 0b, 0o or 0x prefix (of either case) followed by digits of
+// that base. As with big.Int, scanning stops at the first character which is
+// not a digit, and leading zeros are allowed; unlike big.Int, there is no
+// sign, and a leading zero does not select octal.
+func (z *Int) Scan(s fmt.ScanState, ch rune) error {
+	if z == nil {
+		panic(nilReceiver("Scan"))
+	}
+	var base int
+	switch ch {
+	case 'b':
+		base = 2
+	case 'o':
+		base = 8
+	case 'd':
+		base = 10
+	case 'x', 'X':
+		base = 16
+	case 's', 'v':
+	default:
+		return errScanVerb
+	}
+	s.SkipSpace()
+	var buf []byte
+	if base == 0 {
+		base = 10
+		if r, _, err := s.ReadRune(); err == nil {
+			if r != '0' {
+				s.UnreadRune()
+			} else if p, _, err := s.ReadRune(); err == nil {
+				switch p {
+				case 'b', 'B':
+					base = 2
+				case 'o', 'O':
+					base = 8
+				case 'x', 'X':
+					base = 16
+				default:
+					s.UnreadRune()
+					buf = append(buf, '0')
+				}
+			} else {
+				buf = append(buf, '0')
+			}
+		}
+	}
+	for {
+		r, _, err := s.ReadRune()
+		if err != nil {
+			break
+		}
+		if r >= utf8.RuneSelf || textValue(byte(r), uint64(base)) == uint64(base) {
+			s.UnreadRune()
+			break
+		}
+		buf = append(buf, byte(r))
+	}
+	return z.SetText(string(buf), base)
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	max := "115792089237316195423570985008687907853269984665640564039457584007913129639935"
+	for _, tc := range []struct {
+		format string
+		input  string
+		want   string // Decimal.
+		rest   string // Remaining input.
+	}{
+		{"%d", "123", "123", ""},
+		{"%d", "  0042 rest", "42", " rest"},
+		{"%d", max, max, ""},
+		{"%d", "12abc", "12", "abc"},
+		{"%x", "ff", "255", ""},
+		{"%X", "FFg", "255", "g"},
+		{"%b", "1012", "5", "2"},
+		{"%o", "178", "15", "8"},
+		{"%v", "123", "123", ""},
+		{"%v", "0", "0", ""},
+		{"%v", "0 ", "0", " "},
+		{"%v", "0x1F", "31", ""},
+		{"%s", "0XfF", "255", ""},
+		{"%v", "0b101", "5", ""},
+		{"%v", "0o17", "15", ""},
+		{"%v", "017", "17", ""},
+		{"%3d", "12345", "123", "45"},
+	} {
+		var (
+			z    Int
+			rest string
+			r    = strings.NewReader(tc.input)
+		)
+		if _, err := fmt.Fscanf(r, tc.format, &z); err != nil {
+			t.Errorf("%s %q: unexpected error %v", tc.format, tc.input, err)
+			continue
+		}
+		rest = tc.input[len(tc.input)-r.Len():]
+		if have := z.Dec(); have != tc.want {
+			t.Errorf("%s %q: got %s, exp %s", tc.format, tc.input, have, tc.want)
+		}
+		if rest != tc.rest {
+			t.Errorf("%s %q: got rest %q, exp %q", tc.format, tc.input, rest, tc.rest)
+		}
+	}
+}
+
+func TestSscan(t *testing.T) {
+	var a, b Int
+	n, err := fmt.Sscan("10 0xff", &a, &b)
+	if err != nil || n != 2 {
+		t.Fatalf("got %d, %v", n, err)
+	}
+	if a.Uint64() != 10 || b.Uint64() != 255 {
+		t.Errorf("got %v %v, exp 10 255", &a, &b)
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		input  string
+		err    error
+	}{
+		{"%d", "-1", errEmptyString},
+		{"%d", "x", errEmptyString},
+		{"%v", "0x", errEmptyString},
+		{"%v", "0xg", errEmptyString},
+		{"%d", "115792089237316195423570985008687907853269984665640564039457584007913129639936", errOverflow},
+		{"%q", "1", errScanVerb},
+	} {
+		var z Int
+		if _, err := fmt.Sscanf(tc.input, tc.format, &z); !errors.Is(err, tc.err) {
+			t.Errorf("%s %q: got err %v, exp %v", tc.format, tc.input, err, tc.err)
+		}
+	}
+}
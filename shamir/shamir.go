@@ -0,0 +1,142 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package shamir implements Shamir's secret sharing over a 256-bit prime
+// field, using the fixed size arithmetic of the uint256 package.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"github.com/holiman/uint256"
+)
+
+// DefaultPrime is the largest prime below 2**256, namely 2**256 - 189.
+// It allows sharing any secret of up to 255 bits, as well as most 256-bit
+// values.
+var DefaultPrime = uint256.Int{
+	0xffffffffffffff43,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+	0xffffffffffffffff,
+}
+
+var (
+	errThreshold      = errors.New("shamir: threshold must be between 1 and the number of shares")
+	errSecretTooLarge = errors.New("shamir: secret must be smaller than the prime")
+	errNoShares       = errors.New("shamir: no shares")
+	errInvalidShare   = errors.New("shamir: shares must have distinct, non-zero x-coordinates below the prime")
+)
+
+// Share is a single share of a secret: the point (X, Y) on the secret
+// polynomial.
+type Share struct {
+	X uint256.Int
+	Y uint256.Int
+}
+
+// randomElement returns a uniformly random element of [0, prime) read from r.
+func randomElement(r io.Reader, prime *uint256.Int) (*uint256.Int, error) {
+	var (
+		buf   [32]byte
+		z     = new(uint256.Int)
+		shift = uint(256 - prime.BitLen())
+	)
+	for {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return nil, err
+		}
+		z.SetBytes(buf[:]).Rsh(z, shift)
+		if z.Lt(prime) {
+			return z, nil
+		}
+	}
+}
+
+// Split splits secret into n shares over the field defined by prime, such
+// that any threshold of them can reconstruct the secret, while fewer reveal
+// nothing about it. The randomness is read from random, or from crypto/rand if
+// it is nil. The prime is not checked for primality.
+func Split(secret *uint256.Int, n, threshold int, prime *uint256.Int, random io.Reader) ([]Share, error) {
+	if threshold < 1 || n < threshold || prime.LtUint64(uint64(n)+1) {
+		return nil, errThreshold
+	}
+	if !secret.Lt(prime) {
+		return nil, errSecretTooLarge
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+	// coeffs[0] is the secret, the rest are random.
+	coeffs := make([]uint256.Int, threshold)
+	coeffs[0] = *secret
+	for i := 1; i < threshold; i++ {
+		c, err := randomElement(random, prime)
+		if err != nil {
+			return nil, err
+		}
+		coeffs[i] = *c
+	}
+	shares := make([]Share, n)
+	for i := range shares {
+		x := &shares[i].X
+		y := &shares[i].Y
+		x.SetUint64(uint64(i) + 1)
+		// Horner evaluation of the polynomial at x.
+		y.Clear()
+		for j := threshold - 1; j >= 0; j-- {
+			y.MulMod(y, x, prime)
+			y.AddMod(y, &coeffs[j], prime)
+		}
+	}
+	return shares, nil
+}
+
+// Combine reconstructs the secret from shares produced by Split over the
+// same prime, using Lagrange interpolation at x = 0. If fewer shares than the
+// threshold are given, the result is an unrelated field element.
+func Combine(shares []Share, prime *uint256.Int) (*uint256.Int, error) {
+	if len(shares) == 0 {
+		return nil, errNoShares
+	}
+	for i := range shares {
+		if shares[i].X.IsZero() || !shares[i].X.Lt(prime) || !shares[i].Y.Lt(prime) {
+			return nil, errInvalidShare
+		}
+		for j := 0; j < i; j++ {
+			if shares[i].X.Eq(&shares[j].X) {
+				return nil, errInvalidShare
+			}
+		}
+	}
+	var (
+		secret             = new(uint256.Int)
+		num, den, term, xj uint256.Int
+	)
+	for i := range shares {
+		// The Lagrange basis polynomial for share i, evaluated at 0, is
+		// prod_{j != i} (0 - x_j) / (x_i - x_j).
+		num.SetOne()
+		den.SetOne()
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			xj.Sub(prime, &shares[j].X)
+			num.MulMod(&num, &xj, prime)
+			term.SubMod(&shares[i].X, &shares[j].X, prime)
+			den.MulMod(&den, &term, prime)
+		}
+		if _, ok := den.ModInverse(&den, prime); !ok {
+			return nil, errInvalidShare
+		}
+		term.MulMod(&num, &den, prime)
+		term.MulMod(&term, &shares[i].Y, prime)
+		secret.AddMod(secret, &term, prime)
+	}
+	return secret, nil
+}
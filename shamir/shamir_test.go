@@ -0,0 +1,87 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package shamir
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/holiman/uint256"
+)
+
+func TestSplitCombine(t *testing.T) {
+	secret := new(uint256.Int).SetBytes([]byte("a very secret 256-bit value...."))
+	shares, err := Split(secret, 5, 3, &DefaultPrime, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every subset of three shares reconstructs the secret.
+	for a := 0; a < 5; a++ {
+		for b := a + 1; b < 5; b++ {
+			for c := b + 1; c < 5; c++ {
+				got, err := Combine([]Share{shares[a], shares[b], shares[c]}, &DefaultPrime)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !got.Eq(secret) {
+					t.Fatalf("shares %d,%d,%d: got %x exp %x", a, b, c, got, secret)
+				}
+			}
+		}
+	}
+	// So do all five.
+	if got, err := Combine(shares, &DefaultPrime); err != nil || !got.Eq(secret) {
+		t.Fatalf("got %x (err=%v)", got, err)
+	}
+	// Two shares give an unrelated value.
+	if got, err := Combine(shares[:2], &DefaultPrime); err != nil || got.Eq(secret) {
+		t.Fatalf("two shares revealed the secret (err=%v)", err)
+	}
+}
+
+func TestSmallPrime(t *testing.T) {
+	prime := new(uint256.Int).SetUint64(65521)
+	// A deterministic randomness source.
+	random := bytes.NewReader(bytes.Repeat([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x12, 0x34}, 10))
+	secret := new(uint256.Int).SetUint64(1234)
+	shares, err := Split(secret, 10, 10, prime, random)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Combine(shares, prime); err != nil || !got.Eq(secret) {
+		t.Fatalf("got %x (err=%v)", got, err)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	secret := new(uint256.Int).SetOne()
+	if _, err := Split(secret, 2, 3, &DefaultPrime, nil); err != errThreshold {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Split(secret, 3, 0, &DefaultPrime, nil); err != errThreshold {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Split(new(uint256.Int).SetAllOne(), 3, 2, &DefaultPrime, nil); err != errSecretTooLarge {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Split(secret, 7, 2, new(uint256.Int).SetUint64(7), nil); err != errThreshold {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Split(secret, 3, 2, &DefaultPrime, bytes.NewReader(nil)); err == nil {
+		t.Errorf("expected error from exhausted randomness")
+	}
+	shares, _ := Split(secret, 3, 2, &DefaultPrime, nil)
+	if _, err := Combine(nil, &DefaultPrime); err != errNoShares {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Combine([]Share{shares[0], shares[0]}, &DefaultPrime); err != errInvalidShare {
+		t.Errorf("got %v", err)
+	}
+	if _, err := Combine([]Share{{}}, &DefaultPrime); err != errInvalidShare {
+		t.Errorf("got %v", err)
+	}
+}
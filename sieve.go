@@ -0,0 +1,169 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// smallPrimeLimit bounds the odd primes for which SmallestFactorUnder uses
+// precomputed constants.
+const smallPrimeLimit = 1 << 16
+
+// primeMagic holds the constants of the divisibility test for an odd prime
+// p: since multiplication by the inverse of p modulo 2**64 maps the multiples
+// of p onto [0, 2**64 / p], p divides r if and only if r * inv <= max.
+type primeMagic struct {
+	p, inv, max uint64
+}
+
+// divides reports whether m.p divides r.
+func (m *primeMagic) divides(r uint64) bool {
+	return r*m.inv <= m.max
+}
+
+// primeGroup is a run of consecutive odd primes whose product fits in 64
+// bits, so that one remainder by the product serves to test them all. The
+// product is stored normalized, shifted left by shift, with its reciprocal.
+type primeGroup struct {
+	product    uint64
+	shift      uint
+	reciprocal uint64
+	primes     []primeMagic
+}
+
+var (
+	primeGroupsOnce sync.Once
+	primeGroups     []primeGroup
+)
+
+// newPrimeMagic returns the divisibility constants for the odd prime p.
+func newPrimeMagic(p uint64) primeMagic {
+	// Each Newton iteration doubles the number of correct low bits, from
+	// the three bits of p, since p*p = 1 mod 8 for odd p.
+	inv := p
+	for i := 0; i < 5; i++ {
+		inv *= 2 - p*inv
+	}
+	return primeMagic{p: p, inv: inv, max: ^uint64(0) / p}
+}
+
+// initPrimeGroups sieves the odd primes below smallPrimeLimit, and groups
+// them.
+func initPrimeGroups() {
+	var composite [smallPrimeLimit]bool
+	var group primeGroup
+	group.product = 1
+	flush := func() {
+		group.shift = uint(bits.LeadingZeros64(group.product))
+		group.product <<= group.shift
+		group.reciprocal = reciprocal2by1(group.product)
+		primeGroups = append(primeGroups, group)
+	}
+	for p := uint64(3); p < smallPrimeLimit; p += 2 {
+		if composite[p] {
+			continue
+		}
+		for m := p * p; m < smallPrimeLimit; m += 2 * p {
+			composite[m] = true
+		}
+		if hi, _ := bits.Mul64(group.product, p); hi != 0 {
+			flush()
+			group = primeGroup{product: 1}
+		}
+		group.product *= p
+		group.primes = append(group.primes, newPrimeMagic(p))
+	}
+	flush()
+}
+
+// mod64 returns z mod d, for the divisor d normalized by a left shift of s,
+// and its reciprocal.
+func (z *Int) mod64(d uint64, s uint, reciprocal uint64) uint64 {
+	rem := z[3] >> (64 - s)
+	for i := 3; i >= 0; i-- {
+		u := z[i] << s
+		if i > 0 {
+			u |= z[i-1] >> (64 - s)
+		}
+		_, rem = udivrem2by1(rem, u, d, reciprocal)
+	}
+	return rem >> s
+}
+
+// SmallestFactorUnder returns the smallest prime factor of z less than
+// limit, or 0 if there is none. It is intended to screen out values with
+// small factors before a costlier primality test. Odd primes below 2**16 are
+// tested a group at a time, with precomputed constants; larger candidates
+// are tested by trial division, so large limits are slow for values without
+// small factors. Every prime divides zero, so for z == 0 it returns 2.
+func (z *Int) SmallestFactorUnder(limit uint64) uint64 {
+	if limit <= 2 || z.IsOne() {
+		return 0
+	}
+	if z[0]&1 == 0 {
+		return 2
+	}
+	primeGroupsOnce.Do(initPrimeGroups)
+	// A z with no factor up to its square root is prime.
+	small := z.IsUint64()
+	isPrime := func(p uint64) bool {
+		hi, lo := bits.Mul64(p, p)
+		return small && (hi != 0 || lo > z[0])
+	}
+	var last uint64
+	for i := range primeGroups {
+		g := &primeGroups[i]
+		r := z.mod64(g.product, g.shift, g.reciprocal)
+		for j := range g.primes {
+			m := &g.primes[j]
+			if m.p >= limit {
+				return 0
+			}
+			if isPrime(m.p) {
+				if z[0] < limit {
+					return z[0]
+				}
+				return 0
+			}
+			if m.divides(r) {
+				return m.p
+			}
+			last = m.p
+		}
+	}
+	var q Int
+	for d := last + 2; d < limit && d > last; d += 2 {
+		if isPrime(d) {
+			if z[0] < limit {
+				return z[0]
+			}
+			return 0
+		}
+		if q.divRem64(z, d) == 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// IsDivisibleBy reports whether n divides z. Zero divides only zero.
+func (z *Int) IsDivisibleBy(n uint64) bool {
+	if n == 0 {
+		return z.IsZero()
+	}
+	tz := uint(bits.TrailingZeros64(n))
+	if z[0]&(1<<tz-1) != 0 {
+		return false
+	}
+	n >>= tz
+	if n == 1 {
+		return true
+	}
+	var q Int
+	return q.divRem64(z, n) == 0
+}
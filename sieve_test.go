@@ -0,0 +1,123 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// smallestFactorBig returns the smallest prime factor of x below limit, by
+// trial division, or 0.
+func smallestFactorBig(x *big.Int, limit uint64) uint64 {
+	if x.Sign() == 0 {
+		if limit > 2 {
+			return 2
+		}
+		return 0
+	}
+	var r, d big.Int
+	for p := uint64(2); p < limit; p++ {
+		d.SetUint64(p)
+		if d.Cmp(x) > 0 {
+			break
+		}
+		if r.Mod(x, &d).Sign() == 0 {
+			return p
+		}
+	}
+	return 0
+}
+
+func TestSmallestFactorUnder(t *testing.T) {
+	// 65537 and 65539 are the smallest primes above the precomputed table.
+	p1, p2 := NewInt().SetUint64(65537), NewInt().SetUint64(65539)
+	tests := []struct {
+		x     *Int
+		limit uint64
+		want  uint64
+	}{
+		{new(Int), 100, 2},
+		{new(Int), 2, 0},
+		{NewInt().SetOne(), 100, 0},
+		{NewInt().SetUint64(97), 100, 97},
+		{NewInt().SetUint64(97), 97, 0},
+		{NewInt().SetUint64(65521 * 65521), 1 << 17, 65521},
+		{new(Int).Mul(p1, p2), 1 << 17, 65537},
+		{new(Int).Mul(p2, p2), 65539, 0},
+		{new(Int).Mul(p2, p2), 65540, 65539},
+		{new(Int).Mul(new(Int).Mul(p2, p2), NewInt().SetUint64(65521)), 1 << 20, 65521},
+		{new(Int).SetAllOne(), 100, 3},
+		// 2**255 - 19 is prime.
+		{new(Int).Sub(new(Int).Lsh(NewInt().SetOne(), 255), NewInt().SetUint64(19)), 70000, 0},
+	}
+	for i, tc := range tests {
+		if have := tc.x.SmallestFactorUnder(tc.limit); have != tc.want {
+			t.Errorf("test %d: SmallestFactorUnder(%v, %d): have %d, want %d", i, tc.x, tc.limit, have, tc.want)
+		}
+	}
+}
+
+func TestSmallestFactorUnderRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		var x Int
+		switch i % 3 {
+		case 0:
+			x.SetUint64(rnd.Uint64() >> uint(rnd.Intn(64)))
+		case 1:
+			// A product of small odd numbers.
+			x.SetOne()
+			for j := 0; j < 4; j++ {
+				x.Mul(&x, NewInt().SetUint64(uint64(rnd.Intn(2000))|1))
+			}
+		default:
+			x = Int{rnd.Uint64() | 1, rnd.Uint64(), rnd.Uint64(), rnd.Uint64()}
+		}
+		limit := uint64(rnd.Intn(3000))
+		if have, want := x.SmallestFactorUnder(limit), smallestFactorBig(x.ToBig(), limit); have != want {
+			t.Fatalf("SmallestFactorUnder(%v, %d): have %d, want %d", &x, limit, have, want)
+		}
+	}
+}
+
+func TestIsDivisibleBy(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		n := rnd.Uint64() >> uint(rnd.Intn(64))
+		if i%4 == 0 {
+			n <<= uint(rnd.Intn(8))
+		}
+		var x Int
+		if i%2 == 0 {
+			x = Int{rnd.Uint64(), rnd.Uint64(), rnd.Uint64(), rnd.Uint64()}
+		} else {
+			// A multiple of n.
+			x.Mul(NewInt().SetUint64(n), &Int{rnd.Uint64(), rnd.Uint64()})
+		}
+		want := x.IsZero()
+		if n != 0 {
+			want = new(big.Int).Mod(x.ToBig(), new(big.Int).SetUint64(n)).Sign() == 0
+		}
+		if have := x.IsDivisibleBy(n); have != want {
+			t.Fatalf("IsDivisibleBy(%v, %d): have %v, want %v", &x, n, have, want)
+		}
+	}
+	if !new(Int).IsDivisibleBy(0) || NewInt().SetOne().IsDivisibleBy(0) {
+		t.Errorf("IsDivisibleBy(0) wrong")
+	}
+}
+
+func BenchmarkSmallestFactorUnder(b *testing.B) {
+	// 2**255 - 19 has no small factors, so all groups are tested.
+	x := new(Int).Sub(new(Int).Lsh(NewInt().SetOne(), 255), NewInt().SetUint64(19))
+	x.SmallestFactorUnder(smallPrimeLimit)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.SmallestFactorUnder(smallPrimeLimit)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "sort"
+
+// The functions in this file compare Ints with ==, which the compiler turns
+// into a single 32-byte memory comparison, rather than with Eq.
+
+// EqualSlices reports whether a and b have the same length and hold the same
+// values in the same order.
+func EqualSlices(a, b []Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains reports whether x occurs in s.
+func Contains(s []Int, x *Int) bool {
+	v := *x
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Dedup removes consecutive duplicates from s in place, leaving one of
+// each, and returns the shortened slice; for a sorted s, the result holds
+// every value once. The elements past the returned length are zeroed.
+func Dedup(s []Int) []Int {
+	if len(s) < 2 {
+		return s
+	}
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[n-1] {
+			s[n] = s[i]
+			n++
+		}
+	}
+	clearInts(s[n:])
+	return s[:n]
+}
+
+// TopK returns the k largest values of values, largest first, or all of them
+// if there are fewer than k. values is not modified. It keeps the running
+// top k in a min-heap, so it takes O(n log k) time and O(k) space, instead of
+// sorting all of values.
+func TopK(values []Int, k int) []Int {
+	if k > len(values) {
+		k = len(values)
+	}
+	if k <= 0 {
+		return nil
+	}
+	h := make([]Int, k)
+	copy(h, values)
+	for i := k/2 - 1; i >= 0; i-- {
+		siftDown(h, i)
+	}
+	for i := k; i < len(values); i++ {
+		if values[i].Gt(&h[0]) {
+			h[0] = values[i]
+			siftDown(h, 0)
+		}
+	}
+	sort.Slice(h, func(i, j int) bool { return h[i].Gt(&h[j]) })
+	return h
+}
+
+// siftDown restores the min-heap property of h below the element i.
+func siftDown(h []Int, i int) {
+	for {
+		min := i
+		if l := 2*i + 1; l < len(h) && h[l].Lt(&h[min]) {
+			min = l
+		}
+		if r := 2*i + 2; r < len(h) && h[r].Lt(&h[min]) {
+			min = r
+		}
+		if min == i {
+			return
+		}
+		h[i], h[min] = h[min], h[i]
+		i = min
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestEqualSlices(t *testing.T) {
+	a := randSlice(100)
+	b := append([]Int(nil), a...)
+	if !EqualSlices(a, b) {
+		t.Fatal("equal slices reported unequal")
+	}
+	if !EqualSlices(nil, []Int{}) {
+		t.Fatal("empty slices reported unequal")
+	}
+	if EqualSlices(a, b[:99]) {
+		t.Fatal("slices of different lengths reported equal")
+	}
+	for i := 0; i < 4; i++ {
+		b[50][i] ^= 1
+		if EqualSlices(a, b) {
+			t.Fatalf("slices differing in limb %d reported equal", i)
+		}
+		b[50][i] ^= 1
+	}
+}
+
+func TestContains(t *testing.T) {
+	s := randSlice(100)
+	for i := range s {
+		if !Contains(s, s[i].Clone()) {
+			t.Fatalf("missing element %d", i)
+		}
+	}
+	x := s[0]
+	x[3] ^= 1 << 63
+	if Contains(s[1:], &x) {
+		t.Fatal("found absent element")
+	}
+	if Contains(nil, &x) {
+		t.Fatal("found element in empty slice")
+	}
+}
+
+func TestDedup(t *testing.T) {
+	for _, tc := range []struct {
+		in, want []uint64
+	}{
+		{nil, nil},
+		{[]uint64{1}, []uint64{1}},
+		{[]uint64{1, 1, 1}, []uint64{1}},
+		{[]uint64{1, 2, 2, 3, 3, 3, 4}, []uint64{1, 2, 3, 4}},
+		{[]uint64{1, 2, 1}, []uint64{1, 2, 1}},
+	} {
+		s := make([]Int, len(tc.in))
+		for i, v := range tc.in {
+			s[i].SetUint64(v)
+		}
+		have := Dedup(s)
+		want := make([]Int, len(tc.want))
+		for i, v := range tc.want {
+			want[i].SetUint64(v)
+		}
+		if !EqualSlices(have, want) {
+			t.Errorf("Dedup%v: have %v, want %v", tc.in, have, want)
+		}
+		for i := len(have); i < len(s); i++ {
+			if !s[i].IsZero() {
+				t.Errorf("Dedup%v: element %d not cleared", tc.in, i)
+			}
+		}
+	}
+	// Every value once, for sorted random input with duplicates.
+	s := randSlice(200)
+	s = append(s, s[:100]...)
+	distinct := make(map[Int]bool)
+	for _, x := range s {
+		distinct[x] = true
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i].Lt(&s[j]) })
+	s = Dedup(s)
+	if len(s) != len(distinct) {
+		t.Fatalf("have %d distinct values, want %d", len(s), len(distinct))
+	}
+	for i := 1; i < len(s); i++ {
+		if !s[i-1].Lt(&s[i]) {
+			t.Fatalf("values %d and %d not strictly increasing", i-1, i)
+		}
+	}
+}
+
+func TestTopK(t *testing.T) {
+	values := randSlice(500)
+	values = append(values, values[:50]...)
+	orig := append([]Int(nil), values...)
+	sorted := append([]Int(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Gt(&sorted[j]) })
+	for _, k := range []int{-1, 0, 1, 2, 10, 100, 549, 550, 551, 1000} {
+		have := TopK(values, k)
+		var want []Int
+		switch {
+		case k > len(sorted):
+			want = sorted
+		case k > 0:
+			want = sorted[:k]
+		}
+		if !EqualSlices(have, want) {
+			t.Fatalf("k=%d: have %v, want %v", k, have, want)
+		}
+	}
+	if !EqualSlices(values, orig) {
+		t.Fatal("TopK modified its input")
+	}
+}
+
+func BenchmarkTopK(b *testing.B) {
+	values := randSlice(100000)
+	b.Run("TopK", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			TopK(values, 100)
+		}
+	})
+	b.Run("sort", func(b *testing.B) {
+		s := make([]Int, len(values))
+		for i := 0; i < b.N; i++ {
+			copy(s, values)
+			sort.Slice(s, func(i, j int) bool { return s[i].Gt(&s[j]) })
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build uint256spec
+// +build uint256spec
+
+package uint256
+
+import (
+	"fmt"
+
+	"github.com/holiman/uint256/specuint256"
+)
+
+// With the uint256spec build tag, the optimized arithmetic operations Add,
+// Sub, Mul, Div, Mod, Sdiv, Smod, AddMod, MulMod, Exp, Lsh and Rsh check
+// their results against the reference implementation in the specuint256
+// package, and panic if they differ. The checks are orders of magnitude
+// slower than the operations, so the tag is meant for running the tests and
+// fuzzers of new code paths, not for production builds.
+const specChecks = true
+
+type specOp func(a []specuint256.Int) specuint256.Int
+
+var specOps = map[string]specOp{
+	"Add":    func(a []specuint256.Int) specuint256.Int { return specuint256.Add(a[0], a[1]) },
+	"Sub":    func(a []specuint256.Int) specuint256.Int { return specuint256.Sub(a[0], a[1]) },
+	"Mul":    func(a []specuint256.Int) specuint256.Int { return specuint256.Mul(a[0], a[1]) },
+	"Div":    func(a []specuint256.Int) specuint256.Int { return specuint256.Div(a[0], a[1]) },
+	"Mod":    func(a []specuint256.Int) specuint256.Int { return specuint256.Mod(a[0], a[1]) },
+	"Sdiv":   func(a []specuint256.Int) specuint256.Int { return specuint256.SDiv(a[0], a[1]) },
+	"Smod":   func(a []specuint256.Int) specuint256.Int { return specuint256.SMod(a[0], a[1]) },
+	"AddMod": func(a []specuint256.Int) specuint256.Int { return specuint256.AddMod(a[0], a[1], a[2]) },
+	"MulMod": func(a []specuint256.Int) specuint256.Int { return specuint256.MulMod(a[0], a[1], a[2]) },
+	"Exp":    func(a []specuint256.Int) specuint256.Int { return specuint256.Exp(a[0], a[1]) },
+	"Lsh":    func(a []specuint256.Int) specuint256.Int { return specuint256.Lsh(a[0], uint(a[1][0])) },
+	"Rsh":    func(a []specuint256.Int) specuint256.Int { return specuint256.Rsh(a[0], uint(a[1][0])) },
+}
+
+// checkSpec panics if z, the result of op on args, differs from the
+// specification. It is deferred by the operations, with copies of their
+// operands taken before z is written.
+func checkSpec(op string, z *Int, args ...Int) {
+	a := make([]specuint256.Int, len(args))
+	operands := make([]string, len(args))
+	for i := range args {
+		a[i] = specuint256.Int(args[i])
+		operands[i] = args[i].Hex()
+	}
+	if want := Int(specOps[op](a)); *z != want {
+		panic(fmt.Sprintf("uint256: %s%v = %v, specification gives %v", op, operands, z.Hex(), want.Hex()))
+	}
+}
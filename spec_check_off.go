@@ -0,0 +1,17 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build !uint256spec
+// +build !uint256spec
+
+package uint256
+
+// specChecks reports whether the optimized operations check their results
+// against the specuint256 reference implementation, which requires the
+// uint256spec build tag. Without it, the checks are compiled out.
+const specChecks = false
+
+// checkSpec does nothing.
+func checkSpec(op string, z *Int, args ...Int) {}
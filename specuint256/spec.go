@@ -0,0 +1,281 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+// Package specuint256 is a reference specification of the operations of
+// the uint256 package: a slow implementation which is obviously correct,
+// for cross-checking the optimized code, model checking, and as an oracle
+// when landing new backends.
+//
+// Every operation is a pure function on values, defined by converting its
+// operands to math/big integers, computing the mathematical result, and
+// reducing it modulo 2**256. Int has the layout of uint256.Int, four 64-bit
+// words with the least significant first, so the types convert to each
+// other. Signed operations interpret their operands as two's complement
+// numbers. As in the EVM, division and modular operations by zero give
+// zero.
+//
+// The uint256 package cross-checks its optimized operations against this
+// package when built with the uint256spec tag.
+package specuint256
+
+import "math/big"
+
+// Int is a 256-bit unsigned integer, as four 64-bit words with the least
+// significant first.
+type Int [4]uint64
+
+var (
+	modulus = new(big.Int).Lsh(big.NewInt(1), 256)
+	signBit = new(big.Int).Lsh(big.NewInt(1), 255)
+)
+
+// ToBig returns x as a big.Int.
+func ToBig(x Int) *big.Int {
+	b := new(big.Int)
+	for i := len(x) - 1; i >= 0; i-- {
+		b.Lsh(b, 64)
+		b.Or(b, new(big.Int).SetUint64(x[i]))
+	}
+	return b
+}
+
+// ToSignedBig returns x, interpreted as a two's complement number, as a
+// big.Int.
+func ToSignedBig(x Int) *big.Int {
+	b := ToBig(x)
+	if b.Cmp(signBit) >= 0 {
+		b.Sub(b, modulus)
+	}
+	return b
+}
+
+// FromBig returns b modulo 2**256. Negative numbers are reduced to their
+// two's complement.
+func FromBig(b *big.Int) Int {
+	r := new(big.Int).Mod(b, modulus)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	var z Int
+	for i := range z {
+		z[i] = new(big.Int).And(r, mask).Uint64()
+		r.Rsh(r, 64)
+	}
+	return z
+}
+
+// Add returns x + y mod 2**256.
+func Add(x, y Int) Int {
+	return FromBig(new(big.Int).Add(ToBig(x), ToBig(y)))
+}
+
+// AddOverflow returns x + y mod 2**256, and whether the sum overflowed.
+func AddOverflow(x, y Int) (Int, bool) {
+	sum := new(big.Int).Add(ToBig(x), ToBig(y))
+	return FromBig(sum), sum.Cmp(modulus) >= 0
+}
+
+// Sub returns x - y mod 2**256.
+func Sub(x, y Int) Int {
+	return FromBig(new(big.Int).Sub(ToBig(x), ToBig(y)))
+}
+
+// SubOverflow returns x - y mod 2**256, and whether the difference
+// underflowed.
+func SubOverflow(x, y Int) (Int, bool) {
+	diff := new(big.Int).Sub(ToBig(x), ToBig(y))
+	return FromBig(diff), diff.Sign() < 0
+}
+
+// Mul returns x * y mod 2**256.
+func Mul(x, y Int) Int {
+	return FromBig(new(big.Int).Mul(ToBig(x), ToBig(y)))
+}
+
+// Div returns the quotient x / y, or 0 if y is 0.
+func Div(x, y Int) Int {
+	if y == (Int{}) {
+		return Int{}
+	}
+	return FromBig(new(big.Int).Quo(ToBig(x), ToBig(y)))
+}
+
+// Mod returns the remainder x % y, or 0 if y is 0.
+func Mod(x, y Int) Int {
+	if y == (Int{}) {
+		return Int{}
+	}
+	return FromBig(new(big.Int).Rem(ToBig(x), ToBig(y)))
+}
+
+// SDiv returns the signed quotient x / y, rounded towards zero, or 0 if y
+// is 0. The quotient of -2**255 by -1 wraps around to -2**255.
+func SDiv(x, y Int) Int {
+	if y == (Int{}) {
+		return Int{}
+	}
+	return FromBig(new(big.Int).Quo(ToSignedBig(x), ToSignedBig(y)))
+}
+
+// SMod returns the signed remainder of x / y, which has the sign of x, or
+// 0 if y is 0.
+func SMod(x, y Int) Int {
+	if y == (Int{}) {
+		return Int{}
+	}
+	return FromBig(new(big.Int).Rem(ToSignedBig(x), ToSignedBig(y)))
+}
+
+// AddMod returns (x + y) % m, computed without overflow, or 0 if m is 0.
+func AddMod(x, y, m Int) Int {
+	if m == (Int{}) {
+		return Int{}
+	}
+	sum := new(big.Int).Add(ToBig(x), ToBig(y))
+	return FromBig(sum.Rem(sum, ToBig(m)))
+}
+
+// MulMod returns (x * y) % m, computed without overflow, or 0 if m is 0.
+func MulMod(x, y, m Int) Int {
+	if m == (Int{}) {
+		return Int{}
+	}
+	prod := new(big.Int).Mul(ToBig(x), ToBig(y))
+	return FromBig(prod.Rem(prod, ToBig(m)))
+}
+
+// Exp returns base**exponent mod 2**256.
+func Exp(base, exponent Int) Int {
+	return FromBig(new(big.Int).Exp(ToBig(base), ToBig(exponent), modulus))
+}
+
+// Neg returns -x mod 2**256.
+func Neg(x Int) Int {
+	return FromBig(new(big.Int).Neg(ToBig(x)))
+}
+
+// Abs returns the absolute value of x interpreted as a signed number. The
+// absolute value of -2**255 wraps around to -2**255.
+func Abs(x Int) Int {
+	return FromBig(new(big.Int).Abs(ToSignedBig(x)))
+}
+
+// Not returns the bitwise complement of x.
+func Not(x Int) Int {
+	return FromBig(new(big.Int).Sub(new(big.Int).Sub(modulus, big.NewInt(1)), ToBig(x)))
+}
+
+// And returns the bitwise and of x and y.
+func And(x, y Int) Int {
+	return FromBig(new(big.Int).And(ToBig(x), ToBig(y)))
+}
+
+// Or returns the bitwise or of x and y.
+func Or(x, y Int) Int {
+	return FromBig(new(big.Int).Or(ToBig(x), ToBig(y)))
+}
+
+// Xor returns the bitwise exclusive or of x and y.
+func Xor(x, y Int) Int {
+	return FromBig(new(big.Int).Xor(ToBig(x), ToBig(y)))
+}
+
+// Lsh returns x << n mod 2**256.
+func Lsh(x Int, n uint) Int {
+	if n > 256 {
+		n = 256 // Avoids huge intermediate numbers.
+	}
+	return FromBig(new(big.Int).Lsh(ToBig(x), n))
+}
+
+// Rsh returns x >> n, shifting in zeros.
+func Rsh(x Int, n uint) Int {
+	if n > 256 {
+		n = 256
+	}
+	return FromBig(new(big.Int).Rsh(ToBig(x), n))
+}
+
+// SRsh returns x >> n, interpreting x as a signed number, which shifts in
+// copies of the sign bit: the result is x / 2**n rounded towards negative
+// infinity.
+func SRsh(x Int, n uint) Int {
+	if n > 256 {
+		n = 256
+	}
+	return FromBig(new(big.Int).Rsh(ToSignedBig(x), n))
+}
+
+// Byte returns the n'th byte of x, counting from the most significant
+// byte, or 0 if n >= 32.
+func Byte(n, x Int) Int {
+	if Lt(n, Int{32}) {
+		shift := uint(8 * (31 - n[0]))
+		return And(Rsh(x, shift), Int{0xff})
+	}
+	return Int{}
+}
+
+// SignExtend returns x with the bits above bit 8*back+7 set to that bit,
+// extending the sign of the (back+1)-byte signed number in the low bytes
+// of x, or x if back >= 31.
+func SignExtend(back, x Int) Int {
+	if !Lt(back, Int{31}) {
+		return x
+	}
+	bits := uint(8*back[0] + 8)
+	low := new(big.Int).Mod(ToBig(x), new(big.Int).Lsh(big.NewInt(1), bits))
+	if low.Bit(int(bits-1)) == 1 {
+		low.Sub(low, new(big.Int).Lsh(big.NewInt(1), bits))
+	}
+	return FromBig(low)
+}
+
+// Cmp compares x and y, and returns -1, 0 or +1 for x < y, x == y and
+// x > y.
+func Cmp(x, y Int) int {
+	return ToBig(x).Cmp(ToBig(y))
+}
+
+// SCmp compares x and y as signed numbers, and returns -1, 0 or +1 for
+// x < y, x == y and x > y.
+func SCmp(x, y Int) int {
+	return ToSignedBig(x).Cmp(ToSignedBig(y))
+}
+
+// Lt reports whether x < y.
+func Lt(x, y Int) bool { return Cmp(x, y) < 0 }
+
+// Gt reports whether x > y.
+func Gt(x, y Int) bool { return Cmp(x, y) > 0 }
+
+// Slt reports whether x < y as signed numbers.
+func Slt(x, y Int) bool { return SCmp(x, y) < 0 }
+
+// Sgt reports whether x > y as signed numbers.
+func Sgt(x, y Int) bool { return SCmp(x, y) > 0 }
+
+// Eq reports whether x == y.
+func Eq(x, y Int) bool { return Cmp(x, y) == 0 }
+
+// IsZero reports whether x == 0.
+func IsZero(x Int) bool { return Eq(x, Int{}) }
+
+// Sign returns the sign of x as a signed number: -1, 0 or +1.
+func Sign(x Int) int {
+	return ToSignedBig(x).Sign()
+}
+
+// BitLen returns the number of bits required to represent x.
+func BitLen(x Int) int {
+	return ToBig(x).BitLen()
+}
+
+// Bool returns 1 if b is true, and 0 otherwise, as the EVM comparison
+// opcodes do.
+func Bool(b bool) Int {
+	if b {
+		return Int{1}
+	}
+	return Int{}
+}
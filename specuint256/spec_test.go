@@ -0,0 +1,153 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package specuint256_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	spec "github.com/holiman/uint256/specuint256"
+)
+
+func TestSpecValues(t *testing.T) {
+	max := spec.Int{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)}
+	minSigned := spec.Int{0, 0, 0, 1 << 63}
+	tests := []struct {
+		name       string
+		have, want spec.Int
+	}{
+		{"max+1", spec.Add(max, spec.Int{1}), spec.Int{}},
+		{"0-1", spec.Sub(spec.Int{}, spec.Int{1}), max},
+		{"max*max", spec.Mul(max, max), spec.Int{1}},
+		{"7/0", spec.Div(spec.Int{7}, spec.Int{}), spec.Int{}},
+		{"-7/2", spec.SDiv(spec.Neg(spec.Int{7}), spec.Int{2}), spec.Neg(spec.Int{3})},
+		{"-7%2", spec.SMod(spec.Neg(spec.Int{7}), spec.Int{2}), max},
+		{"min/-1", spec.SDiv(minSigned, max), minSigned},
+		{"addmod", spec.AddMod(max, spec.Int{2}, spec.Int{10}), spec.Int{7}},
+		{"mulmod", spec.MulMod(max, max, spec.Int{12}), spec.Int{9}},
+		{"2**255", spec.Exp(spec.Int{2}, spec.Int{255}), minSigned},
+		{"abs(min)", spec.Abs(minSigned), minSigned},
+		{"not(0)", spec.Not(spec.Int{}), max},
+		{"sar", spec.SRsh(minSigned, 1000), max},
+		{"byte", spec.Byte(spec.Int{31}, spec.Int{0x1234}), spec.Int{0x34}},
+		{"signextend", spec.SignExtend(spec.Int{0}, spec.Int{0x80}), spec.Sub(max, spec.Int{0x7f})},
+	}
+	for _, tc := range tests {
+		if tc.have != tc.want {
+			t.Errorf("%s: have %x, want %x", tc.name, tc.have, tc.want)
+		}
+	}
+}
+
+// operands returns edge cases and random values, some of them small.
+func operands() []spec.Int {
+	xs := []spec.Int{
+		{}, {1}, {2}, {31}, {32}, {255}, {256},
+		{^uint64(0)},
+		{0, 1},
+		{0, 0, 0, 1 << 63},
+		{1, 0, 0, 1 << 63},
+		{^uint64(0), ^uint64(0), ^uint64(0), 1<<63 - 1},
+		{^uint64(0), ^uint64(0), ^uint64(0), ^uint64(0)},
+		{^uint64(0) - 1, ^uint64(0), ^uint64(0), ^uint64(0)},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 12; i++ {
+		var x spec.Int
+		for j := 0; j <= i%4; j++ {
+			x[j] = rng.Uint64()
+		}
+		xs = append(xs, x)
+	}
+	return xs
+}
+
+// TestSpecMatchesUint256 checks the optimized operations of the uint256
+// package against the specification, for all pairs of operands.
+func TestSpecMatchesUint256(t *testing.T) {
+	u := func(x spec.Int) *uint256.Int { z := uint256.Int(x); return &z }
+	b := func(v bool) spec.Int { return spec.Bool(v) }
+	xs := operands()
+	for _, x := range xs {
+		for _, y := range xs {
+			check := func(op string, have *uint256.Int, want spec.Int) {
+				t.Helper()
+				if spec.Int(*have) != want {
+					t.Fatalf("%s(%x, %x): have %x, want %x", op, x, y, *have, want)
+				}
+			}
+			z := new(uint256.Int)
+			check("Add", z.Add(u(x), u(y)), spec.Add(x, y))
+			check("Sub", z.Sub(u(x), u(y)), spec.Sub(x, y))
+			check("Mul", z.Mul(u(x), u(y)), spec.Mul(x, y))
+			check("Div", z.Div(u(x), u(y)), spec.Div(x, y))
+			check("Mod", z.Mod(u(x), u(y)), spec.Mod(x, y))
+			check("Sdiv", z.Sdiv(u(x), u(y)), spec.SDiv(x, y))
+			check("Smod", z.Smod(u(x), u(y)), spec.SMod(x, y))
+			check("Exp", z.Exp(u(x), u(y)), spec.Exp(x, y))
+			check("And", z.And(u(x), u(y)), spec.And(x, y))
+			check("Or", z.Or(u(x), u(y)), spec.Or(x, y))
+			check("Xor", z.Xor(u(x), u(y)), spec.Xor(x, y))
+			check("ByteOf", z.ByteOf(u(x), u(y)), spec.Byte(x, y))
+			check("Lt", u(b(u(x).Lt(u(y)))), b(spec.Lt(x, y)))
+			check("Gt", u(b(u(x).Gt(u(y)))), b(spec.Gt(x, y)))
+			check("Slt", u(b(u(x).Slt(u(y)))), b(spec.Slt(x, y)))
+			check("Sgt", u(b(u(x).Sgt(u(y)))), b(spec.Sgt(x, y)))
+			check("Eq", u(b(u(x).Eq(u(y)))), b(spec.Eq(x, y)))
+			if have, want := u(x).Cmp(u(y)), spec.Cmp(x, y); have != want {
+				t.Fatalf("Cmp(%x, %x): have %d, want %d", x, y, have, want)
+			}
+			sum, overflow := spec.AddOverflow(x, y)
+			if have := z.AddOverflow(u(x), u(y)); have != overflow {
+				t.Fatalf("AddOverflow(%x, %x): have %v, want %v", x, y, have, overflow)
+			}
+			check("AddOverflow", z, sum)
+			diff, overflow := spec.SubOverflow(x, y)
+			if have := z.SubOverflow(u(x), u(y)); have != overflow {
+				t.Fatalf("SubOverflow(%x, %x): have %v, want %v", x, y, have, overflow)
+			}
+			check("SubOverflow", z, diff)
+			if y[1]|y[2]|y[3] == 0 && y[0] < 300 {
+				n := uint(y[0])
+				check("Lsh", z.Lsh(u(x), n), spec.Lsh(x, n))
+				check("Rsh", z.Rsh(u(x), n), spec.Rsh(x, n))
+				check("Srsh", z.Srsh(u(x), n), spec.SRsh(x, n))
+			}
+			if !spec.IsZero(y) {
+				for _, m := range []spec.Int{y, x} {
+					if spec.IsZero(m) {
+						continue
+					}
+					check("AddMod", z.AddMod(u(x), u(y), u(m)), spec.AddMod(x, y, m))
+					check("MulMod", z.MulMod(u(x), u(y), u(m)), spec.MulMod(x, y, m))
+				}
+			}
+			check("ExtendSign", z.ExtendSign(u(y), u(x)), spec.SignExtend(x, y))
+		}
+		check := func(op string, have *uint256.Int, want spec.Int) {
+			t.Helper()
+			if spec.Int(*have) != want {
+				t.Fatalf("%s(%x): have %x, want %x", op, x, *have, want)
+			}
+		}
+		z := new(uint256.Int)
+		check("NegOf", z.NegOf(u(x)), spec.Neg(x))
+		check("SAbs", z.SAbs(u(x)), spec.Abs(x))
+		check("NotOf", z.NotOf(u(x)), spec.Not(x))
+		check("SquareOf", z.SquareOf(u(x)), spec.Mul(x, x))
+		check("IsZero", u(b(u(x).IsZero())), b(spec.IsZero(x)))
+		if have, want := u(x).Sign(), spec.Sign(x); have != want {
+			t.Fatalf("Sign(%x): have %d, want %d", x, have, want)
+		}
+		if have, want := u(x).BitLen(), spec.BitLen(x); have != want {
+			t.Fatalf("BitLen(%x): have %d, want %d", x, have, want)
+		}
+		if have, want := u(x).ToBig(), spec.ToBig(x); have.Cmp(want) != 0 {
+			t.Fatalf("ToBig(%x): have %v, want %v", x, have, want)
+		}
+	}
+}
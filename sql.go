@@ -0,0 +1,170 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+)
+
+var (
+	errSQLType    = errors.New("uint256: unsupported sql source type")
+	errSQLArray   = errors.New("uint256: invalid postgres array")
+	errSQLNull    = errors.New("uint256: null element in postgres array")
+	errSQLElement = errors.New("uint256: invalid postgres array element")
+)
+
+// NumericArray is a slice of Ints which can be scanned from and written to a
+// Postgres numeric[] column, using the text array form, e.g. {1,2,3}. A nil
+// slice is written as NULL, and NULL is scanned into a nil slice.
+type NumericArray []Int
+
+// ByteaArray is a slice of Ints which can be scanned from and written to a
+// Postgres bytea[] column. Elements are written as 32-byte big-endian values
+// in the hex bytea format; when scanning, elements of up to 32 bytes are
+// accepted.
+type ByteaArray []Int
+
+// Value implements driver.Valuer.
+func (a NumericArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range a {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(a[i].Dec())
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+// Scan implements sql.Scanner. Both numeric and bytea elements are accepted.
+func (a *NumericArray) Scan(src interface{}) error {
+	return scanSQLArray((*[]Int)(a), src)
+}
+
+// Value implements driver.Valuer.
+func (a ByteaArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range a {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		// Array elements escape backslashes, so \x becomes "\\x".
+		sb.WriteString(`"\\x`)
+		b := a[i].Bytes32()
+		for _, c := range b {
+			sb.WriteByte(hexDigits[c>>4])
+			sb.WriteByte(hexDigits[c&0xf])
+		}
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String(), nil
+}
+
+// Scan implements sql.Scanner. Both numeric and bytea elements are accepted.
+func (a *ByteaArray) Scan(src interface{}) error {
+	return scanSQLArray((*[]Int)(a), src)
+}
+
+// scanSQLArray parses a one-dimensional Postgres text array into dst. On
+// error, dst is left unchanged.
+func scanSQLArray(dst *[]Int, src interface{}) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*dst = nil
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return errSQLType
+	}
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return errSQLArray
+	}
+	s = s[1 : len(s)-1]
+	res := make([]Int, 0, strings.Count(s, ",")+1)
+	for len(s) > 0 {
+		var (
+			elem   string
+			quoted bool
+		)
+		if s[0] == '"' {
+			// Quoted element, with backslash escapes.
+			var sb strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' {
+					i++
+					if i == len(s) {
+						break
+					}
+				}
+				sb.WriteByte(s[i])
+			}
+			if i >= len(s) {
+				return errSQLArray
+			}
+			elem, s, quoted = sb.String(), s[i+1:], true
+		} else {
+			i := strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			elem, s = s[:i], s[i:]
+		}
+		if len(s) > 0 {
+			if s[0] != ',' || len(s) == 1 {
+				return errSQLArray
+			}
+			s = s[1:]
+		}
+		if !quoted && strings.EqualFold(elem, "NULL") {
+			return errSQLNull
+		}
+		res = append(res, Int{})
+		if err := setSQLElement(&res[len(res)-1], elem); err != nil {
+			return err
+		}
+	}
+	*dst = res
+	return nil
+}
+
+// setSQLElement sets z from a numeric element, or a bytea element in hex
+// format.
+func setSQLElement(z *Int, s string) error {
+	if !strings.HasPrefix(s, `\x`) {
+		return z.SetFromDecimal(s)
+	}
+	s = s[2:]
+	if len(s)%2 != 0 || len(s) > 64 {
+		return errSQLElement
+	}
+	var b [32]byte
+	for i := 0; i < len(s); i += 2 {
+		hi, lo := hexValue(s[i]), hexValue(s[i+1])
+		if hi == 0xff || lo == 0xff {
+			return errSQLElement
+		}
+		b[i/2] = hi<<4 | lo
+	}
+	z.SetBytes(b[:len(s)/2])
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumericArrayRoundtrip(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		_, a, _ := randNums()
+		_, b, _ := randHighNums()
+		in := NumericArray{*a, *b, Int{}}
+		v, err := in.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out NumericArray
+		if err := out.Scan(v); err != nil {
+			t.Fatalf("scan %v: %v", v, err)
+		}
+		if len(out) != len(in) {
+			t.Fatalf("length %d, want %d", len(out), len(in))
+		}
+		for j := range in {
+			if !out[j].Eq(&in[j]) {
+				t.Fatalf("element %d: have %v, want %v", j, &out[j], &in[j])
+			}
+		}
+	}
+}
+
+func TestByteaArrayRoundtrip(t *testing.T) {
+	in := ByteaArray{Int{1}, *new(Int).SetAllOne()}
+	v, err := in.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"\\x` + strings.Repeat("00", 31) + `01","\\x` + strings.Repeat("ff", 32) + `"}`
+	if v != want {
+		t.Fatalf("have %v\nwant %v", v, want)
+	}
+	var out ByteaArray
+	if err := out.Scan([]byte(v.(string))); err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || !out[0].Eq(&in[0]) || !out[1].Eq(&in[1]) {
+		t.Fatalf("have %v, want %v", out, in)
+	}
+}
+
+func TestSQLArrayScan(t *testing.T) {
+	for _, tc := range []struct {
+		src  interface{}
+		want []uint64
+		ok   bool
+	}{
+		{"{}", []uint64{}, true},
+		{"{0}", []uint64{0}, true},
+		{"{1,22,333}", []uint64{1, 22, 333}, true},
+		{`{"\\x01","\\x0100",7}`, []uint64{1, 256, 7}, true},
+		{[]byte(`{"\\x"}`), []uint64{0}, true},
+		{"", nil, false},
+		{"1,2", nil, false},
+		{"{1,}", nil, false},
+		{"{,1}", nil, false},
+		{"{1,NULL}", nil, false},
+		{"{-1}", nil, false},
+		{"{1.5}", nil, false},
+		{"{{1,2}}", nil, false},
+		{`{"\\x1"}`, nil, false},
+		{`{"\\xzz"}`, nil, false},
+		{`{"\\x` + strings.Repeat("00", 33) + `"}`, nil, false},
+		{`{"1}`, nil, false},
+		{42, nil, false},
+	} {
+		var a NumericArray
+		err := a.Scan(tc.src)
+		if !tc.ok {
+			if err == nil {
+				t.Errorf("Scan(%v): expected error", tc.src)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%v): %v", tc.src, err)
+			continue
+		}
+		if len(a) != len(tc.want) {
+			t.Errorf("Scan(%v): have %v, want %v", tc.src, a, tc.want)
+			continue
+		}
+		for i, w := range tc.want {
+			if !a[i].Eq(new(Int).SetUint64(w)) {
+				t.Errorf("Scan(%v)[%d]: have %v, want %d", tc.src, i, &a[i], w)
+			}
+		}
+	}
+}
+
+func TestSQLArrayNull(t *testing.T) {
+	a := NumericArray{Int{1}}
+	if err := a.Scan(nil); err != nil || a != nil {
+		t.Fatalf("Scan(nil): have %v, %v", a, err)
+	}
+	if v, err := a.Value(); v != nil || err != nil {
+		t.Fatalf("nil Value: have %v, %v", v, err)
+	}
+	if v, err := ByteaArray(nil).Value(); v != nil || err != nil {
+		t.Fatalf("nil Value: have %v, %v", v, err)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"math/bits"
+)
+
+const (
+	// MaxBase is the largest base accepted by Text and SetText, as for
+	// big.MaxBase.
+	MaxBase = 10 + ('z' - 'a' + 1) + ('Z' - 'A' + 1)
+
+	textDigits = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+var (
+	errBase  = errors.New("uint256: base out of range")
+	errDigit = errors.New("uint256: invalid digit for base")
+)
+
+// bigBase returns the largest power of base which fits in a uint64, and its
+// exponent.
+func bigBase(base uint64) (bb uint64, n int) {
+	bb, n = base, 1
+	for {
+		hi, lo := bits.Mul64(bb, base)
+		if hi != 0 {
+			return bb, n
+		}
+		bb, n = lo, n+1
+	}
+}
+
+// textValue returns the value of the digit c in the given base, or base if
+// c is not a digit of it. As with big.Int, letters of either case are the
+// same digit for bases up to 36, and upper-case letters are the digits 36 to
+// 61 above that.
+func textValue(c byte, base uint64) uint64 {
+	var v uint64
+	switch {
+	case c >= '0' && c <= '9':
+		v = uint64(c - '0')
+	case c >= 'a' && c <= 'z':
+		v = uint64(c-'a') + 10
+	case c >= 'A' && c <= 'Z':
+		if base <= 36 {
+			v = uint64(c-'A') + 10
+		} else {
+			v = uint64(c-'A') + 36
+		}
+	default:
+		return base
+	}
+	if v >= base {
+		return base
+	}
+	return v
+}
+
+// Text returns the representation of z in the given base, which must be
+// between 2 and MaxBase, using lower-case letters for the digits 10 to 35 and
+// upper-case letters for the digits 36 to 61, without prefix or leading
+// zeros. It panics if the base is out of range.
+func (z *Int) Text(base int) string {
+	if base < 2 || base > MaxBase {
+		panic("uint256: Text with base out of range")
+	}
+	if base == 10 {
+		return z.Dec()
+	}
+	var (
+		buf   [256]byte // enough for base 2
+		pos   = len(buf)
+		y     Int
+		b     = uint64(base)
+		bb, n = bigBase(b)
+	)
+	if z != nil {
+		y = *z
+	}
+	for {
+		rem := y.divRem64(&y, bb)
+		if y.IsZero() {
+			// The most significant chunk, which is not padded.
+			for {
+				pos--
+				buf[pos] = textDigits[rem%b]
+				if rem /= b; rem == 0 {
+					return string(buf[pos:])
+				}
+			}
+		}
+		for i := 0; i < n; i++ {
+			pos--
+			buf[pos] = textDigits[rem%b]
+			rem /= b
+		}
+	}
+}
+
+// SetText sets z from the representation s in the given base, which must be
+// between 2 and MaxBase, with digits as in Text; letters of either case are
+// accepted for bases up to 36. Unlike big.Int.SetString, there is no base
+// prefix, sign or underscore, but leading zeros are allowed. The value must
+// be at most 2**256 - 1. On error, z is left unchanged. Errors for invalid
+// input are of type *ParseError. The policy is TextOptions, with the given
+// Base.
+func (z *Int) SetText(s string, base int) error {
+	if z == nil {
+		panic(nilReceiver("SetText"))
+	}
+	if base < 2 || base > MaxBase {
+		return errBase
+	}
+	opts := textOptions
+	opts.Base = base
+	return z.parse(s, &opts)
+}
+
+// setTextDigits sets z from the valid digits s in base, returning -1 and an
+// error on overflow.
+func (z *Int) setTextDigits(s string, b uint64) (int, error) {
+	var (
+		res   Int
+		bb, n = bigBase(b)
+	)
+	for pos := 0; pos < len(s); {
+		chunk, mul := n, bb
+		if len(s)-pos < n {
+			chunk, mul = len(s)-pos, 1
+			for i := 0; i < chunk; i++ {
+				mul *= b
+			}
+		}
+		var v uint64
+		for i := pos; i < pos+chunk; i++ {
+			v = v*b + textValue(s[i], b)
+		}
+		if res.mulAdd64(mul, v) {
+			return -1, errOverflow
+		}
+		pos += chunk
+	}
+	z.Copy(&res)
+	return 0, nil
+}
+
+// FromText is a convenience-constructor for SetText.
+func FromText(s string, base int) (*Int, error) {
+	z := new(Int)
+	if err := z.SetText(s, base); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	max := new(Int).SetAllOne()
+	for base := 2; base <= MaxBase; base++ {
+		for _, x := range []*Int{new(Int), new(Int).SetUint64(uint64(base - 1)), new(Int).SetUint64(uint64(base)), max} {
+			if got, exp := x.Text(base), x.ToBig().Text(base); got != exp {
+				t.Errorf("base %d: got %s, exp %s", base, got, exp)
+			}
+		}
+		for i := 0; i < 20; i++ {
+			b, x, _ := randNums()
+			if got, exp := x.Text(base), b.Text(base); got != exp {
+				t.Fatalf("base %d: got %s, exp %s", base, got, exp)
+			}
+		}
+	}
+}
+
+func TestTextInvalidBase(t *testing.T) {
+	for _, base := range []int{-1, 0, 1, MaxBase + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Text(%d): expected panic", base)
+				}
+			}()
+			new(Int).Text(base)
+		}()
+	}
+}
+
+func TestSetText(t *testing.T) {
+	for base := 2; base <= MaxBase; base++ {
+		for i := 0; i < 20; i++ {
+			b, x, _ := randHighNums()
+			s := b.Text(base)
+			z := new(Int)
+			if err := z.SetText(s, base); err != nil {
+				t.Fatalf("base %d, %s: %v", base, s, err)
+			}
+			if !z.Eq(x) {
+				t.Fatalf("base %d, %s: got %v, exp %v", base, s, z.Hex(), x.Hex())
+			}
+			if base <= 36 {
+				if err := z.SetText(strings.ToUpper(s), base); err != nil || !z.Eq(x) {
+					t.Fatalf("base %d, upper case %s: got %v, %v", base, s, z.Hex(), err)
+				}
+			}
+		}
+	}
+}
+
+func TestSetTextErrors(t *testing.T) {
+	overflow := new(big.Int).Lsh(big.NewInt(1), 256)
+	for _, tc := range []struct {
+		s    string
+		base int
+		err  error
+	}{
+		{"0", 1, errBase},
+		{"0", MaxBase + 1, errBase},
+		{"", 10, errEmptyString},
+		{"2", 2, errDigit},
+		{"-1", 10, errDecimalSyntax},
+		{"0x1", 16, errHexSyntax},
+		{"1_000", 10, errDecimalSyntax},
+		{"Z", 36 + 25, errDigit},
+		{overflow.Text(2), 2, errOverflow},
+		{overflow.Text(7), 7, errOverflow},
+		{overflow.Text(62), 62, errOverflow},
+	} {
+		z := new(Int).SetUint64(42)
+		if err := z.SetText(tc.s, tc.base); !errors.Is(err, tc.err) {
+			t.Errorf("SetText(%q, %d): got err %v, exp %v", tc.s, tc.base, err, tc.err)
+		}
+		if z.Uint64() != 42 {
+			t.Errorf("SetText(%q, %d): modified z on error", tc.s, tc.base)
+		}
+	}
+	// Leading zeros are allowed.
+	if z, err := FromText("000000000000000000000000000000000000000000000000000000000000000000000000000000000000101", 2); err != nil || z.Uint64() != 5 {
+		t.Errorf("leading zeros: got %v, %v", z, err)
+	}
+	if z, err := FromText("Z", 62); err != nil || z.Uint64() != 61 {
+		t.Errorf("Z in base 62: got %v, %v", z, err)
+	}
+}
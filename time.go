@@ -0,0 +1,104 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	nanosPerSecond = 1000000000
+
+	// maxUnixSeconds is the largest Unix time (in seconds) representable by a
+	// time.Time, which internally counts seconds since year 1.
+	maxUnixSeconds = math.MaxInt64 - 62135596800
+)
+
+// saturatedInt64 interprets z as a signed number, and returns it as an int64,
+// clamped to the range [math.MinInt64, math.MaxInt64].
+func (z *Int) saturatedInt64() int64 {
+	if z.IsNegative() {
+		if z[3]&z[2]&z[1] == math.MaxUint64 && z[0]>>63 == 1 {
+			return int64(z[0])
+		}
+		return math.MinInt64
+	}
+	if z.IsUint64() && z[0]>>63 == 0 {
+		return int64(z[0])
+	}
+	return math.MaxInt64
+}
+
+// setInt64 sets z to the two's complement representation of x, and returns z.
+func (z *Int) setInt64(x int64) *Int {
+	z.SetUint64(uint64(x))
+	if x < 0 {
+		z[3], z[2], z[1] = math.MaxUint64, math.MaxUint64, math.MaxUint64
+	}
+	return z
+}
+
+// SetFromDuration sets z to the number of nanoseconds in d, and returns z.
+// Negative durations are stored in two's complement form, like SetFromBig
+// does for negative numbers.
+func (z *Int) SetFromDuration(d time.Duration) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromDuration"))
+	}
+	return z.setInt64(int64(d))
+}
+
+// ToDuration interprets z as a signed number of nanoseconds, and returns it
+// as a time.Duration. Values outside the range of time.Duration saturate to
+// the minimum or maximum duration.
+func (z *Int) ToDuration() time.Duration {
+	z = orZero(z)
+	return time.Duration(z.saturatedInt64())
+}
+
+// SetFromTime sets z to t as the number of nanoseconds elapsed since
+// January 1, 1970 UTC, and returns z. Unlike t.UnixNano, the result is exact
+// for all times. Times before 1970 are stored in two's complement form.
+func (z *Int) SetFromTime(t time.Time) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromTime"))
+	}
+	var sec, nsec Int
+	sec.setInt64(t.Unix())
+	nsec.SetUint64(uint64(t.Nanosecond()))
+	z.Mul(&sec, &Int{nanosPerSecond})
+	return z.Add(z, &nsec)
+}
+
+// ToTime interprets z as a signed number of nanoseconds since January 1, 1970
+// UTC, and returns the corresponding local time, as time.Unix does. Values
+// outside the range of time.Time saturate to the earliest or latest
+// representable time.
+func (z *Int) ToTime() time.Time {
+	var (
+		abs = z.Clone()
+		sec Int
+		rem Int
+	)
+	negative := z.IsNegative()
+	if negative {
+		abs.NegOf(abs)
+	}
+	sec.Div(abs, &Int{nanosPerSecond})
+	rem.Mod(abs, &Int{nanosPerSecond})
+	if !negative {
+		if sec.GtUint64(maxUnixSeconds) {
+			return time.Unix(maxUnixSeconds, nanosPerSecond-1)
+		}
+		return time.Unix(int64(sec[0]), int64(rem[0]))
+	}
+	if sec.GtUint64(math.MaxInt64) {
+		return time.Unix(math.MinInt64, 0)
+	}
+	// time.Unix normalizes the negative nanoseconds.
+	return time.Unix(-int64(sec[0]), -int64(rem[0]))
+}
@@ -0,0 +1,62 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, 1, -1, time.Hour, -time.Hour, math.MaxInt64, math.MinInt64} {
+		z := new(Int).SetFromDuration(d)
+		requireEq(t, U256(big.NewInt(int64(d))), z, d.String())
+		if got := z.ToDuration(); got != d {
+			t.Errorf("got %v exp %v", got, d)
+		}
+	}
+	// Saturation.
+	z := new(Int).SetUint64(math.MaxInt64)
+	if got := z.Add(z, new(Int).SetOne()).ToDuration(); got != math.MaxInt64 {
+		t.Errorf("got %v", got)
+	}
+	if got := MaxInt256().ToDuration(); got != math.MaxInt64 {
+		t.Errorf("got %v", got)
+	}
+	if got := MinInt256().ToDuration(); got != math.MinInt64 {
+		t.Errorf("got %v", got)
+	}
+	z = new(Int).SetFromDuration(math.MinInt64)
+	if got := z.Sub(z, new(Int).SetOne()).ToDuration(); got != math.MinInt64 {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestTime(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Unix(0, 0),
+		time.Unix(1584748800, 123456789),
+		time.Unix(-1, 1),
+		time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(3000, time.January, 1, 0, 0, 0, 999999999, time.UTC),
+	} {
+		z := new(Int).SetFromTime(tm)
+		exp := new(big.Int).Mul(big.NewInt(tm.Unix()), big.NewInt(1e9))
+		exp.Add(exp, big.NewInt(int64(tm.Nanosecond())))
+		requireEq(t, U256(exp), z, tm.String())
+		if got := z.ToTime(); !got.Equal(tm) {
+			t.Errorf("got %v exp %v", got, tm)
+		}
+	}
+	if got := new(Int).SetAllOne().Rsh(new(Int).SetAllOne(), 1).ToTime(); got.Unix() != maxUnixSeconds {
+		t.Errorf("got %v", got.Unix())
+	}
+	if got := MinInt256().ToTime(); got.Unix() != math.MinInt64 {
+		t.Errorf("got %v", got.Unix())
+	}
+}
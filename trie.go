@@ -0,0 +1,222 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// trieKeyLen is the number of nibbles in a trie key.
+const trieKeyLen = 64
+
+// trieNode is a node of a Trie. Its path holds the nibbles below the nibble
+// which selected it in its parent, compressed into a single node. A node at
+// the end of a key is a leaf, and holds a value, any other is a branch of at
+// least two children.
+type trieNode struct {
+	path     []byte
+	children [16]*trieNode
+	value    interface{}
+	leaf     bool
+}
+
+// Trie is an in-memory radix tree mapping Ints to values, which iterates in
+// ascending order of keys. Keys are split into 64 nibbles, most significant
+// first, and chains of single-child nodes are compressed. The zero value is an
+// empty Trie. A Trie is not safe for concurrent modification.
+type Trie struct {
+	root *trieNode
+	size int
+}
+
+// trieNibbles returns the nibbles of k, most significant first.
+func trieNibbles(k *Int) (nib [trieKeyLen]byte) {
+	for i := range nib {
+		nib[i] = byte(k[3-i/16]>>(60-4*uint(i%16))) & 0xf
+	}
+	return nib
+}
+
+// setNibbles sets z to the key with the given nibbles, and returns z.
+func (z *Int) setNibbles(nib *[trieKeyLen]byte) *Int {
+	z.Clear()
+	for i, n := range nib {
+		z[3-i/16] |= uint64(n) << (60 - 4*uint(i%16))
+	}
+	return z
+}
+
+// commonPrefix returns the length of the common prefix of a and b.
+func commonPrefix(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Len returns the number of keys in t.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Get returns the value of k, and whether it is present.
+func (t *Trie) Get(k *Int) (interface{}, bool) {
+	nib := trieNibbles(k)
+	key := nib[:]
+	n := t.root
+	for n != nil {
+		if commonPrefix(n.path, key) != len(n.path) {
+			return nil, false
+		}
+		if n.leaf {
+			return n.value, true
+		}
+		key = key[len(n.path):]
+		n, key = n.children[key[0]], key[1:]
+	}
+	return nil, false
+}
+
+// Put sets the value of k to v.
+func (t *Trie) Put(k *Int, v interface{}) {
+	nib := trieNibbles(k)
+	var added bool
+	t.root, added = t.put(t.root, nib[:], v)
+	if added {
+		t.size++
+	}
+}
+
+// put sets the value of key in the subtree n, and returns its new root, and
+// whether key was added.
+func (t *Trie) put(n *trieNode, key []byte, v interface{}) (*trieNode, bool) {
+	if n == nil {
+		return &trieNode{path: append([]byte(nil), key...), value: v, leaf: true}, true
+	}
+	common := commonPrefix(n.path, key)
+	if common == len(n.path) {
+		if n.leaf {
+			n.value = v
+			return n, false
+		}
+		c := key[common]
+		var added bool
+		n.children[c], added = t.put(n.children[c], key[common+1:], v)
+		return n, added
+	}
+	// Split n where key diverges.
+	branch := &trieNode{path: n.path[:common:common]}
+	branch.children[n.path[common]] = n
+	n.path = n.path[common+1:]
+	branch.children[key[common]] = &trieNode{
+		path:  append([]byte(nil), key[common+1:]...),
+		value: v,
+		leaf:  true,
+	}
+	return branch, true
+}
+
+// Delete removes k, and reports whether it was present.
+func (t *Trie) Delete(k *Int) bool {
+	nib := trieNibbles(k)
+	var deleted bool
+	t.root, deleted = t.delete(t.root, nib[:])
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// delete removes key from the subtree n, and returns its new root, and
+// whether key was present.
+func (t *Trie) delete(n *trieNode, key []byte) (*trieNode, bool) {
+	if n == nil || commonPrefix(n.path, key) != len(n.path) {
+		return n, false
+	}
+	if n.leaf {
+		return nil, true
+	}
+	key = key[len(n.path):]
+	child, deleted := t.delete(n.children[key[0]], key[1:])
+	if !deleted {
+		return n, false
+	}
+	n.children[key[0]] = child
+	// A branch left with a single child is merged into it.
+	only := -1
+	for i, c := range n.children {
+		if c != nil {
+			if only >= 0 {
+				return n, true
+			}
+			only = i
+		}
+	}
+	c := n.children[only]
+	path := make([]byte, 0, len(n.path)+1+len(c.path))
+	path = append(append(append(path, n.path...), byte(only)), c.path...)
+	c.path = path
+	return c, true
+}
+
+// Walk calls f for each key and value in t, in ascending order of keys,
+// until f returns false. f must not modify t, or retain k.
+func (t *Trie) Walk(f func(k *Int, v interface{}) bool) {
+	t.Range(new(Int), new(Int).SetAllOne(), f)
+}
+
+// Range calls f for each key in the inclusive range [start, end] and its
+// value, in ascending order of keys, until f returns false. f must not
+// modify t, or retain k.
+func (t *Trie) Range(start, end *Int, f func(k *Int, v interface{}) bool) {
+	if t.root == nil || start.Gt(end) {
+		return
+	}
+	w := trieWalker{lo: trieNibbles(start), hi: trieNibbles(end), f: f}
+	w.walk(t.root, 0)
+}
+
+// trieWalker holds the state of a Range.
+type trieWalker struct {
+	lo, hi [trieKeyLen]byte
+	buf    [trieKeyLen]byte // Nibbles of the current key.
+	key    Int
+	f      func(k *Int, v interface{}) bool
+}
+
+// compare compares prefix with the prefix of bound of the same length.
+func (w *trieWalker) compare(prefix, bound []byte) int {
+	for i, n := range prefix {
+		if n != bound[i] {
+			if n < bound[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// walk visits the subtree n, whose path starts at nibble depth, and reports
+// whether to continue.
+func (w *trieWalker) walk(n *trieNode, depth int) bool {
+	end := depth + copy(w.buf[depth:], n.path)
+	prefix := w.buf[:end]
+	// Skip subtrees entirely outside the range.
+	if w.compare(prefix, w.lo[:]) < 0 || w.compare(prefix, w.hi[:]) > 0 {
+		return true
+	}
+	if n.leaf {
+		return w.f(w.key.setNibbles(&w.buf), n.value)
+	}
+	for i, c := range n.children {
+		if c == nil {
+			continue
+		}
+		w.buf[end] = byte(i)
+		if !w.walk(c, end+1) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// trieKeys returns the keys of t between start and end, in Range order.
+func trieKeys(t *Trie, start, end *Int) []Int {
+	var keys []Int
+	t.Range(start, end, func(k *Int, _ interface{}) bool {
+		keys = append(keys, *k)
+		return true
+	})
+	return keys
+}
+
+func TestTrie(t *testing.T) {
+	var (
+		trie Trie
+		ref  = make(map[Int]int)
+		rnd  = rand.New(rand.NewSource(1))
+	)
+	// Keys sharing long prefixes exercise splitting and merging of paths.
+	randKey := func() Int {
+		var k Int
+		switch rnd.Intn(3) {
+		case 0:
+			k.SetUint64(uint64(rnd.Intn(64)))
+		case 1:
+			k = Int{uint64(rnd.Intn(64)), 0, 0, 1 << 63}
+		default:
+			k = Int{rnd.Uint64(), rnd.Uint64(), rnd.Uint64(), rnd.Uint64()}
+		}
+		return k
+	}
+	for i := 0; i < 5000; i++ {
+		k := randKey()
+		if rnd.Intn(3) == 0 {
+			_, want := ref[k]
+			if have := trie.Delete(&k); have != want {
+				t.Fatalf("Delete(%v): have %v, want %v", &k, have, want)
+			}
+			delete(ref, k)
+		} else {
+			trie.Put(&k, i)
+			ref[k] = i
+		}
+		if trie.Len() != len(ref) {
+			t.Fatalf("Len: have %d, want %d", trie.Len(), len(ref))
+		}
+	}
+	for k, want := range ref {
+		k := k
+		if have, ok := trie.Get(&k); !ok || have != want {
+			t.Errorf("Get(%v): have %v %v, want %v", &k, have, ok, want)
+		}
+	}
+	missing := Int{1, 2, 3, 4}
+	if _, ok := trie.Get(&missing); ok {
+		t.Errorf("Get of missing key succeeded")
+	}
+
+	sorted := make([]Int, 0, len(ref))
+	for k := range ref {
+		sorted = append(sorted, k)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Lt(&sorted[j]) })
+
+	var all []Int
+	trie.Walk(func(k *Int, v interface{}) bool {
+		if v != ref[*k] {
+			t.Errorf("Walk: value of %v: have %v, want %v", k, v, ref[*k])
+		}
+		all = append(all, *k)
+		return true
+	})
+	if len(all) != len(sorted) {
+		t.Fatalf("Walk: have %d keys, want %d", len(all), len(sorted))
+	}
+	for i := range all {
+		if all[i] != sorted[i] {
+			t.Fatalf("Walk: key %d: have %v, want %v", i, &all[i], &sorted[i])
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		start, end := randKey(), randKey()
+		var want []Int
+		for _, k := range sorted {
+			if !k.Lt(&start) && !k.Gt(&end) {
+				want = append(want, k)
+			}
+		}
+		have := trieKeys(&trie, &start, &end)
+		if len(have) != len(want) {
+			t.Fatalf("Range(%v, %v): have %d keys, want %d", &start, &end, len(have), len(want))
+		}
+		for j := range have {
+			if have[j] != want[j] {
+				t.Fatalf("Range(%v, %v): key %d: have %v, want %v", &start, &end, j, &have[j], &want[j])
+			}
+		}
+	}
+
+	for _, k := range sorted {
+		k := k
+		trie.Delete(&k)
+	}
+	if trie.Len() != 0 || trie.root != nil {
+		t.Errorf("not empty after deleting all keys")
+	}
+}
+
+func TestTrieRangeStop(t *testing.T) {
+	var trie Trie
+	for i := uint64(0); i < 10; i++ {
+		trie.Put(NewInt().SetUint64(i), nil)
+	}
+	var n int
+	trie.Range(NewInt().SetUint64(3), new(Int).SetAllOne(), func(k *Int, _ interface{}) bool {
+		if k.Uint64() != uint64(3+n) {
+			t.Errorf("have %v, want %d", k, 3+n)
+		}
+		n++
+		return n < 4
+	})
+	if n != 4 {
+		t.Errorf("have %d calls, want 4", n)
+	}
+}
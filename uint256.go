@@ -9,30 +9,90 @@ package uint256
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/bits"
 )
 
 var (
-	SignedMax = &Int{
-		0xffffffffffffffff,
-		0xffffffffffffffff,
-		0xffffffffffffffff,
-		0x7fffffffffffffff,
-	}
-	SignedMin = &Int{
-		0x0000000000000000,
-		0x0000000000000000,
-		0x0000000000000000,
-		0x8000000000000000,
-	}
+	// SignedMax is 2**255-1, the largest signed number.
+	//
+	// Deprecated: SignedMax can be modified by any caller, corrupting it for
+	// all others. Use MaxInt256, which returns a new copy.
+	SignedMax = MaxInt256()
+	// SignedMin is -2**255, the smallest signed number.
+	//
+	// Deprecated: SignedMin can be modified by any caller, corrupting it for
+	// all others. Use MinInt256, which returns a new copy.
+	SignedMin = MinInt256()
 )
 
+// MaxUint256 returns a new Int set to 2**256-1, the largest value.
+func MaxUint256() *Int {
+	return &Int{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}
+}
+
+// MinUint256 returns a new Int set to 0, the smallest value.
+func MinUint256() *Int {
+	return &Int{}
+}
+
+// MaxInt256 returns a new Int set to 2**255-1, the largest value when
+// interpreted as a two's complement signed number.
+func MaxInt256() *Int {
+	return &Int{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxInt64}
+}
+
+// MinInt256 returns a new Int set to -2**255, i.e. 2**255, the smallest
+// value when interpreted as a two's complement signed number.
+func MinInt256() *Int {
+	return &Int{0, 0, 0, 1 << 63}
+}
+
+var errSliceShort = errors.New("uint256: value does not fit in destination slice")
+
 // Int is represented as an array of 4 uint64, in little-endian order,
 // so that Int[3] is the most significant, and Int[0] is the least significant
+//
+// This layout is part of the API, and will not change: an Int is 32 bytes
+// with the alignment of a uint64 and no padding or pointers, holding the
+// limbs least significant first, each in the byte order of the platform. On
+// little-endian platforms its memory is thus the 32-byte little-endian form
+// of the value, the layout of 256-bit types in C and Rust libraries such as
+// intx and ruint, and a pointer to it, as returned by Limbs, may be passed to
+// such code through cgo. A []Int is a contiguous array of such values.
+//
+// A nil *Int is treated as zero by the methods which only read z, such as
+// the queries IsZero, IsOne and BitLen, the conversions Uint64, AsUint64,
+// ToBig and WordsBE, and the encoders Bytes32, Hex, PutCompact and
+// PutUvarint, and it is formatted as <nil> like a nil big.Int. The setters
+// and decoders, such as SetUint64, SetBytes and SetFromHex, panic with an
+// error naming the method when z is nil. Arithmetic, bitwise and comparison
+// methods, such as Add, Lsh and Cmp, do not check z or their operands, for
+// speed: none of them may be nil.
 type Int [4]uint64
 
+// nilReceiver is the panic value of a method which sets z, called on a nil
+// *Int. It names the method.
+type nilReceiver string
+
+func (method nilReceiver) Error() string {
+	return "uint256: " + string(method) + " called on nil *Int"
+}
+
+// zero is substituted for a nil receiver by the methods which only read it.
+// It must not be modified.
+var zero Int
+
+// orZero returns x, or zero if x is nil.
+func orZero(x *Int) *Int {
+	if x == nil {
+		return &zero
+	}
+	return x
+}
+
 func NewInt() *Int {
 	return &Int{}
 }
@@ -40,6 +100,9 @@ func NewInt() *Int {
 // SetBytes interprets buf as the bytes of a big-endian unsigned
 // integer, sets z to that value, and returns z.
 func (z *Int) SetBytes(buf []byte) *Int {
+	if z == nil {
+		panic(nilReceiver("SetBytes"))
+	}
 	var d uint64
 	k := 0
 	s := uint64(0)
@@ -64,36 +127,88 @@ func (z *Int) SetBytes(buf []byte) *Int {
 
 // Bytes32 returns a the a 32 byte big-endian array.
 func (z *Int) Bytes32() [32]byte {
-	// The PutUint64()s are inlined and we get 4x (load, bswap, store) instructions.
 	var b [32]byte
-	binary.BigEndian.PutUint64(b[0:8], z[3])
-	binary.BigEndian.PutUint64(b[8:16], z[2])
-	binary.BigEndian.PutUint64(b[16:24], z[1])
-	binary.BigEndian.PutUint64(b[24:32], z[0])
+	if z == nil {
+		return b
+	}
+	z.PutBytes(b[:], 32, BigEndian)
 	return b
 }
 
 // Bytes20 returns a the a 32 byte big-endian array.
 func (z *Int) Bytes20() [20]byte {
 	var b [20]byte
-	// The PutUint*()s are inlined and we get 3x (load, bswap, store) instructions.
-	binary.BigEndian.PutUint32(b[0:4], uint32(z[2]))
-	binary.BigEndian.PutUint64(b[4:12], z[1])
-	binary.BigEndian.PutUint64(b[12:20], z[0])
+	if z == nil {
+		return b
+	}
+	z.PutBytes(b[:], 20, BigEndian)
 	return b
 }
 
+// PutBytes writes the low width bytes of z to dst[:width], in the given
+// order. The rest of dst is left untouched. It panics if width is not between
+// 0 and 32, or if dst is shorter than width.
+func (z *Int) PutBytes(dst []byte, width int, order Endianness) {
+	z = orZero(z)
+	if width < 0 || width > 32 {
+		panic("uint256: PutBytes width out of range")
+	}
+	if len(dst) < width {
+		panic("uint256: PutBytes destination shorter than width")
+	}
+	if order == BigEndian {
+		if width == 32 {
+			// The PutUint64()s are inlined and we get 4x (load, bswap, store) instructions.
+			binary.BigEndian.PutUint64(dst[0:8], z[3])
+			binary.BigEndian.PutUint64(dst[8:16], z[2])
+			binary.BigEndian.PutUint64(dst[16:24], z[1])
+			binary.BigEndian.PutUint64(dst[24:32], z[0])
+			return
+		}
+		for i := 0; i < width; i++ {
+			dst[width-1-i] = byte(z[i/8] >> uint64(8*(i%8)))
+		}
+		return
+	}
+	if width == 32 {
+		binary.LittleEndian.PutUint64(dst[0:8], z[0])
+		binary.LittleEndian.PutUint64(dst[8:16], z[1])
+		binary.LittleEndian.PutUint64(dst[16:24], z[2])
+		binary.LittleEndian.PutUint64(dst[24:32], z[3])
+		return
+	}
+	for i := 0; i < width; i++ {
+		dst[i] = byte(z[i/8] >> uint64(8*(i%8)))
+	}
+}
+
 // Bytes returns the value of z as a big-endian byte slice.
 func (z *Int) Bytes() []byte {
 	b := z.Bytes32()
 	return b[32-z.ByteLen():]
 }
 
+// AppendBytes appends the value of z, as returned by Bytes, to dst and
+// returns the extended slice. It does not allocate if dst has room for
+// z.ByteLen() more bytes.
+func (z *Int) AppendBytes(dst []byte) []byte {
+	b := z.Bytes32()
+	return append(dst, b[32-z.ByteLen():]...)
+}
+
+// BytesTo returns the value of z, as returned by Bytes, using the storage of
+// buf if its capacity is large enough, i.e. z.AppendBytes(buf[:0]). A buf of
+// 32 bytes can be reused for every value.
+func (z *Int) BytesTo(buf []byte) []byte {
+	return z.AppendBytes(buf[:0])
+}
+
 // WriteToSlice writes the content of z into the given byteslice.
 // If dest is larger than 32 bytes, z will fill the first parts, and leave
 // the end untouched.
 // OBS! If dest is smaller than 32 bytes, only the end parts of z will be used
-// for filling the array, making it useful for filling an Address object
+// for filling the array, making it useful for filling an Address object.
+// The truncation is silent; WriteToSliceChecked reports it instead.
 func (z *Int) WriteToSlice(dest []byte) {
 	// ensure 32 bytes
 	// A too large buffer. Fill last 32 bytes
@@ -106,18 +221,53 @@ func (z *Int) WriteToSlice(dest []byte) {
 	}
 }
 
+// WriteToSliceChecked writes z into the whole of dest as a big-endian number,
+// zero-padded on the left, and returns len(dest). Unlike WriteToSlice, a dest
+// longer than 32 bytes is padded rather than partially filled, and if z does
+// not fit in len(dest) bytes, nothing is written and an error is returned.
+func (z *Int) WriteToSliceChecked(dest []byte) (n int, err error) {
+	if z.ByteLen() > len(dest) {
+		return 0, errSliceShort
+	}
+	for i := range dest {
+		if i < 32 {
+			dest[len(dest)-1-i] = byte(z[i/8] >> uint64(8*(i%8)))
+		} else {
+			dest[len(dest)-1-i] = 0
+		}
+	}
+	return len(dest), nil
+}
+
 // WriteToArray32 writes all 32 bytes of z to the destination array, including zero-bytes
 func (z *Int) WriteToArray32(dest *[32]byte) {
-	for i := 0; i < 32; i++ {
-		dest[31-i] = byte(z[i/8] >> uint64(8*(i%8)))
-	}
+	z.PutBytes(dest[:], 32, BigEndian)
+}
+
+// SetFromArray32 sets z from the 32-byte big-endian array b, the inverse of
+// WriteToArray32, and returns z. It compiles to four byte-swapping loads
+// rather than a per-byte loop. The memory layouts of [32]byte and Int differ
+// in byte order, so there is no copy-free cast between them; maps that need
+// a fixed-size key can instead be keyed by Int, which is comparable.
+func (z *Int) SetFromArray32(b *[32]byte) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromArray32"))
+	}
+	z[3] = binary.BigEndian.Uint64(b[0:8])
+	z[2] = binary.BigEndian.Uint64(b[8:16])
+	z[1] = binary.BigEndian.Uint64(b[16:24])
+	z[0] = binary.BigEndian.Uint64(b[24:32])
+	return z
+}
+
+// FromArray32 is a convenience-constructor for SetFromArray32.
+func FromArray32(b *[32]byte) *Int {
+	return new(Int).SetFromArray32(b)
 }
 
 // WriteToArray20 writes the last 20 bytes of z to the destination array, including zero-bytes
 func (z *Int) WriteToArray20(dest *[20]byte) {
-	for i := 0; i < 20; i++ {
-		dest[19-i] = byte(z[i/8] >> uint64(8*(i%8)))
-	}
+	z.PutBytes(dest[:], 20, BigEndian)
 }
 
 //func (z *Int) WriteToArr32(dest [32]bytes){
@@ -127,26 +277,41 @@ func (z *Int) WriteToArray20(dest *[20]byte) {
 //}
 // Uint64 returns the lower 64-bits of z
 func (z *Int) Uint64() uint64 {
+	if z == nil {
+		return 0
+	}
 	return z[0]
 }
 
 // Uint64 returns the lower 64-bits of z and bool whether overflow occurred
 func (z *Int) Uint64WithOverflow() (uint64, bool) {
+	if z == nil {
+		return 0, false
+	}
 	return z[0], z[1] != 0 || z[2] != 0 || z[3] != 0
 }
 
 // Uint64 returns the lower 63-bits of z as int64
 func (z *Int) Int64() int64 {
+	if z == nil {
+		return 0
+	}
 	return int64(z[0] & 0x7fffffffffffffff)
 }
 
 // Clone create a new Int identical to z
 func (z *Int) Clone() *Int {
+	if z == nil {
+		return new(Int)
+	}
 	return &Int{z[0], z[1], z[2], z[3]}
 }
 
 // Add sets z to the sum x+y
 func (z *Int) Add(x, y *Int) *Int {
+	if specChecks {
+		defer checkSpec("Add", z, *x, *y)
+	}
 	var carry uint64
 	z[0], carry = bits.Add64(x[0], y[0], 0)
 	z[1], carry = bits.Add64(x[1], y[1], carry)
@@ -165,8 +330,45 @@ func (z *Int) AddOverflow(x, y *Int) bool {
 	return carry != 0
 }
 
+// AddOne sets z to x+1 mod 2**256, and returns z.
+func (z *Int) AddOne(x *Int) *Int {
+	z.IncOverflow(x)
+	return z
+}
+
+// IncOverflow sets z to x+1 mod 2**256, and returns true if the increment
+// overflowed, i.e. if x was 2**256-1 and z wrapped around to 0.
+func (z *Int) IncOverflow(x *Int) bool {
+	var carry uint64
+	z[0], carry = bits.Add64(x[0], 1, 0)
+	z[1], carry = bits.Add64(x[1], 0, carry)
+	z[2], carry = bits.Add64(x[2], 0, carry)
+	z[3], carry = bits.Add64(x[3], 0, carry)
+	return carry != 0
+}
+
+// SubOne sets z to x-1 mod 2**256, and returns z.
+func (z *Int) SubOne(x *Int) *Int {
+	z.DecOverflow(x)
+	return z
+}
+
+// DecOverflow sets z to x-1 mod 2**256, and returns true if the decrement
+// underflowed, i.e. if x was 0 and z wrapped around to 2**256-1.
+func (z *Int) DecOverflow(x *Int) bool {
+	var borrow uint64
+	z[0], borrow = bits.Sub64(x[0], 1, 0)
+	z[1], borrow = bits.Sub64(x[1], 0, borrow)
+	z[2], borrow = bits.Sub64(x[2], 0, borrow)
+	z[3], borrow = bits.Sub64(x[3], 0, borrow)
+	return borrow != 0
+}
+
 // AddMod sets z to the sum ( x+y ) mod m, and returns z
 func (z *Int) AddMod(x, y, m *Int) *Int {
+	if specChecks && !m.IsZero() {
+		defer checkSpec("AddMod", z, *x, *y, *m)
+	}
 	if z == m { // z is an alias for m  // TODO: Understand why needed and add tests for all "division" methods.
 		m = m.Clone()
 	}
@@ -190,28 +392,74 @@ func addTo128(z []uint64, x0, x1 uint64) {
 // of the slice is at least n bytes.
 // Example, z =1, n = 20 => [0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 1]
 func (z *Int) PaddedBytes(n int) []byte {
-	b := make([]byte, n)
+	return z.PaddedBytesTo(nil, n)
+}
+
+// PaddedBytesTo is like PaddedBytes, but uses the storage of dst if its
+// capacity is at least n bytes, so that a buffer can be reused.
+func (z *Int) PaddedBytesTo(dst []byte, n int) []byte {
+	z = orZero(z)
+	var b []byte
+	if cap(dst) >= n {
+		b = dst[:n]
+	} else {
+		b = make([]byte, n)
+	}
+	for i := 0; i < n; i++ {
+		if i < 32 {
+			b[n-1-i] = byte(z[i/8] >> uint64(8*(i%8)))
+		} else {
+			b[n-1-i] = 0
+		}
+	}
+	return b
+}
 
-	for i := 0; i < 32 && i < n; i++ {
-		b[n-1-i] = byte(z[i/8] >> uint64(8*(i%8)))
+// RightPaddedBytes encodes z as a big-endian byte slice without leading
+// zeros, as Bytes does, followed by zero bytes up to a length of n, as for
+// the data of ABI dynamic bytes. The length of the slice is at least n, and
+// more if z needs more bytes.
+// Example, z = 0x0102, n = 4 => [1 2 0 0]
+func (z *Int) RightPaddedBytes(n int) []byte {
+	return z.RightPaddedBytesTo(make([]byte, 0, n), n)
+}
+
+// RightPaddedBytesTo is like RightPaddedBytes, but uses the storage of dst
+// if its capacity is large enough, so that a buffer can be reused.
+func (z *Int) RightPaddedBytesTo(dst []byte, n int) []byte {
+	b := z.AppendBytes(dst[:0])
+	for len(b) < n {
+		b = append(b, 0)
 	}
 	return b
 }
 
 // Sub64 set z to the difference x - y, where y is a 64 bit uint
+//
+// Deprecated: Use SubUint64, which returns z.
 func (z *Int) Sub64(x *Int, y uint64) {
+	z.SubUint64(x, y)
+}
+
+// SubUint64 sets z to the difference x - y, where y is a 64 bit uint, and
+// returns z.
+func (z *Int) SubUint64(x *Int, y uint64) *Int {
 	var carry uint64
 
 	if z[0], carry = bits.Sub64(x[0], y, carry); carry == 0 {
-		return
+		z[1], z[2], z[3] = x[1], x[2], x[3]
+		return z
 	}
 	if z[1], carry = bits.Sub64(x[1], 0, carry); carry == 0 {
-		return
+		z[2], z[3] = x[2], x[3]
+		return z
 	}
 	if z[2], carry = bits.Sub64(x[2], 0, carry); carry == 0 {
-		return
+		z[3] = x[3]
+		return z
 	}
-	z[3]--
+	z[3] = x[3] - 1
+	return z
 }
 
 // Sub sets z to the difference x-y and returns true if the operation underflowed
@@ -226,6 +474,9 @@ func (z *Int) SubOverflow(x, y *Int) bool {
 
 // Sub sets z to the difference x-y
 func (z *Int) Sub(x, y *Int) *Int {
+	if specChecks {
+		defer checkSpec("Sub", z, *x, *y)
+	}
 	var carry uint64
 	z[0], carry = bits.Sub64(x[0], y[0], 0)
 	z[1], carry = bits.Sub64(x[1], y[1], carry)
@@ -246,6 +497,14 @@ func umulStep(z, x, y, carry uint64) (uint64, uint64) {
 
 // umul computes full 256 x 256 -> 512 multiplication.
 func umul(x, y *Int) [8]uint64 {
+	if limb32 {
+		return umul32(x, y)
+	}
+	return umul64(x, y)
+}
+
+// umul64 implements umul with 64-bit limbs.
+func umul64(x, y *Int) [8]uint64 {
 	var res [8]uint64
 	for j := 0; j < len(y); j++ {
 		var carry uint64
@@ -260,7 +519,17 @@ func umul(x, y *Int) [8]uint64 {
 
 // Mul sets z to the sum x*y
 func (z *Int) Mul(x, y *Int) *Int {
+	if specChecks {
+		defer checkSpec("Mul", z, *x, *y)
+	}
+	if limb32 {
+		return z.mul32(x, y)
+	}
+	return z.mul64(x, y)
+}
 
+// mul64 implements Mul with 64-bit limbs.
+func (z *Int) mul64(x, y *Int) *Int {
 	var (
 		alfa = &Int{} // Aggregate results
 		beta = &Int{} // Calculate intermediate
@@ -321,31 +590,46 @@ func (z *Int) Mul(x, y *Int) *Int {
 	return z.Copy(alfa)
 }
 
+// Squared sets z to z*z.
+//
+// Deprecated: Use SquareOf, which takes its operand explicitly and returns z.
 func (z *Int) Squared() {
+	z.SquareOf(z)
+}
 
+// SquareOf sets z to the product x*x, and returns z.
+func (z *Int) SquareOf(x *Int) *Int {
+	if limb32 {
+		return z.mul32(x, x)
+	}
+	return z.square64(x)
+}
+
+// square64 implements SquareOf with 64-bit limbs.
+func (z *Int) square64(x *Int) *Int {
 	var (
 		alfa = &Int{} // Aggregate results
 		beta = &Int{} // Calculate intermediate
 	)
 	// This algo is based on Mul, but since it's squaring, we know that
-	// e.g. z.b*y.c + z.c*y.c == 2 * z.b * z.c, and can save some calculations
+	// e.g. x.b*y.c + x.c*y.c == 2 * x.b * x.c, and can save some calculations
 	// 2 * d * b
-	alfa[3], alfa[2] = bits.Mul64(z[0], z[2])
+	alfa[3], alfa[2] = bits.Mul64(x[0], x[2])
 	alfa.lshOne()
-	alfa[1], alfa[0] = bits.Mul64(z[0], z[0])
+	alfa[1], alfa[0] = bits.Mul64(x[0], x[0])
 
 	// 2 * a * d + 2 * b * c
-	alfa[3] += (z[0]*z[3] + z[1]*z[2]) << 1
+	alfa[3] += (x[0]*x[3] + x[1]*x[2]) << 1
 
 	// 2 * d * c
-	beta[2], beta[1] = bits.Mul64(z[0], z[1])
+	beta[2], beta[1] = bits.Mul64(x[0], x[1])
 	beta.lshOne()
 	alfa.Add(alfa, beta)
 
 	// c * c
-	beta[3], beta[2] = bits.Mul64(z[1], z[1])
+	beta[3], beta[2] = bits.Mul64(x[1], x[1])
 	addTo128(alfa[2:], beta[2], beta[3])
-	z.Copy(alfa)
+	return z.Copy(alfa)
 }
 
 func (z *Int) setBit(n uint) *Int {
@@ -445,6 +729,19 @@ func udivremKnuth(quot, u, d []uint64) {
 // It loosely follows the Knuth's division algorithm (sometimes referenced as "schoolbook" division) using 64-bit words.
 // See Knuth, Volume 2, section 4.3.1, Algorithm D.
 func udivrem(quot, u []uint64, d *Int) (rem Int) {
+	if profileLabels {
+		labeled("udivrem", func() { rem = divrem(quot, u, d) })
+	} else {
+		rem = divrem(quot, u, d)
+	}
+	if checkInvariants {
+		mustDivrem(quot, u, d, &rem)
+	}
+	return rem
+}
+
+// divrem implements udivrem.
+func divrem(quot, u []uint64, d *Int) (rem Int) {
 	var dLen int
 	for i := len(d) - 1; i >= 0; i-- {
 		if d[i] != 0 {
@@ -499,6 +796,9 @@ func udivrem(quot, u []uint64, d *Int) (rem Int) {
 // Div sets z to the quotient x/y for returns z.
 // If d == 0, z is set to 0
 func (z *Int) Div(x, y *Int) *Int {
+	if specChecks {
+		defer checkSpec("Div", z, *x, *y)
+	}
 	if y.IsZero() || y.Gt(x) {
 		return z.Clear()
 	}
@@ -521,6 +821,9 @@ func (z *Int) Div(x, y *Int) *Int {
 // Mod sets z to the modulus x%y for y != 0 and returns z.
 // If y == 0, z is set to 0 (OBS: differs from the big.Int)
 func (z *Int) Mod(x, y *Int) *Int {
+	if specChecks {
+		defer checkSpec("Mod", z, *x, *y)
+	}
 	if x.IsZero() || y.IsZero() {
 		return z.Clear()
 	}
@@ -554,20 +857,22 @@ func (z *Int) Mod(x, y *Int) *Int {
 // If y == 0, z is set to 0 (OBS: differs from the big.Int)
 // OBS! Modifies x and y
 func (z *Int) Smod(x, y *Int) *Int {
-	ys := y.Sign()
-	xs := x.Sign()
+	if specChecks {
+		defer checkSpec("Smod", z, *x, *y)
+	}
+	xNeg := x.IsNegative()
 
 	// abs x
-	if xs == -1 {
-		x.Neg()
+	if xNeg {
+		x.NegOf(x)
 	}
 	// abs y
-	if ys == -1 {
-		y.Neg()
+	if y.IsNegative() {
+		y.NegOf(y)
 	}
 	z.Mod(x, y)
-	if xs == -1 {
-		z.Neg()
+	if xNeg {
+		z.NegOf(z)
 	}
 	return z
 }
@@ -575,6 +880,18 @@ func (z *Int) Smod(x, y *Int) *Int {
 // MulMod calculates the modulo-n multiplication of x and y and
 // returns z
 func (z *Int) MulMod(x, y, m *Int) *Int {
+	if specChecks && !m.IsZero() {
+		defer checkSpec("MulMod", z, *x, *y, *m)
+	}
+	if profileLabels {
+		labeled("MulMod", func() { z.mulMod(x, y, m) })
+		return z
+	}
+	return z.mulMod(x, y, m)
+}
+
+// mulMod implements MulMod.
+func (z *Int) mulMod(x, y, m *Int) *Int {
 	p := umul(x, y)
 	var (
 		pl Int
@@ -597,23 +914,52 @@ func (z *Int) MulMod(x, y, m *Int) *Int {
 	return z.Copy(&rem)
 }
 
-// Abs interprets x as a a signed number, and sets z to the Abs value
+// Abs interprets z as a a signed number, and sets z to the Abs value
 //   S256(0)        = 0
 //   S256(1)        = 1
 //   S256(2**255)   = -2**255
 //   S256(2**256-1) = -1
-
+//
+// Deprecated: Use SAbs, which takes its operand explicitly.
 func (z *Int) Abs() *Int {
-	if z.Lt(SignedMin) {
-		return z
+	return z.SAbs(z)
+}
+
+// SAbs interprets x as a signed number, and sets z to its absolute value,
+// and returns z, leaving x unmodified. The result is exact when read as an
+// unsigned number: the absolute value of -2**255 is 2**255, which reads as
+// -2**255 again only when interpreted as a signed number.
+func (z *Int) SAbs(x *Int) *Int {
+	if !x.IsNegative() {
+		return z.Copy(x)
 	}
-	z.Sub(&Int{}, z)
-	return z
+	return z.Sub(&Int{}, x)
 }
 
+// CmpAbs interprets z and x as signed numbers, and compares their absolute
+// values, returning:
+//
+//   -1 if |z| <  |x|
+//    0 if |z| == |x|
+//   +1 if |z| >  |x|
+//
+// Neither operand is modified; |-2**255| = 2**255 is the largest absolute
+// value.
+func (z *Int) CmpAbs(x *Int) int {
+	var a, b Int
+	return a.SAbs(z).Cmp(b.SAbs(x))
+}
+
+// Neg sets z to -z mod 2**256, and returns z.
+//
+// Deprecated: Use NegOf, which takes its operand explicitly.
 func (z *Int) Neg() *Int {
-	z.Sub(&Int{}, z)
-	return z
+	return z.NegOf(z)
+}
+
+// NegOf sets z to -x mod 2**256, and returns z.
+func (z *Int) NegOf(x *Int) *Int {
+	return z.Sub(&Int{}, x)
 }
 
 // Sdiv interprets n and d as signed integers, does a
@@ -621,26 +967,29 @@ func (z *Int) Neg() *Int {
 // If d == 0, z is set to 0
 // OBS! This method (potentially) modifies both n and d
 func (z *Int) Sdiv(n, d *Int) *Int {
-	if n.Sign() > 0 {
-		if d.Sign() > 0 {
+	if specChecks {
+		defer checkSpec("Sdiv", z, *n, *d)
+	}
+	if n.IsPositive() {
+		if d.IsPositive() {
 			// pos / pos
 			z.Div(n, d)
 			return z
 		} else {
 			// pos / neg
-			z.Div(n, d.Neg())
-			return z.Neg()
+			z.Div(n, d.NegOf(d))
+			return z.NegOf(z)
 		}
 	}
 
-	if d.Sign() < 0 {
+	if d.IsNegative() {
 		// neg / neg
-		z.Div(n.Neg(), d.Neg())
+		z.Div(n.NegOf(n), d.NegOf(d))
 		return z
 	}
 	// neg / pos
-	z.Div(n.Neg(), d)
-	return z.Neg()
+	z.Div(n.NegOf(n), d)
+	return z.NegOf(z)
 }
 
 // Sign returns:
@@ -650,18 +999,32 @@ func (z *Int) Sdiv(n, d *Int) *Int {
 //	+1 if z >  0
 // Where z is interpreted as a signed number
 func (z *Int) Sign() int {
+	if z.IsNegative() {
+		return -1
+	}
 	if z.IsZero() {
 		return 0
 	}
-	if z.Lt(SignedMin) {
-		return 1
-	}
-	return -1
+	return 1
+}
+
+// IsNegative reports whether z is negative when interpreted as a signed
+// number, i.e. whether its top bit is set.
+func (z *Int) IsNegative() bool {
+	return z != nil && z[3]>>63 == 1
+}
+
+// IsPositive reports whether z is greater than zero when interpreted as a
+// signed number.
+func (z *Int) IsPositive() bool {
+	return !z.IsZero() && z[3]>>63 == 0
 }
 
 // BitLen returns the number of bits required to represent x
 func (z *Int) BitLen() int {
 	switch {
+	case z == nil:
+		return 0
 	case z[3] != 0:
 		return 192 + bits.Len64(z[3])
 	case z[2] != 0:
@@ -713,9 +1076,16 @@ func (z *Int) srsh192(x *Int) *Int {
 	return z
 }
 
-// Not sets z = ^x and returns z.
+// Not sets z = ^z and returns z.
+//
+// Deprecated: Use NotOf, which takes its operand explicitly.
 func (z *Int) Not() *Int {
-	z[3], z[2], z[1], z[0] = ^z[3], ^z[2], ^z[1], ^z[0]
+	return z.NotOf(z)
+}
+
+// NotOf sets z = ^x and returns z.
+func (z *Int) NotOf(x *Int) *Int {
+	z[3], z[2], z[1], z[0] = ^x[3], ^x[2], ^x[1], ^x[0]
 	return z
 }
 
@@ -727,14 +1097,12 @@ func (z *Int) Gt(x *Int) bool {
 // Slt interprets z and x as signed integers, and returns
 // true if z < x
 func (z *Int) Slt(x *Int) bool {
-
-	zSign := z.Sign()
-	xSign := x.Sign()
+	zNeg, xNeg := z.IsNegative(), x.IsNegative()
 
 	switch {
-	case zSign >= 0 && xSign < 0:
+	case !zNeg && xNeg:
 		return false
-	case zSign < 0 && xSign >= 0:
+	case zNeg && !xNeg:
 		return true
 	default:
 		return z.Lt(x)
@@ -744,13 +1112,12 @@ func (z *Int) Slt(x *Int) bool {
 // Sgt interprets z and x as signed integers, and returns
 // true if z > x
 func (z *Int) Sgt(x *Int) bool {
-	zSign := z.Sign()
-	xSign := x.Sign()
+	zNeg, xNeg := z.IsNegative(), x.IsNegative()
 
 	switch {
-	case zSign >= 0 && xSign < 0:
+	case !zNeg && xNeg:
 		return true
-	case zSign < 0 && xSign >= 0:
+	case zNeg && !xNeg:
 		return false
 	default:
 		return z.Gt(x)
@@ -758,7 +1125,13 @@ func (z *Int) Sgt(x *Int) bool {
 }
 
 // SetIfGt sets z to 1 if z > x
+//
+// Deprecated: Use z.SetBool(z.Gt(x)), or the evm package for the operands
+// of the EVM stack.
 func (z *Int) SetIfGt(x *Int) {
+	if z == nil {
+		panic(nilReceiver("SetIfGt"))
+	}
 	if z.Gt(x) {
 		z.SetOne()
 	} else {
@@ -777,7 +1150,13 @@ func (z *Int) Lt(x *Int) bool {
 }
 
 // SetIfLt sets z to 1 if z < x
+//
+// Deprecated: Use z.SetBool(z.Lt(x)), or the evm package for the operands
+// of the EVM stack.
 func (z *Int) SetIfLt(x *Int) {
+	if z == nil {
+		panic(nilReceiver("SetIfLt"))
+	}
 	if z.Lt(x) {
 		z.SetOne()
 	} else {
@@ -785,8 +1164,34 @@ func (z *Int) SetIfLt(x *Int) {
 	}
 }
 
+// Lte returns true if z <= x
+func (z *Int) Lte(x *Int) bool {
+	return !x.Lt(z)
+}
+
+// Gte returns true if z >= x
+func (z *Int) Gte(x *Int) bool {
+	return !z.Lt(x)
+}
+
+// SetBool sets z to 1 if b is true, and to 0 otherwise, and returns z. With
+// the comparisons, it gives the results of the EVM comparison opcodes, e.g.
+// z.SetBool(x.Lt(y)).
+func (z *Int) SetBool(b bool) *Int {
+	if z == nil {
+		panic(nilReceiver("SetBool"))
+	}
+	if b {
+		return z.SetOne()
+	}
+	return z.Clear()
+}
+
 // SetUint64 sets z to the value x
 func (z *Int) SetUint64(x uint64) *Int {
+	if z == nil {
+		panic(nilReceiver("SetUint64"))
+	}
 	z[3], z[2], z[1], z[0] = 0, 0, 0, x
 	return z
 }
@@ -799,7 +1204,13 @@ func (z *Int) Eq(x *Int) bool {
 // SetIfEq sets x to
 // 1 if z == x
 // 0 if Z != x
+//
+// Deprecated: Use z.SetBool(z.Eq(x)), or the evm package for the operands
+// of the EVM stack.
 func (z *Int) SetIfEq(x *Int) {
+	if z == nil {
+		panic(nilReceiver("SetIfEq"))
+	}
 	if z.Eq(x) {
 		z.SetOne()
 	} else {
@@ -823,6 +1234,28 @@ func (z *Int) Cmp(x *Int) (r int) {
 	return 0
 }
 
+// Scmp interprets z and x as signed integers, and compares them, returning:
+//
+//   -1 if z <  x
+//    0 if z == x
+//   +1 if z >  x
+//
+func (z *Int) Scmp(x *Int) (r int) {
+	// Flipping the sign bits maps the signed order onto the unsigned one,
+	// moving -2**255 to 0 and 2**255-1 to 2**256-1.
+	d0, carry := bits.Sub64(z[0], x[0], 0)
+	d1, carry := bits.Sub64(z[1], x[1], carry)
+	d2, carry := bits.Sub64(z[2], x[2], carry)
+	d3, carry := bits.Sub64(z[3]^(1<<63), x[3]^(1<<63), carry)
+	if carry == 1 {
+		return -1
+	}
+	if d0|d1|d2|d3 == 0 {
+		return 0
+	}
+	return 1
+}
+
 // LtUint64 returns true if x is smaller than n
 func (z *Int) LtUint64(n uint64) bool {
 	return (z[3] == 0) && (z[2] == 0) && (z[1] == 0) && z[0] < n
@@ -835,38 +1268,47 @@ func (z *Int) GtUint64(n uint64) bool {
 
 // IsUint64 reports whether z can be represented as a uint64.
 func (z *Int) IsUint64() bool {
-	return (z[3] == 0) && (z[2] == 0) && (z[1] == 0)
+	return z == nil || (z[3] == 0) && (z[2] == 0) && (z[1] == 0)
 }
 
 // IsUint128 reports whether z can be represented in 128 bits.
 func (z *Int) IsUint128() bool {
-	return (z[3] == 0) && (z[2] == 0)
+	return z == nil || (z[3] == 0) && (z[2] == 0)
 }
 
 // IsZero returns true if z == 0
 func (z *Int) IsZero() bool {
-	return (z[0] | z[1] | z[2] | z[3]) == 0
+	return z == nil || (z[0] | z[1] | z[2] | z[3]) == 0
 }
 
 // IsOne returns true if z == 1
 func (z *Int) IsOne() bool {
-	return (z[0] == 1) && (z[1]|z[2]|z[3]) == 0
+	return z != nil && (z[0] == 1) && (z[1]|z[2]|z[3]) == 0
 }
 
 // Clear sets z to 0
 func (z *Int) Clear() *Int {
+	if z == nil {
+		panic(nilReceiver("Clear"))
+	}
 	z[3], z[2], z[1], z[0] = 0, 0, 0, 0
 	return z
 }
 
 // SetAllOne sets all the bits of z to 1
 func (z *Int) SetAllOne() *Int {
+	if z == nil {
+		panic(nilReceiver("SetAllOne"))
+	}
 	z[3], z[2], z[1], z[0] = math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64
 	return z
 }
 
 // SetOne sets z to 1
 func (z *Int) SetOne() *Int {
+	if z == nil {
+		panic(nilReceiver("SetOne"))
+	}
 	z[3], z[2], z[1], z[0] = 0, 0, 0, 1
 	return z
 }
@@ -889,6 +1331,9 @@ func (z *Int) lshOne() {
 
 // Lsh sets z = x << n and returns z.
 func (z *Int) Lsh(x *Int, n uint) *Int {
+	if specChecks {
+		defer checkSpec("Lsh", z, *x, Int{uint64(n)})
+	}
 	// n % 64 == 0
 	if n&0x3f == 0 {
 		switch n {
@@ -948,6 +1393,9 @@ sh192:
 
 // Rsh sets z = x >> n and returns z.
 func (z *Int) Rsh(x *Int, n uint) *Int {
+	if specChecks {
+		defer checkSpec("Rsh", z, *x, Int{uint64(n)})
+	}
 	// n % 64 == 0
 	if n&0x3f == 0 {
 		switch n {
@@ -1006,11 +1454,11 @@ sh192:
 }
 
 // Srsh (Signed/Arithmetic right shift)
-// considers z to be a signed integer, during right-shift
+// considers x to be a signed integer, during right-shift
 // and sets z = x >> n and returns z.
 func (z *Int) Srsh(x *Int, n uint) *Int {
 	// If the MSB is 0, Srsh is same as Rsh.
-	if !z.isBitSet(255) {
+	if !x.isBitSet(255) {
 		return z.Rsh(x, n)
 	}
 	// n % 64 == 0
@@ -1069,6 +1517,9 @@ sh192:
 
 // Copy copies the value x into z, and returns z
 func (z *Int) Copy(x *Int) *Int {
+	if z == nil {
+		panic(nilReceiver("Copy"))
+	}
 	*z = *x
 	return z
 }
@@ -1104,12 +1555,22 @@ func (z *Int) Xor(x, y *Int) *Int {
 // with 'z' considered as a big-endian 32-byte integer
 // if 'n' > 32, f is set to 0
 // Example: f = '5', n=31 => 5
+//
+// Deprecated: Use ByteOf, which takes its operand explicitly.
 func (z *Int) Byte(n *Int) *Int {
-	// in z, z[0] is the least significant
+	return z.ByteOf(n, z)
+}
+
+// ByteOf sets z to the value of the byte at position n of x, considered as
+// a big-endian 32-byte integer, as the EVM BYTE opcode does, and returns z.
+// If n > 31, z is set to 0.
+// Example: x = 5, n = 31 => 5
+func (z *Int) ByteOf(n, x *Int) *Int {
+	// in x, x[0] is the least significant
 	//
 	if number, overflow := n.Uint64WithOverflow(); !overflow {
 		if number < 32 {
-			number := z[4-1-number/8]
+			number := x[4-1-number/8]
 			offset := (n[0] & 0x7) << 3 // 8*(n.d % 8)
 			z[0] = (number & (0xff00000000000000 >> offset)) >> (56 - offset)
 			z[3], z[2], z[1] = 0, 0, 0
@@ -1121,11 +1582,26 @@ func (z *Int) Byte(n *Int) *Int {
 
 // Hex returns a hex representation of z
 func (z *Int) Hex() string {
+	if z == nil {
+		z = new(Int)
+	}
 	return fmt.Sprintf("%016x.%016x.%016x.%016x", z[3], z[2], z[1], z[0])
 }
 
 // Exp sets z = base**exponent mod 2**256, and returns z.
 func (z *Int) Exp(base, exponent *Int) *Int {
+	if specChecks {
+		defer checkSpec("Exp", z, *base, *exponent)
+	}
+	if profileLabels {
+		labeled("Exp", func() { z.exp(base, exponent) })
+		return z
+	}
+	return z.exp(base, exponent)
+}
+
+// exp implements Exp.
+func (z *Int) exp(base, exponent *Int) *Int {
 	res := Int{1, 0, 0, 0}
 	// b^0 == 1
 	if exponent.IsZero() || base.IsOne() {
@@ -1148,7 +1624,7 @@ func (z *Int) Exp(base, exponent *Int) *Int {
 		if word&1 == 1 {
 			res.Mul(&res, &multiplier)
 		}
-		multiplier.Squared()
+		multiplier.SquareOf(&multiplier)
 		word >>= 1
 	}
 
@@ -1157,7 +1633,7 @@ func (z *Int) Exp(base, exponent *Int) *Int {
 		if word&1 == 1 {
 			res.Mul(&res, &multiplier)
 		}
-		multiplier.Squared()
+		multiplier.SquareOf(&multiplier)
 		word >>= 1
 	}
 
@@ -1166,7 +1642,7 @@ func (z *Int) Exp(base, exponent *Int) *Int {
 		if word&1 == 1 {
 			res.Mul(&res, &multiplier)
 		}
-		multiplier.Squared()
+		multiplier.SquareOf(&multiplier)
 		word >>= 1
 	}
 
@@ -1175,33 +1651,57 @@ func (z *Int) Exp(base, exponent *Int) *Int {
 		if word&1 == 1 {
 			res.Mul(&res, &multiplier)
 		}
-		multiplier.Squared()
+		multiplier.SquareOf(&multiplier)
 		word >>= 1
 	}
 	return z.Copy(&res)
 }
 
 //Extend length of two’s complement signed integer
-// sets z to
+// sets z and num to
 //  - num if back  > 31
 //  - num interpreted as a signed number with sign-bit at (back*8+7), extended to the full 256 bits
+//
+// Deprecated: Use ExtendSign, which leaves its operands unmodified.
 func (z *Int) SignExtend(back, num *Int) {
-	if back.GtUint64(31) {
-		z.Copy(num)
-		return
-	}
-	bit := uint(back.Uint64()*8 + 7)
+	num.Copy(z.ExtendSign(num, back))
+}
 
-	mask := back.Lsh(back.SetOne(), bit)
-	mask.Sub64(mask, 1)
-	if num.isBitSet(bit) {
-		num.Or(num, mask.Not())
-	} else {
-		num.And(num, mask)
+// ExtendSign sets z to x interpreted as a two's complement signed integer
+// of byteNum+1 bytes, i.e. with its sign bit at byteNum*8+7, extended to the
+// full 256 bits, as the EVM SIGNEXTEND opcode does, and returns z. If
+// byteNum > 30, z is set to x.
+func (z *Int) ExtendSign(x, byteNum *Int) *Int {
+	if byteNum.GtUint64(30) {
+		return z.Copy(x)
 	}
+	bit := uint(byteNum.Uint64()*8 + 7)
 
+	var mask Int
+	mask.Lsh(mask.SetOne(), bit)
+	mask.SubUint64(&mask, 1)
+	if x.isBitSet(bit) {
+		return z.Or(x, mask.NotOf(&mask))
+	}
+	return z.And(x, &mask)
 }
 
+// Format implements fmt.Formatter, with the verbs of big.Int, unless the
+// Formatter registered with SetFormatter handles the verb. %#v prints
+// GoString.
 func (z *Int) Format(s fmt.State, ch rune) {
+	if ch == 'v' && s.Flag('#') {
+		fmt.Fprint(s, z.GoString())
+		return
+	}
+	if z == nil {
+		// Like big.Int.
+		fmt.Fprint(s, "<nil>")
+		return
+	}
+	if str, ok := z.customFormat(ch); ok {
+		writePadded(s, str)
+		return
+	}
 	z.ToBig().Format(s, ch)
 }
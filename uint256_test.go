@@ -11,6 +11,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -224,7 +226,7 @@ func TestRandomSMod(t *testing.T) {
 			f1.Smod(f2, f3)
 		},
 		func(b1, b2, b3 *big.Int) {
-			b1.Set(Smod(b2, b3))
+			b1.Set(bigSmod(b2, b3))
 		},
 	)
 }
@@ -316,7 +318,7 @@ func TestRandomSDiv(t *testing.T) {
 			bb1 := S256(big.NewInt(0).Set(b))
 			bb2 := S256(big.NewInt(0).Set(b2))
 
-			b = Sdiv(bb1, bb2)
+			b = bigSdiv(bb1, bb2)
 		}
 		if eq := checkEq(b, f1); !eq {
 			bf, _ := FromBig(b)
@@ -490,12 +492,12 @@ func U256(x *big.Int) *big.Int {
 	return x.And(x, tt256m1)
 }
 
-// Exp implements exponentiation by squaring.
-// Exp returns a newly-allocated big integer and does not change
+// bigExp implements exponentiation by squaring.
+// bigExp returns a newly-allocated big integer and does not change
 // base or exponent. The result is truncated to 256 bits.
 //
 // Courtesy @karalabe and @chfast
-func Exp(base, exponent *big.Int) *big.Int {
+func bigExp(base, exponent *big.Int) *big.Int {
 	result := big.NewInt(1)
 
 	for _, word := range exponent.Bits() {
@@ -510,7 +512,7 @@ func Exp(base, exponent *big.Int) *big.Int {
 	return result
 }
 
-func Sdiv(x, y *big.Int) *big.Int {
+func bigSdiv(x, y *big.Int) *big.Int {
 	if y.Sign() == 0 {
 		return new(big.Int)
 
@@ -526,7 +528,7 @@ func Sdiv(x, y *big.Int) *big.Int {
 	res.Mul(res, n)
 	return res
 }
-func Smod(x, y *big.Int) *big.Int {
+func bigSmod(x, y *big.Int) *big.Int {
 	res := new(big.Int)
 	if y.Sign() == 0 {
 		return res
@@ -565,7 +567,7 @@ func TestRandomExp(t *testing.T) {
 			t.Fatal("FromBig(exp) overflow")
 		}
 
-		b_res := Exp(b_base, b_exp)
+		b_res := bigExp(b_base, b_exp)
 		if eq := checkEq(b_res, f_res); !eq {
 			bf, _ := FromBig(b_res)
 			t.Fatalf("Expected equality:\nbase= %v\nexp = %v\n[ ^ ]==\nf = %v\nbf= %v\nb = %x\n", basecopy.Hex(), expcopy.Hex(), f_res.Hex(), bf.Hex(), b_res)
@@ -663,7 +665,7 @@ func TestFixedExp(t *testing.T) {
 	//	exp.d = 255
 	res := new(Int).Exp(base, exp)
 
-	b_res := Exp(b_base, b_exp)
+	b_res := bigExp(b_base, b_exp)
 
 	want, _ := FromBig(b_res)
 	fmt.Printf("B: %x\n", b_res)
@@ -824,6 +826,170 @@ func TestWriteToSlice(t *testing.T) {
 	}
 
 }
+func TestWriteToSliceTable(t *testing.T) {
+	x := hex2Bytes("fe7fb0d1f59dfe9492ffbf73683fd1e870eec79504c60144cc7f5fc2bad1e611")
+	fx := new(Int).SetBytes(x)
+	small := new(Int).SetUint64(0x0102)
+	for i, tc := range []struct {
+		x       *Int
+		dest    string // Initial content.
+		exp     string // Content after WriteToSlice.
+		fits    bool   // Whether WriteToSliceChecked succeeds.
+		checked string // Content after WriteToSliceChecked.
+	}{
+		{fx, "", "", false, ""},
+		{fx, "ffff", "e611", false, "ffff"},
+		{fx, strings.Repeat("ff", 32), hex.EncodeToString(x), true, hex.EncodeToString(x)},
+		{fx, strings.Repeat("ff", 34), hex.EncodeToString(x) + "ffff", true, "0000" + hex.EncodeToString(x)},
+		{small, "ffffff", "000102", true, "000102"},
+		{small, "ff", "02", false, "ff"},
+		{small, "ffff", "0102", true, "0102"},
+		{new(Int), "", "", true, ""},
+		{new(Int), "ffff", "0000", true, "0000"},
+	} {
+		dest := hex2Bytes(tc.dest)
+		tc.x.WriteToSlice(dest)
+		if got := hex.EncodeToString(dest); got != tc.exp {
+			t.Errorf("%d: WriteToSlice: got %s, exp %s", i, got, tc.exp)
+		}
+		dest = hex2Bytes(tc.dest)
+		n, err := tc.x.WriteToSliceChecked(dest)
+		if (err == nil) != tc.fits {
+			t.Errorf("%d: WriteToSliceChecked: got err %v, exp fits %v", i, err, tc.fits)
+		}
+		if got := hex.EncodeToString(dest); got != tc.checked {
+			t.Errorf("%d: WriteToSliceChecked: got %s, exp %s", i, got, tc.checked)
+		}
+		exp := len(dest)
+		if !tc.fits {
+			exp = 0
+		}
+		if n != exp {
+			t.Errorf("%d: WriteToSliceChecked: got n %d, exp %d", i, n, exp)
+		}
+	}
+}
+
+func TestPaddedBytesTo(t *testing.T) {
+	x := hex2Bytes("fe7fb0d1f59dfe9492ffbf73683fd1e870eec79504c60144cc7f5fc2bad1e611")
+	fx := new(Int).SetBytes(x)
+	small := new(Int).SetUint64(0x0102)
+	buf := hex2Bytes(strings.Repeat("ff", 40))
+	for i, tc := range []struct {
+		x     *Int
+		n     int
+		left  string
+		right string
+	}{
+		{small, 0, "", "0102"},
+		{small, 1, "02", "0102"},
+		{small, 4, "00000102", "01020000"},
+		{new(Int), 3, "000000", "000000"},
+		{fx, 20, hex.EncodeToString(x[12:]), hex.EncodeToString(x)},
+		{fx, 34, "0000" + hex.EncodeToString(x), hex.EncodeToString(x) + "0000"},
+	} {
+		for _, got := range [][]byte{tc.x.PaddedBytes(tc.n), tc.x.PaddedBytesTo(buf, tc.n), tc.x.PaddedBytesTo(nil, tc.n)} {
+			if hex.EncodeToString(got) != tc.left {
+				t.Errorf("%d: PaddedBytes: got %x, exp %s", i, got, tc.left)
+			}
+		}
+		for _, got := range [][]byte{tc.x.RightPaddedBytes(tc.n), tc.x.RightPaddedBytesTo(buf, tc.n), tc.x.RightPaddedBytesTo(nil, tc.n)} {
+			if hex.EncodeToString(got) != tc.right {
+				t.Errorf("%d: RightPaddedBytes: got %x, exp %s", i, got, tc.right)
+			}
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() {
+		fx.PaddedBytesTo(buf, 32)
+		fx.RightPaddedBytesTo(buf, 40)
+	}); allocs != 0 {
+		t.Errorf("got %v allocs, exp 0", allocs)
+	}
+}
+
+func TestPutBytes(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		_, x, _ := randNums()
+		be := x.Bytes32()
+		for width := 0; width <= 32; width++ {
+			dst := hex2Bytes(strings.Repeat("ee", 34))
+			x.PutBytes(dst, width, BigEndian)
+			if !bytes.Equal(dst[:width], be[32-width:]) || !bytes.Equal(dst[width:], hex2Bytes(strings.Repeat("ee", 34-width))) {
+				t.Fatalf("BigEndian width %d: got %x, exp %x", width, dst, be[32-width:])
+			}
+			x.PutBytes(dst, width, LittleEndian)
+			for j := 0; j < width; j++ {
+				if dst[j] != be[31-j] {
+					t.Fatalf("LittleEndian width %d: got %x, exp reverse of %x", width, dst[:width], be[32-width:])
+				}
+			}
+		}
+	}
+	x := new(Int).SetAllOne()
+	for _, tc := range []struct {
+		name  string
+		dst   []byte
+		width int
+	}{
+		{"negative width", make([]byte, 32), -1},
+		{"wide", make([]byte, 33), 33},
+		{"short", make([]byte, 20, 32), 21},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected panic", tc.name)
+				}
+			}()
+			x.PutBytes(tc.dst, tc.width, BigEndian)
+		}()
+	}
+}
+
+func TestFromArray32(t *testing.T) {
+	var arr [32]byte
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		x.WriteToArray32(&arr)
+		if got := FromArray32(&arr); !got.Eq(x) {
+			t.Fatalf("got %v, exp %v", got, x)
+		}
+		if got := new(Int).SetBytes(arr[:]); !got.Eq(x) {
+			t.Fatalf("SetBytes: got %v, exp %v", got, x)
+		}
+	}
+	var z Int
+	if allocs := testing.AllocsPerRun(100, func() { z.SetFromArray32(&arr) }); allocs != 0 {
+		t.Errorf("got %v allocs, exp 0", allocs)
+	}
+}
+
+func TestAppendBytes(t *testing.T) {
+	prefix := []byte{0xaa, 0xbb}
+	buf := make([]byte, 32)
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		exp := x.Bytes()
+		if got := x.AppendBytes(prefix[:2:2]); !bytes.Equal(got[:2], prefix) || !bytes.Equal(got[2:], exp) {
+			t.Fatalf("AppendBytes: got %x, exp %x%x", got, prefix, exp)
+		}
+		got := x.BytesTo(buf)
+		if !bytes.Equal(got, exp) {
+			t.Fatalf("BytesTo: got %x, exp %x", got, exp)
+		}
+		if len(got) > 0 && &got[0] != &buf[0] {
+			t.Fatalf("BytesTo: buffer not reused")
+		}
+	}
+	x := new(Int).SetAllOne()
+	if allocs := testing.AllocsPerRun(100, func() { x.BytesTo(buf) }); allocs != 0 {
+		t.Errorf("got %v allocs, exp 0", allocs)
+	}
+	if got := new(Int).BytesTo(nil); len(got) != 0 {
+		t.Errorf("got %x, exp empty", got)
+	}
+}
+
 func TestInt_WriteToArray(t *testing.T) {
 	x1 := hex2Bytes("0000000000000000000000000000d1e870eec79504c60144cc7f5fc2bad1e611")
 	a := big.NewInt(0).SetBytes(x1)
@@ -946,3 +1112,341 @@ func TestByte32Representation(t *testing.T) {
 		}
 	}
 }
+
+func TestNilReceiver(t *testing.T) {
+	var z *Int
+	if !z.IsZero() || z.Sign() != 0 || z.IsNegative() || z.IsPositive() || z.BitLen() != 0 || z.ByteLen() != 0 || !z.IsUint64() {
+		t.Errorf("nil is not zero")
+	}
+	if z.Uint64() != 0 || z.Int64() != 0 {
+		t.Errorf("nil Uint64/Int64 not zero")
+	}
+	if v, overflow := z.Uint64WithOverflow(); v != 0 || overflow {
+		t.Errorf("nil Uint64WithOverflow: got %d, %v", v, overflow)
+	}
+	if len(z.Bytes()) != 0 || z.Bytes32() != [32]byte{} || z.Bytes20() != [20]byte{} {
+		t.Errorf("nil bytes not zero")
+	}
+	if z.Dec() != "0" || z.ToHex() != "0x0" || z.Hex() != new(Int).Hex() {
+		t.Errorf("nil strings: %s %s %s", z.Dec(), z.ToHex(), z.Hex())
+	}
+	if z.SDec() != "0" || z.SHex() != "0x0" {
+		t.Errorf("nil signed strings: %s %s", z.SDec(), z.SHex())
+	}
+	if z.ToBig().Sign() != 0 || !z.Clone().IsZero() {
+		t.Errorf("nil ToBig/Clone not zero")
+	}
+	if got := fmt.Sprintf("%d", z); got != "<nil>" {
+		t.Errorf("nil format: got %s", got)
+	}
+
+	if z.IsOne() || !z.IsUint128() {
+		t.Errorf("nil IsOne/IsUint128 not as zero")
+	}
+
+	// The other methods which only read z treat nil as zero too.
+	for name, f := range map[string]func(z *Int) []interface{}{
+		"AsUint64":            func(z *Int) []interface{} { v, ok := z.AsUint64(); return []interface{}{v, ok} },
+		"AsInt64":             func(z *Int) []interface{} { v, ok := z.AsInt64(); return []interface{}{v, ok} },
+		"AsUint32":            func(z *Int) []interface{} { v, ok := z.AsUint32(); return []interface{}{v, ok} },
+		"AsInt":               func(z *Int) []interface{} { v, ok := z.AsInt(); return []interface{}{v, ok} },
+		"WordsBE":             func(z *Int) []interface{} { return []interface{}{z.WordsBE()} },
+		"LittleEndianLimbs":   func(z *Int) []interface{} { return []interface{}{z.LittleEndianLimbs()} },
+		"ToLimbs":             func(z *Int) []interface{} { return []interface{}{z.ToLimbs(64, BigEndian)} },
+		"ToBits":              func(z *Int) []interface{} { return []interface{}{z.ToBits(LittleEndian)} },
+		"PutCompact":          func(z *Int) []interface{} { return []interface{}{z.PutCompact()} },
+		"Key":                 func(z *Int) []interface{} { return []interface{}{z.Key()} },
+		"MarshalBSONValue":    func(z *Int) []interface{} { k, b, err := z.MarshalBSONValue(); return []interface{}{k, b, err} },
+		"MarshalGQL":          func(z *Int) []interface{} { var b bytes.Buffer; z.MarshalGQL(&b); return []interface{}{b.String()} },
+		"MarshalCacheCompact": func(z *Int) []interface{} { return []interface{}{z.MarshalCacheCompact()} },
+		"NAF":                 func(z *Int) []interface{} { return []interface{}{z.NAF(4)} },
+		"Booth":               func(z *Int) []interface{} { return []interface{}{z.Booth(4)} },
+		"Windows":             func(z *Int) []interface{} { return []interface{}{z.Windows(4)} },
+		"Factor64":            func(z *Int) []interface{} { f, ok := z.Factor64(); return []interface{}{f, ok} },
+		"TrailingZeroBits":    func(z *Int) []interface{} { return []interface{}{z.TrailingZeroBits()} },
+		"ToDuration":          func(z *Int) []interface{} { return []interface{}{z.ToDuration()} },
+		"Deinterleave":        func(z *Int) []interface{} { var x, y Int; z.Deinterleave(&x, &y); return []interface{}{x, y} },
+		"ToBech32":            func(z *Int) []interface{} { s, err := z.ToBech32("bc"); return []interface{}{s, err} },
+		"ToUUID":              func(z *Int) []interface{} { return []interface{}{z.ToUUID()} },
+		"ToULID":              func(z *Int) []interface{} { return []interface{}{z.ToULID()} },
+		"PaddedBytes":         func(z *Int) []interface{} { return []interface{}{z.PaddedBytes(8)} },
+		"AppendUvarint":       func(z *Int) []interface{} { return []interface{}{z.AppendUvarint(nil)} },
+		"AppendVarint":        func(z *Int) []interface{} { return []interface{}{z.AppendVarint(nil)} },
+	} {
+		if got, exp := f(z), f(new(Int)); !reflect.DeepEqual(got, exp) {
+			t.Errorf("nil %s: got %v, exp %v", name, got, exp)
+		}
+	}
+
+	// Methods which set z panic, naming themselves.
+	x := NewInt().SetOne()
+	for name, f := range map[string]func(){
+		"SetBytes":             func() { z.SetBytes([]byte{1}) },
+		"SetFromBig":           func() { z.SetFromBig(big.NewInt(1)) },
+		"SetFromDecimal":       func() { z.SetFromDecimal("1") },
+		"SetFromHex":           func() { z.SetFromHex("0x1") },
+		"SetFromString":        func() { z.SetFromString("1", ParseOptions{}) },
+		"SetUint64":            func() { z.SetUint64(1) },
+		"SetOne":               func() { z.SetOne() },
+		"SetAllOne":            func() { z.SetAllOne() },
+		"SetBool":              func() { z.SetBool(true) },
+		"Clear":                func() { z.Clear() },
+		"Copy":                 func() { z.Copy(x) },
+		"CMov":                 func() { z.CMov(1, x) },
+		"CSelect":              func() { z.CSelect(1, x, x) },
+		"Select":               func() { z.Select(true, x, x) },
+		"Interleave":           func() { z.Interleave(x, x) },
+		"SetFromBase58":        func() { z.SetFromBase58("2") },
+		"SetFromBytes32":       func() { z.SetFromBytes32(make([]byte, 32)) },
+		"SetLittleEndianLimbs": func() { z.SetLittleEndianLimbs([4]uint64{1}) },
+		"ReadUvarint":          func() { z.ReadUvarint(bytes.NewReader([]byte{1})) },
+	} {
+		func() {
+			defer func() {
+				exp := "uint256: " + name + " called on nil *Int"
+				if r, ok := recover().(error); !ok || r.Error() != exp {
+					t.Errorf("%s: got panic %v, exp %q", name, r, exp)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+// TestExplicitOperands checks the methods which take their operands
+// explicitly against the deprecated forms which operate on the receiver,
+// with and without aliasing, and that they leave their operands unmodified.
+func TestExplicitOperands(t *testing.T) {
+	type unary struct {
+		name       string
+		explicit   func(z, x *Int) *Int
+		deprecated func(z *Int)
+	}
+	unaries := []unary{
+		{"NegOf", (*Int).NegOf, func(z *Int) { z.Neg() }},
+		{"SAbs", (*Int).SAbs, func(z *Int) { z.Abs() }},
+		{"NotOf", (*Int).NotOf, func(z *Int) { z.Not() }},
+		{"SquareOf", (*Int).SquareOf, func(z *Int) { z.Squared() }},
+		{"SubUint64", func(z, x *Int) *Int { return z.SubUint64(x, 7) }, func(z *Int) { z.Sub64(z, 7) }},
+		{"ByteOf", func(z, x *Int) *Int { return z.ByteOf(&Int{20}, x) }, func(z *Int) { z.Byte(&Int{20}) }},
+		{"ExtendSign", func(z, x *Int) *Int { return z.ExtendSign(x, &Int{8}) }, func(z *Int) { z.SignExtend(&Int{8}, z) }},
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		if i%2 == 1 {
+			x.Rsh(x, uint(i%256))
+		}
+		for _, op := range unaries {
+			want := x.Clone()
+			op.deprecated(want)
+			orig := *x
+			z := new(Int).SetAllOne()
+			if have := op.explicit(z, x); have != z || !z.Eq(want) {
+				t.Fatalf("%s(%v): have %v, want %v", op.name, x.Hex(), z.Hex(), want.Hex())
+			}
+			if *x != orig {
+				t.Fatalf("%s(%v): operand modified", op.name, orig.Hex())
+			}
+			if have := op.explicit(x, x); !have.Eq(want) {
+				t.Fatalf("%s(%v) in place: have %v, want %v", op.name, orig.Hex(), have.Hex(), want.Hex())
+			}
+		}
+	}
+}
+
+func TestSrshOperand(t *testing.T) {
+	// The sign is that of x, not of the receiver.
+	x := new(Int).SetAllOne()
+	if z := new(Int).Srsh(x, 8); !z.Eq(x) {
+		t.Errorf("have %v, want %v", z.Hex(), x.Hex())
+	}
+	if z := new(Int).SetAllOne().Srsh(&Int{0x100}, 8); !z.Eq(&Int{1}) {
+		t.Errorf("have %v, want 1", z.Hex())
+	}
+}
+
+func TestExtendSign(t *testing.T) {
+	tests := []struct {
+		x, byteNum, want string
+	}{
+		{"0x80", "0x0", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80"},
+		{"0x7f", "0x0", "0x7f"},
+		{"0x1ff", "0x0", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+		{"0x12345678", "0x1", "0x5678"},
+		{"0x8000", "0x1", "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8000"},
+		{"0x8000", "0x1f", "0x8000"},
+		{"0x8000", "0x10000000000000000", "0x8000"},
+	}
+	hex := func(s string) *Int {
+		z, err := FromHex(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return z
+	}
+	for _, tc := range tests {
+		x, byteNum := hex(tc.x), hex(tc.byteNum)
+		have := new(Int).ExtendSign(x, byteNum)
+		if have.Hex() != hex(tc.want).Hex() {
+			t.Errorf("ExtendSign(%s, %s): have %v, want %s", tc.x, tc.byteNum, have.ToHex(), tc.want)
+		}
+		if x.Hex() != hex(tc.x).Hex() || byteNum.Hex() != hex(tc.byteNum).Hex() {
+			t.Errorf("ExtendSign(%s, %s): operands modified", tc.x, tc.byteNum)
+		}
+	}
+}
+
+func TestSetBool(t *testing.T) {
+	z := new(Int).SetAllOne()
+	if z.SetBool(true); !z.Eq(&Int{1}) {
+		t.Errorf("SetBool(true): have %v", z.Hex())
+	}
+	if z.SetBool(false); !z.IsZero() {
+		t.Errorf("SetBool(false): have %v", z.Hex())
+	}
+	one, two := &Int{1}, &Int{2}
+	if !one.Lte(two) || !one.Lte(one) || two.Lte(one) {
+		t.Errorf("Lte")
+	}
+	if !two.Gte(one) || !one.Gte(one) || one.Gte(two) {
+		t.Errorf("Gte")
+	}
+}
+
+func TestCmpScmp(t *testing.T) {
+	edges := []*Int{
+		{}, {1}, {^uint64(0)}, {0, 1},
+		{0, 0, 0, 1<<63 - 1}, {^uint64(0), ^uint64(0), ^uint64(0), 1<<63 - 1},
+		{0, 0, 0, 1 << 63}, {1, 0, 0, 1 << 63},
+		new(Int).SetAllOne(), {^uint64(0) - 1, ^uint64(0), ^uint64(0), ^uint64(0)},
+	}
+	for i := 0; i < 50; i++ {
+		_, x, _ := randNums()
+		_, y, _ := randHighNums()
+		edges = append(edges, x, y)
+	}
+	for _, x := range edges {
+		for _, y := range edges {
+			if have, want := x.Cmp(y), x.ToBig().Cmp(y.ToBig()); have != want {
+				t.Fatalf("%v.Cmp(%v): have %d, want %d", x.Hex(), y.Hex(), have, want)
+			}
+			if have, want := x.Scmp(y), S256(x.ToBig()).Cmp(S256(y.ToBig())); have != want {
+				t.Fatalf("%v.Scmp(%v): have %d, want %d", x.Hex(), y.Hex(), have, want)
+			}
+		}
+	}
+}
+
+func TestCmpAbs(t *testing.T) {
+	edges := []*Int{
+		{}, {1}, new(Int).SetAllOne(), {0, 0, 0, 1 << 63}, {1, 0, 0, 1 << 63},
+		{^uint64(0), ^uint64(0), ^uint64(0), 1<<63 - 1},
+	}
+	for i := 0; i < 50; i++ {
+		_, x, _ := randHighNums()
+		edges = append(edges, x, new(Int).NegOf(x))
+	}
+	for _, x := range edges {
+		bx := new(big.Int).Abs(S256(x.ToBig()))
+		if have := new(Int).SAbs(x); !checkEq(bx, have) {
+			t.Fatalf("SAbs(%v): have %v, want %#x", x.Hex(), have.Hex(), bx)
+		}
+		for _, y := range edges {
+			x0, y0 := *x, *y
+			by := new(big.Int).Abs(S256(y.ToBig()))
+			if have, want := x.CmpAbs(y), bx.Cmp(by); have != want {
+				t.Fatalf("%v.CmpAbs(%v): have %d, want %d", x.Hex(), y.Hex(), have, want)
+			}
+			if *x != x0 || *y != y0 {
+				t.Fatalf("%v.CmpAbs(%v): operands modified", x0.Hex(), y0.Hex())
+			}
+		}
+	}
+}
+
+func TestIsNegativeIsPositive(t *testing.T) {
+	for _, tc := range []struct {
+		x                  *Int
+		negative, positive bool
+	}{
+		{&Int{}, false, false},
+		{&Int{1}, false, true},
+		{&Int{0, 0, 0, 1<<63 - 1}, false, true},
+		{&Int{0, 0, 0, 1 << 63}, true, false},
+		{new(Int).SetAllOne(), true, false},
+	} {
+		if tc.x.IsNegative() != tc.negative || tc.x.IsPositive() != tc.positive {
+			t.Errorf("%v: have negative %v, positive %v, want %v, %v",
+				tc.x.Hex(), tc.x.IsNegative(), tc.x.IsPositive(), tc.negative, tc.positive)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randHighNums()
+		if i%3 == 0 {
+			x.Rsh(x, 1)
+		}
+		sign := S256(x.ToBig()).Sign()
+		if x.Sign() != sign || x.IsNegative() != (sign < 0) || x.IsPositive() != (sign > 0) {
+			t.Fatalf("%v: Sign %d, IsNegative %v, IsPositive %v, want sign %d",
+				x.Hex(), x.Sign(), x.IsNegative(), x.IsPositive(), sign)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func() *Int
+		want *big.Int
+	}{
+		{"MaxUint256", MaxUint256, tt256m1},
+		{"MinUint256", MinUint256, new(big.Int)},
+		{"MaxInt256", MaxInt256, new(big.Int).Sub(bigtt255, big.NewInt(1))},
+		{"MinInt256", MinInt256, bigtt255},
+	}
+	for _, tc := range tests {
+		x := tc.f()
+		if !checkEq(tc.want, x) {
+			t.Errorf("%s: have %v, want %#x", tc.name, x.Hex(), tc.want)
+		}
+		// Every call returns a new copy.
+		x[0]++
+		if !checkEq(tc.want, tc.f()) {
+			t.Errorf("%s: modified by a caller", tc.name)
+		}
+	}
+	if MaxInt256().Sign() != 1 || MinInt256().Sign() != -1 {
+		t.Errorf("signed limits have the wrong signs")
+	}
+	if !SignedMax.Eq(MaxInt256()) || !SignedMin.Eq(MinInt256()) {
+		t.Errorf("deprecated limits differ")
+	}
+}
+
+func TestIncDec(t *testing.T) {
+	max := MaxUint256()
+	for _, tc := range []struct {
+		x, inc, dec    *Int
+		incOver, decOv bool
+	}{
+		{&Int{}, &Int{1}, max, false, true},
+		{&Int{1}, &Int{2}, &Int{}, false, false},
+		{&Int{^uint64(0)}, &Int{0, 1}, &Int{^uint64(0) - 1}, false, false},
+		{&Int{0, 0, 0, 1}, &Int{1, 0, 0, 1}, &Int{^uint64(0), ^uint64(0), ^uint64(0)}, false, false},
+		{max, &Int{}, &Int{^uint64(0) - 1, ^uint64(0), ^uint64(0), ^uint64(0)}, true, false},
+	} {
+		z := new(Int)
+		if over := z.IncOverflow(tc.x); !z.Eq(tc.inc) || over != tc.incOver {
+			t.Errorf("IncOverflow(%v): have %v %v, want %v %v", tc.x.Hex(), z.Hex(), over, tc.inc.Hex(), tc.incOver)
+		}
+		if over := z.DecOverflow(tc.x); !z.Eq(tc.dec) || over != tc.decOv {
+			t.Errorf("DecOverflow(%v): have %v %v, want %v %v", tc.x.Hex(), z.Hex(), over, tc.dec.Hex(), tc.decOv)
+		}
+		if z := tc.x.Clone(); !z.AddOne(z).Eq(tc.inc) {
+			t.Errorf("AddOne(%v): have %v, want %v", tc.x.Hex(), z.Hex(), tc.inc.Hex())
+		}
+		if z := tc.x.Clone(); !z.SubOne(z).Eq(tc.dec) {
+			t.Errorf("SubOne(%v): have %v, want %v", tc.x.Hex(), z.Hex(), tc.dec.Hex())
+		}
+	}
+}
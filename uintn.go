@@ -0,0 +1,97 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "fmt"
+
+// WidthMode selects how a UintN handles values wider than its width.
+type WidthMode int
+
+const (
+	// WidthCheck rejects values which do not fit, like Solidity's checked
+	// arithmetic and ABI decoding.
+	WidthCheck WidthMode = iota
+	// WidthWrap truncates values to the width, keeping the low bits, like
+	// Solidity's explicit conversions and unchecked blocks.
+	WidthWrap
+)
+
+// UintN is an unsigned integer of one of the EVM widths uint8, uint16, ...,
+// uint256, for Go structs which mirror Solidity ones. The width is enforced
+// on every assignment, according to the mode. The zero value is a uint256 in
+// WidthCheck mode.
+type UintN struct {
+	value Int
+	bits  uint // 0 means 256
+	mode  WidthMode
+}
+
+// NewUintN returns a zero UintN of the given width, which must be a multiple
+// of 8 in the range [8, 256].
+func NewUintN(bits uint, mode WidthMode) *UintN {
+	if bits == 0 || bits > 256 || bits%8 != 0 {
+		panic("uint256: NewUintN with invalid width")
+	}
+	return &UintN{bits: bits % 256, mode: mode}
+}
+
+// Bits returns the width of u.
+func (u *UintN) Bits() uint {
+	if u.bits == 0 {
+		return 256
+	}
+	return u.bits
+}
+
+// Mode returns the WidthMode of u.
+func (u *UintN) Mode() WidthMode { return u.mode }
+
+// Value returns a copy of the value of u.
+func (u *UintN) Value() *Int { return u.value.Clone() }
+
+// Set sets the value of u to x. In WidthCheck mode, it returns an error
+// (wrapping ErrOutOfRange) if x does not fit, leaving u unchanged; in
+// WidthWrap mode, it keeps the low bits of x and never fails.
+func (u *UintN) Set(x *Int) error {
+	bits := u.Bits()
+	if uint(x.BitLen()) <= bits {
+		u.value = *x
+		return nil
+	}
+	if u.mode == WidthCheck {
+		return fmt.Errorf("%w: %s does not fit in uint%d", ErrOutOfRange, x.Dec(), bits)
+	}
+	var mask Int
+	mask.SetAllOne().Rsh(&mask, 256-bits)
+	u.value.And(x, &mask)
+	return nil
+}
+
+// SetUint64 is like Set, for a uint64 value.
+func (u *UintN) SetUint64(v uint64) error {
+	return u.Set(new(Int).SetUint64(v))
+}
+
+// String returns the decimal representation of the value of u.
+func (u *UintN) String() string {
+	return u.value.Dec()
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the value as a
+// decimal string.
+func (u *UintN) MarshalText() ([]byte, error) {
+	return []byte(u.value.Dec()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting a decimal or
+// 0x-prefixed hex string, and assigning it as Set does.
+func (u *UintN) UnmarshalText(text []byte) error {
+	var x Int
+	if err := x.setFromDecimalOrHex(string(text)); err != nil {
+		return err
+	}
+	return u.Set(&x)
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestUintNCheck(t *testing.T) {
+	u := NewUintN(48, WidthCheck)
+	if u.Bits() != 48 || u.Mode() != WidthCheck || !u.Value().IsZero() {
+		t.Fatalf("bad initial state: %d %d %v", u.Bits(), u.Mode(), u.Value())
+	}
+	if err := u.SetUint64(1<<48 - 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := u.SetUint64(1 << 48); !errors.Is(err, ErrOutOfRange) {
+		t.Fatalf("got err %v, exp ErrOutOfRange", err)
+	}
+	if !u.Value().Eq(new(Int).SetUint64(1<<48 - 1)) {
+		t.Errorf("value changed by failed Set: %v", u.Value())
+	}
+}
+
+func TestUintNWrap(t *testing.T) {
+	u := NewUintN(8, WidthWrap)
+	if err := u.SetUint64(0x1ff); err != nil {
+		t.Fatal(err)
+	}
+	if !u.Value().Eq(new(Int).SetUint64(0xff)) {
+		t.Errorf("got %v, exp 0xff", u.Value())
+	}
+	for bits := uint(8); bits <= 256; bits += 8 {
+		u := NewUintN(bits, WidthWrap)
+		_, x, _ := randHighNums()
+		u.Set(x)
+		exp := new(Int).Lsh(x, 256-bits)
+		exp.Rsh(exp, 256-bits)
+		if !u.Value().Eq(exp) {
+			t.Errorf("uint%d: got %v, exp %v", bits, u.Value(), exp)
+		}
+	}
+}
+
+func TestUintNZeroValue(t *testing.T) {
+	var u UintN
+	if u.Bits() != 256 {
+		t.Errorf("got %d bits, exp 256", u.Bits())
+	}
+	if err := u.Set(new(Int).SetAllOne()); err != nil {
+		t.Errorf("zero value rejected 2**256-1: %v", err)
+	}
+	if NewUintN(256, WidthCheck).Bits() != 256 {
+		t.Errorf("NewUintN(256) has wrong width")
+	}
+}
+
+func TestUintNInvalidWidth(t *testing.T) {
+	for _, bits := range []uint{0, 7, 12, 264} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewUintN(%d): expected panic", bits)
+				}
+			}()
+			NewUintN(bits, WidthCheck)
+		}()
+	}
+}
+
+func TestUintNJSON(t *testing.T) {
+	type transfer struct {
+		Amount *UintN `json:"amount"`
+	}
+	v := transfer{Amount: NewUintN(96, WidthCheck)}
+	if err := json.Unmarshal([]byte(`{"amount":"0xffffffffffffffffffffffff"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := `{"amount":"79228162514264337593543950335"}`; string(out) != exp {
+		t.Errorf("got %s, exp %s", out, exp)
+	}
+	if err := json.Unmarshal([]byte(`{"amount":"0x1000000000000000000000000"}`), &v); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("got err %v, exp ErrOutOfRange", err)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	ulidAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ" // Crockford's base32
+	ulidLen      = 26
+)
+
+var errInvalidULID = errors.New("uint256: invalid ULID")
+
+// SetFromUUID sets z to the 128-bit big-endian value of the UUID u,
+// and returns z.
+func (z *Int) SetFromUUID(u [16]byte) *Int {
+	if z == nil {
+		panic(nilReceiver("SetFromUUID"))
+	}
+	z[3], z[2] = 0, 0
+	z[1] = binary.BigEndian.Uint64(u[0:8])
+	z[0] = binary.BigEndian.Uint64(u[8:16])
+	return z
+}
+
+// FromUUID is a convenience-constructor for SetFromUUID.
+func FromUUID(u [16]byte) *Int {
+	return new(Int).SetFromUUID(u)
+}
+
+// ToUUID returns the low 128 bits of z as a big-endian UUID.
+func (z *Int) ToUUID() [16]byte {
+	z = orZero(z)
+	var u [16]byte
+	binary.BigEndian.PutUint64(u[0:8], z[1])
+	binary.BigEndian.PutUint64(u[8:16], z[0])
+	return u
+}
+
+// ToULID returns the low 128 bits of z as a 26-character ULID string,
+// in Crockford's base32.
+func (z *Int) ToULID() string {
+	var buf [ulidLen]byte
+	for i := range buf {
+		buf[ulidLen-1-i] = ulidAlphabet[z.Window(uint(5*i), 5)]
+	}
+	// The top character holds only the bits 125..127.
+	buf[0] = ulidAlphabet[z.Window(125, 3)]
+	return string(buf[:])
+}
+
+// SetFromULID sets z to the 128-bit value of the ULID string s. Decoding is
+// case-insensitive. Errors are of type *ParseError.
+func (z *Int) SetFromULID(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromULID"))
+	}
+	switch {
+	case len(s) != ulidLen:
+		return newParseError(s, -1, expectULID, errInvalidULID)
+	case s[0] > '7':
+		// The first digit only holds 3 bits.
+		return newParseError(s, 0, expectULID, errInvalidULID)
+	}
+	var v Int
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		d := -1
+		for j := 0; j < len(ulidAlphabet); j++ {
+			if ulidAlphabet[j] == c {
+				d = j
+				break
+			}
+		}
+		if d < 0 {
+			return newParseError(s, i, expectULID, errInvalidULID)
+		}
+		v.Lsh(&v, 5)
+		v[0] |= uint64(d)
+	}
+	z.Copy(&v)
+	return nil
+}
+
+// FromULID is a convenience-constructor for SetFromULID.
+func FromULID(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromULID(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "testing"
+
+func TestUUID(t *testing.T) {
+	var u [16]byte
+	copy(u[:], hex2Bytes("123e4567e89b12d3a456426614174000"))
+	z := FromUUID(u)
+	if z[1] != 0x123e4567e89b12d3 || z[0] != 0xa456426614174000 || !z.IsUint128() {
+		t.Errorf("got %v", z.Hex())
+	}
+	// Bits above 128 are ignored.
+	z.SetAllOne().SetFromUUID(u)
+	z[3] = 0xff
+	if got := z.ToUUID(); got != u {
+		t.Errorf("got %x exp %x", got, u)
+	}
+}
+
+func TestULID(t *testing.T) {
+	exp := &Int{0x4c61efb99302bd5b, 0x01563e3ab5d3d676}
+	z, err := FromULID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil || !z.Eq(exp) {
+		t.Fatalf("got %v (err=%v), exp %v", z, err, exp.Hex())
+	}
+	if z, err := FromULID("01arz3ndektsv4rrffq69g5fav"); err != nil || !z.Eq(exp) {
+		t.Errorf("lowercase: got %v (err=%v)", z, err)
+	}
+	if got := exp.ToULID(); got != "01ARZ3NDEKTSV4RRFFQ69G5FAV" {
+		t.Errorf("got %s", got)
+	}
+	if got := new(Int).ToULID(); got != "00000000000000000000000000" {
+		t.Errorf("got %s", got)
+	}
+	if got := new(Int).SetAllOne().ToULID(); got != "7ZZZZZZZZZZZZZZZZZZZZZZZZZ" {
+		t.Errorf("got %s", got)
+	}
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU"} {
+		if _, err := FromULID(s); err == nil {
+			t.Errorf("expected error for %q", s)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		x[2], x[3] = 0, 0
+		if z, err := FromULID(x.ToULID()); err != nil || !z.Eq(x) {
+			t.Fatalf("round trip %v: got %v (err=%v)", x.Hex(), z, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+// This file provides a value-based alternative to the z = op(x, y) methods:
+// functions which take their operands and return their result as Int values.
+// Since an Int is a 32-byte array, passing it by value is cheap, and no
+// result can ever alias an operand. Those which modify their operands as
+// methods (Sdiv, Smod) only modify their own copies here.
+
+// Add returns a + b mod 2**256.
+func Add(a, b Int) Int {
+	var z Int
+	z.Add(&a, &b)
+	return z
+}
+
+// AddOverflow returns a + b mod 2**256, and whether it overflowed.
+func AddOverflow(a, b Int) (Int, bool) {
+	var z Int
+	overflow := z.AddOverflow(&a, &b)
+	return z, overflow
+}
+
+// Sub returns a - b mod 2**256.
+func Sub(a, b Int) Int {
+	var z Int
+	z.Sub(&a, &b)
+	return z
+}
+
+// SubOverflow returns a - b mod 2**256, and whether it underflowed.
+func SubOverflow(a, b Int) (Int, bool) {
+	var z Int
+	underflow := z.SubOverflow(&a, &b)
+	return z, underflow
+}
+
+// Mul returns a * b mod 2**256.
+func Mul(a, b Int) Int {
+	var z Int
+	z.Mul(&a, &b)
+	return z
+}
+
+// Div returns a / b, or 0 if b == 0.
+func Div(a, b Int) Int {
+	var z Int
+	z.Div(&a, &b)
+	return z
+}
+
+// Mod returns a % b, or 0 if b == 0.
+func Mod(a, b Int) Int {
+	var z Int
+	z.Mod(&a, &b)
+	return z
+}
+
+// Sdiv returns a / b, with a and b interpreted as two's complement signed
+// integers, or 0 if b == 0.
+func Sdiv(a, b Int) Int {
+	var z Int
+	z.Sdiv(&a, &b)
+	return z
+}
+
+// Smod returns a % b, with a and b interpreted as two's complement signed
+// integers and the result taking the sign of a, or 0 if b == 0.
+func Smod(a, b Int) Int {
+	var z Int
+	z.Smod(&a, &b)
+	return z
+}
+
+// AddMod returns (a + b) % m, or 0 if m == 0.
+func AddMod(a, b, m Int) Int {
+	var z Int
+	if m.IsZero() {
+		// The method requires a non-zero modulus.
+		return z
+	}
+	z.AddMod(&a, &b, &m)
+	return z
+}
+
+// MulMod returns (a * b) % m, or 0 if m == 0.
+func MulMod(a, b, m Int) Int {
+	var z Int
+	if m.IsZero() {
+		// The method requires a non-zero modulus.
+		return z
+	}
+	z.MulMod(&a, &b, &m)
+	return z
+}
+
+// Exp returns base**exponent mod 2**256.
+func Exp(base, exponent Int) Int {
+	var z Int
+	z.Exp(&base, &exponent)
+	return z
+}
+
+// Lsh returns a << n.
+func Lsh(a Int, n uint) Int {
+	var z Int
+	z.Lsh(&a, n)
+	return z
+}
+
+// Rsh returns a >> n.
+func Rsh(a Int, n uint) Int {
+	var z Int
+	z.Rsh(&a, n)
+	return z
+}
+
+// Srsh returns a >> n, with a interpreted as a two's complement signed
+// integer.
+func Srsh(a Int, n uint) Int {
+	var z Int
+	z.Srsh(&a, n)
+	return z
+}
+
+// And returns a & b.
+func And(a, b Int) Int {
+	var z Int
+	z.And(&a, &b)
+	return z
+}
+
+// Or returns a | b.
+func Or(a, b Int) Int {
+	var z Int
+	z.Or(&a, &b)
+	return z
+}
+
+// Xor returns a ^ b.
+func Xor(a, b Int) Int {
+	var z Int
+	z.Xor(&a, &b)
+	return z
+}
+
+// Not returns ^a.
+func Not(a Int) Int {
+	return *a.NotOf(&a)
+}
+
+// Neg returns -a mod 2**256.
+func Neg(a Int) Int {
+	return *a.NegOf(&a)
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b, and +1 if a > b.
+func Cmp(a, b Int) int {
+	return a.Cmp(&b)
+}
+
+// Scmp compares a and b as two's complement signed integers, and returns
+// -1 if a < b, 0 if a == b, and +1 if a > b.
+func Scmp(a, b Int) int {
+	return a.Scmp(&b)
+}
+
+// Eq returns whether a == b.
+func Eq(a, b Int) bool {
+	return a == b
+}
+
+// Lt returns whether a < b.
+func Lt(a, b Int) bool {
+	return a.Lt(&b)
+}
+
+// Gt returns whether a > b.
+func Gt(a, b Int) bool {
+	return b.Lt(&a)
+}
+
+// Slt returns whether a < b, with a and b interpreted as two's complement
+// signed integers.
+func Slt(a, b Int) bool {
+	return a.Slt(&b)
+}
+
+// Sgt returns whether a > b, with a and b interpreted as two's complement
+// signed integers.
+func Sgt(a, b Int) bool {
+	return a.Sgt(&b)
+}
+
+// Lte returns whether a <= b.
+func Lte(a, b Int) bool {
+	return !b.Lt(&a)
+}
+
+// Gte returns whether a >= b.
+func Gte(a, b Int) bool {
+	return !a.Lt(&b)
+}
+
+// BoolToInt returns 1 if b is true, and 0 otherwise.
+func BoolToInt(b bool) Int {
+	var z Int
+	z.SetBool(b)
+	return z
+}
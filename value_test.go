@@ -0,0 +1,126 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestValueBinaryOps(t *testing.T) {
+	for name, tc := range map[string]struct {
+		value  func(a, b Int) Int
+		method func(z, a, b *Int) *Int
+	}{
+		"Add":  {Add, (*Int).Add},
+		"Sub":  {Sub, (*Int).Sub},
+		"Mul":  {Mul, (*Int).Mul},
+		"Div":  {Div, (*Int).Div},
+		"Mod":  {Mod, (*Int).Mod},
+		"Sdiv": {Sdiv, (*Int).Sdiv},
+		"Smod": {Smod, (*Int).Smod},
+		"Exp":  {Exp, (*Int).Exp},
+		"And":  {And, (*Int).And},
+		"Or":   {Or, (*Int).Or},
+		"Xor":  {Xor, (*Int).Xor},
+	} {
+		for i := 0; i < 100; i++ {
+			_, a, _ := randHighNums()
+			_, b, _ := randNums()
+			a0, b0 := *a, *b
+			got := tc.value(*a, *b)
+			exp := tc.method(new(Int), a.Clone(), b.Clone())
+			if got != *exp {
+				t.Fatalf("%s(%v, %v): got %v, exp %v", name, a.Hex(), b.Hex(), got.Hex(), exp.Hex())
+			}
+			if *a != a0 || *b != b0 {
+				t.Fatalf("%s modified its operands", name)
+			}
+		}
+	}
+}
+
+func TestValueModOps(t *testing.T) {
+	// A zero modulus gives zero, where the methods would panic.
+	max := *new(Int).SetAllOne()
+	if z := AddMod(max, max, Int{}); !z.IsZero() {
+		t.Errorf("AddMod with zero modulus: got %v", z.Hex())
+	}
+	if z := MulMod(max, max, Int{}); !z.IsZero() {
+		t.Errorf("MulMod with zero modulus: got %v", z.Hex())
+	}
+	for i := 0; i < 100; i++ {
+		_, a, _ := randHighNums()
+		_, b, _ := randHighNums()
+		_, m, _ := randNums()
+		if m.IsZero() {
+			m.SetOne()
+		}
+		if got, exp := AddMod(*a, *b, *m), new(Int).AddMod(a, b, m); got != *exp {
+			t.Fatalf("AddMod: got %v, exp %v", got.Hex(), exp.Hex())
+		}
+		if got, exp := MulMod(*a, *b, *m), new(Int).MulMod(a, b, m); got != *exp {
+			t.Fatalf("MulMod: got %v, exp %v", got.Hex(), exp.Hex())
+		}
+	}
+}
+
+func TestValueOverflow(t *testing.T) {
+	max := *new(Int).SetAllOne()
+	if z, overflow := AddOverflow(max, Int{1}); !overflow || !z.IsZero() {
+		t.Errorf("AddOverflow: got %v, %v", z.Hex(), overflow)
+	}
+	if z, underflow := SubOverflow(Int{}, Int{1}); !underflow || z != max {
+		t.Errorf("SubOverflow: got %v, %v", z.Hex(), underflow)
+	}
+	if _, overflow := AddOverflow(Int{1}, Int{2}); overflow {
+		t.Errorf("AddOverflow: unexpected overflow")
+	}
+}
+
+func TestValueShifts(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		b, a, _ := randHighNums()
+		n := uint(i * 3)
+		if got, exp := Lsh(*a, n), new(Int).Lsh(a, n); got != *exp {
+			t.Fatalf("Lsh: got %v, exp %v", got.Hex(), exp.Hex())
+		}
+		if got, exp := Rsh(*a, n), new(Int).Rsh(a, n); got != *exp {
+			t.Fatalf("Rsh: got %v, exp %v", got.Hex(), exp.Hex())
+		}
+		exp := new(big.Int).Rsh(S256(b), n)
+		got := Srsh(*a, n)
+		if !checkEq(U256(exp), &got) {
+			t.Fatalf("Srsh(%v, %d): got %v, exp %x", a.Hex(), n, got.Hex(), exp)
+		}
+	}
+}
+
+func TestValueUnaryAndCompare(t *testing.T) {
+	one, two := Int{1}, Int{2}
+	minusOne := *new(Int).SetAllOne()
+	if Not(Int{}) != minusOne || Neg(one) != minusOne {
+		t.Errorf("Not/Neg")
+	}
+	if Cmp(one, two) != -1 || Cmp(two, one) != 1 || Cmp(one, one) != 0 || Scmp(minusOne, one) != -1 {
+		t.Errorf("Cmp")
+	}
+	if !Eq(one, one) || Eq(one, two) {
+		t.Errorf("Eq")
+	}
+	if !Lt(one, two) || Lt(two, one) || !Gt(two, one) || Gt(one, two) {
+		t.Errorf("Lt/Gt")
+	}
+	if !Slt(minusOne, one) || Sgt(minusOne, one) || !Sgt(one, minusOne) {
+		t.Errorf("Slt/Sgt")
+	}
+	if !Lte(one, two) || !Lte(one, one) || Lte(two, one) || !Gte(two, one) || !Gte(one, one) || Gte(one, two) {
+		t.Errorf("Lte/Gte")
+	}
+	if BoolToInt(true) != one || BoolToInt(false) != (Int{}) {
+		t.Errorf("BoolToInt")
+	}
+}
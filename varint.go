@@ -0,0 +1,194 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"errors"
+	"io"
+)
+
+// MaxVarintLen256 is the maximum length of a varint-encoded 256-bit integer.
+const MaxVarintLen256 = 37
+
+var errVarintOverflow = errors.New("uint256: varint overflows a 256-bit integer")
+
+// uvarintLen returns the number of bytes needed to encode z as a uvarint.
+func (z *Int) uvarintLen() int {
+	if n := (z.BitLen() + 6) / 7; n > 0 {
+		return n
+	}
+	return 1
+}
+
+// PutUvarint encodes z into buf as an unsigned LEB128 (protobuf-style) varint,
+// and returns the number of bytes written. If the buffer is too small,
+// PutUvarint will panic.
+func (z *Int) PutUvarint(buf []byte) int {
+	n := z.uvarintLen()
+	_ = buf[n-1] // bounds check hint to compiler
+	for i := 0; i < n-1; i++ {
+		buf[i] = byte(z.Window(uint(7*i), 7)) | 0x80
+	}
+	buf[n-1] = byte(z.Window(uint(7*(n-1)), 7))
+	return n
+}
+
+// AppendUvarint appends the uvarint-encoded form of z to dst and returns the
+// extended buffer.
+func (z *Int) AppendUvarint(dst []byte) []byte {
+	var buf [MaxVarintLen256]byte
+	n := z.PutUvarint(buf[:])
+	return append(dst, buf[:n]...)
+}
+
+// WriteUvarint writes the uvarint-encoded form of z to w.
+func (z *Int) WriteUvarint(w io.Writer) (int, error) {
+	var buf [MaxVarintLen256]byte
+	n := z.PutUvarint(buf[:])
+	return w.Write(buf[:n])
+}
+
+// setUvarintByte ors the 7 payload bits of the i'th byte into z, and reports
+// whether they fit in 256 bits.
+func (z *Int) setUvarintByte(i int, b byte) bool {
+	if i == MaxVarintLen256-1 && b > 0x0f {
+		return false // Only the low 4 bits of the last byte fit.
+	}
+	pos := uint(7 * i)
+	v := uint64(b & 0x7f)
+	z[pos>>6] |= v << (pos & 0x3f)
+	if pos&0x3f > 57 && pos>>6 < 3 {
+		z[pos>>6+1] |= v >> (64 - pos&0x3f)
+	}
+	return true
+}
+
+// SetUvarint decodes a uvarint from buf, sets z to the value, and returns the
+// number of bytes read (> 0). If an error occurred, z is set to 0 and the
+// number of bytes n is <= 0 meaning:
+//
+//	n == 0: buf too small
+//	n  < 0: value larger than 256 bits (overflow)
+//	        and -n is the number of bytes read
+func (z *Int) SetUvarint(buf []byte) int {
+	if z == nil {
+		panic(nilReceiver("SetUvarint"))
+	}
+	z.Clear()
+	for i, b := range buf {
+		if i == MaxVarintLen256 || !z.setUvarintByte(i, b) {
+			z.Clear()
+			return -(i + 1) // overflow
+		}
+		if b < 0x80 {
+			return i + 1
+		}
+	}
+	z.Clear()
+	return 0
+}
+
+// ReadUvarint reads a uvarint from r and sets z to the value.
+// The error is io.EOF only if no bytes were read. If an EOF happens after
+// reading some but not all the bytes, ReadUvarint returns io.ErrUnexpectedEOF.
+// A value larger than 256 bits is reported as a *ParseError with the bytes
+// read as its input.
+func (z *Int) ReadUvarint(r io.ByteReader) error {
+	if z == nil {
+		panic(nilReceiver("ReadUvarint"))
+	}
+	var read [MaxVarintLen256]byte // For the input of a ParseError.
+	z.Clear()
+	for i := 0; i < MaxVarintLen256; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			z.Clear()
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
+		}
+		read[i] = b
+		if !z.setUvarintByte(i, b) {
+			z.Clear()
+			return newParseError(string(read[:i+1]), i, expectUvarint, errVarintOverflow)
+		}
+		if b < 0x80 {
+			return nil
+		}
+	}
+	// The last byte must not have been continued.
+	z.Clear()
+	return newParseError(string(read[:]), MaxVarintLen256-1, expectUvarint, errVarintOverflow)
+}
+
+// zigzag sets z to the zigzag encoding of x, interpreted as a signed number.
+func (z *Int) zigzag(x *Int) *Int {
+	negative := x.IsNegative()
+	z.Lsh(x, 1)
+	if negative {
+		z.NotOf(z)
+	}
+	return z
+}
+
+// unzigzag sets z to the signed number whose zigzag encoding is x.
+func (z *Int) unzigzag(x *Int) *Int {
+	negative := x[0]&1 == 1
+	z.Rsh(x, 1)
+	if negative {
+		z.NotOf(z)
+	}
+	return z
+}
+
+// PutVarint interprets z as a signed number, and encodes it into buf as a
+// zigzag LEB128 varint. It returns the number of bytes written. If the buffer
+// is too small, PutVarint will panic.
+func (z *Int) PutVarint(buf []byte) int {
+	z = orZero(z)
+	var u Int
+	return u.zigzag(z).PutUvarint(buf)
+}
+
+// AppendVarint appends the varint-encoded form of z, interpreted as a signed
+// number, to dst and returns the extended buffer.
+func (z *Int) AppendVarint(dst []byte) []byte {
+	z = orZero(z)
+	var u Int
+	return u.zigzag(z).AppendUvarint(dst)
+}
+
+// WriteVarint writes the varint-encoded form of z, interpreted as a signed
+// number, to w.
+func (z *Int) WriteVarint(w io.Writer) (int, error) {
+	z = orZero(z)
+	var u Int
+	return u.zigzag(z).WriteUvarint(w)
+}
+
+// SetVarint decodes a zigzag varint from buf, sets z to the (two's complement)
+// value, and returns the number of bytes read. The return value follows the
+// same conventions as SetUvarint.
+func (z *Int) SetVarint(buf []byte) int {
+	if z == nil {
+		panic(nilReceiver("SetVarint"))
+	}
+	n := z.SetUvarint(buf)
+	z.unzigzag(z)
+	return n
+}
+
+// ReadVarint reads a zigzag varint from r and sets z to the (two's complement)
+// value. The errors follow the same conventions as ReadUvarint.
+func (z *Int) ReadVarint(r io.ByteReader) error {
+	if z == nil {
+		panic(nilReceiver("ReadVarint"))
+	}
+	err := z.ReadUvarint(r)
+	z.unzigzag(z)
+	return err
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestUvarintSmall(t *testing.T) {
+	// Values which fit in 64 bits must encode exactly as encoding/binary does.
+	for _, v := range []uint64{0, 1, 0x7f, 0x80, 0x3fff, 0x4000, 1 << 63, ^uint64(0)} {
+		exp := make([]byte, binary.MaxVarintLen64)
+		exp = exp[:binary.PutUvarint(exp, v)]
+		if got := new(Int).SetUint64(v).AppendUvarint(nil); !bytes.Equal(got, exp) {
+			t.Errorf("%d: got %x exp %x", v, got, exp)
+		}
+	}
+	for _, v := range []int64{0, 1, -1, 63, -64, 64, -65, 1<<62 - 1} {
+		exp := make([]byte, binary.MaxVarintLen64)
+		exp = exp[:binary.PutVarint(exp, v)]
+		x := new(Int).SetUint64(uint64(v))
+		if v < 0 {
+			x.SetUint64(uint64(-v)).Neg()
+		}
+		if got := x.AppendVarint(nil); !bytes.Equal(got, exp) {
+			t.Errorf("%d: got %x exp %x", v, got, exp)
+		}
+	}
+}
+
+func TestVarintRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	values := []*Int{new(Int), new(Int).SetAllOne(), MinInt256(), MaxInt256()}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		values = append(values, x)
+	}
+	for _, x := range values {
+		enc := x.AppendUvarint(nil)
+		if len(enc) > MaxVarintLen256 {
+			t.Fatalf("%v: encoding too long: %d", x.Hex(), len(enc))
+		}
+		var z Int
+		if n := z.SetUvarint(enc); n != len(enc) || !z.Eq(x) {
+			t.Fatalf("uvarint %x: got %v (n=%d), exp %v", enc, z.Hex(), n, x.Hex())
+		}
+		enc = x.AppendVarint(nil)
+		if n := z.SetVarint(enc); n != len(enc) || !z.Eq(x) {
+			t.Fatalf("varint %x: got %v (n=%d), exp %v", enc, z.Hex(), n, x.Hex())
+		}
+		if _, err := x.WriteUvarint(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := x.WriteVarint(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, x := range values {
+		var z Int
+		if err := z.ReadUvarint(&buf); err != nil || !z.Eq(x) {
+			t.Fatalf("ReadUvarint: got %v (err=%v), exp %v", z.Hex(), err, x.Hex())
+		}
+		if err := z.ReadVarint(&buf); err != nil || !z.Eq(x) {
+			t.Fatalf("ReadVarint: got %v (err=%v), exp %v", z.Hex(), err, x.Hex())
+		}
+	}
+	if err := new(Int).ReadUvarint(&buf); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestUvarintErrors(t *testing.T) {
+	max := new(Int).SetAllOne().AppendUvarint(nil)
+	var z Int
+	if n := z.SetUvarint(max[:len(max)-1]); n != 0 || !z.IsZero() {
+		t.Errorf("short buffer: got n=%d z=%v", n, z.Hex())
+	}
+	if err := z.ReadUvarint(bytes.NewReader(max[:5])); err != io.ErrUnexpectedEOF {
+		t.Errorf("short reader: got %v", err)
+	}
+	// One more bit in the last byte overflows.
+	over := append([]byte{}, max...)
+	over[len(over)-1] = 0x1f
+	if n := z.SetUvarint(over); n != -len(over) || !z.IsZero() {
+		t.Errorf("overflow: got n=%d z=%v", n, z.Hex())
+	}
+	if err := z.ReadUvarint(bytes.NewReader(over)); !errors.Is(err, errVarintOverflow) {
+		t.Errorf("overflow: got %v", err)
+	}
+	// Too many continuation bytes.
+	long := bytes.Repeat([]byte{0x80}, MaxVarintLen256+1)
+	if n := z.SetUvarint(long); n >= 0 {
+		t.Errorf("too long: got n=%d", n)
+	}
+	if err := z.ReadUvarint(bytes.NewReader(long)); !errors.Is(err, errVarintOverflow) {
+		t.Errorf("too long: got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import "errors"
+
+// WireFormat identifies one of the encodings commonly used to carry Ints
+// between services.
+type WireFormat int
+
+const (
+	// WireDecimal is the strict decimal string form, as parsed by
+	// SetFromDecimal and produced by Dec.
+	WireDecimal WireFormat = iota
+	// WireHex is the 0x-prefixed hex string form, as parsed by SetFromHex and
+	// produced by ToHex.
+	WireHex
+	// WireBytes32 is the fixed 32-byte big-endian form, as parsed by
+	// SetFromBytes32 and produced by Bytes32.
+	WireBytes32
+)
+
+const hexDigits = "0123456789abcdef"
+
+var (
+	errMissingPrefix = errors.New("uint256: hex string without 0x prefix")
+	errHexSyntax     = errors.New("uint256: invalid hex string")
+	errBytes32Len    = errors.New("uint256: byte slice must be 32 bytes")
+	errWireFormat    = errors.New("uint256: unknown wire format")
+)
+
+// ToHex returns the hex form of z, with a 0x prefix, in lower case and
+// without leading zeros; 0 is "0x0".
+func (z *Int) ToHex() string {
+	var buf [2 + 64]byte
+	return string(buf[:z.EncodeHex(buf[:])])
+}
+
+// SHex returns the hex form of z interpreted as a two's complement signed
+// number, like ToHex for its magnitude, with a leading minus sign if it is
+// negative, e.g. "-0x1" for 2**256 - 1.
+func (z *Int) SHex() string {
+	if z.IsNegative() {
+		var y Int
+		return "-" + y.NegOf(z).ToHex()
+	}
+	return z.ToHex()
+}
+
+// HexLen returns the length of the hex form of z, as returned by ToHex and
+// written by EncodeHex.
+func (z *Int) HexLen() int {
+	if nibbles := (z.BitLen() + 3) / 4; nibbles > 1 {
+		return 2 + nibbles
+	}
+	return 3
+}
+
+// EncodeHex writes the hex form of z, as returned by ToHex, to the start of
+// dst, and returns the number of bytes written, which is z.HexLen(). It
+// panics if dst is shorter than that; 66 bytes are always enough.
+func (z *Int) EncodeHex(dst []byte) int {
+	n := z.HexLen()
+	_ = dst[n-1] // Bounds check against len(dst), which dst[:n] would not do.
+	out := dst[:n]
+	out[0], out[1] = '0', 'x'
+	if z == nil {
+		out[2] = '0'
+		return n
+	}
+	for i := 0; i < n-2; i++ {
+		out[n-1-i] = hexDigits[z.Window(uint(4*i), 4)]
+	}
+	return n
+}
+
+// hexValue returns the value of the hex digit c, or 0xff if it is not one.
+func hexValue(c byte) byte {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0'
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10
+	}
+	return 0xff
+}
+
+// SetFromHex sets z from the hex string s, which must have a 0x (or 0X)
+// prefix followed by at least one hex digit of either case, and a value of at
+// most 2**256 - 1. Leading zeros are allowed, so fixed-width forms are
+// accepted. Errors are of type *ParseError. The policy is HexOptions.
+func (z *Int) SetFromHex(s string) error {
+	if z == nil {
+		panic(nilReceiver("SetFromHex"))
+	}
+	return z.parse(s, &hexOptions)
+}
+
+// setHexDigits sets z from the hex digits s[start:], returning the offset of
+// the error in s, or -1 for overflow.
+func (z *Int) setHexDigits(s string, start int) (int, error) {
+	var res Int
+	for i := start; i < len(s); i++ {
+		v := hexValue(s[i])
+		if v == 0xff {
+			return i, errHexSyntax
+		}
+		if res[3]>>60 != 0 {
+			return -1, errOverflow
+		}
+		res.Lsh(&res, 4)
+		res[0] |= uint64(v)
+	}
+	z.Copy(&res)
+	return 0, nil
+}
+
+// FromHex is a convenience-constructor for SetFromHex.
+func FromHex(s string) (*Int, error) {
+	z := new(Int)
+	if err := z.SetFromHex(s); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+// setFromDecimalOrHex sets z from s, using SetFromHex if s has a 0x prefix,
+// and SetFromDecimal otherwise.
+func (z *Int) setFromDecimalOrHex(s string) error {
+	if hasHexPrefix(s) {
+		return z.SetFromHex(s)
+	}
+	return z.SetFromDecimal(s)
+}
+
+// SetFromBytes32 sets z from the 32-byte big-endian slice b. Unlike SetBytes,
+// any other length is an error rather than being padded or truncated.
+func (z *Int) SetFromBytes32(b []byte) error {
+	if z == nil {
+		panic(nilReceiver("SetFromBytes32"))
+	}
+	if len(b) != 32 {
+		// The error is at the end of a short slice, or at the first excess
+		// byte.
+		offset := len(b)
+		if offset > 32 {
+			offset = 32
+		}
+		return newParseError(string(b), offset, expectBytes32, errBytes32Len)
+	}
+	z.SetBytes(b)
+	return nil
+}
+
+// MarshalWire returns z encoded in the given wire format.
+func (z *Int) MarshalWire(f WireFormat) ([]byte, error) {
+	switch f {
+	case WireDecimal:
+		return []byte(z.Dec()), nil
+	case WireHex:
+		return []byte(z.ToHex()), nil
+	case WireBytes32:
+		b := z.Bytes32()
+		return b[:], nil
+	}
+	return nil, errWireFormat
+}
+
+// UnmarshalWire sets z from data in the given wire format, with the strict
+// validation of the corresponding parser.
+func (z *Int) UnmarshalWire(f WireFormat, data []byte) error {
+	if z == nil {
+		panic(nilReceiver("UnmarshalWire"))
+	}
+	switch f {
+	case WireDecimal:
+		return z.SetFromDecimal(string(data))
+	case WireHex:
+		return z.SetFromHex(string(data))
+	case WireBytes32:
+		return z.SetFromBytes32(data)
+	}
+	return errWireFormat
+}
+
+// ConvertWire validates data in the wire format from, and returns it
+// re-encoded in the wire format to.
+func ConvertWire(data []byte, from, to WireFormat) ([]byte, error) {
+	var z Int
+	if err := z.UnmarshalWire(from, data); err != nil {
+		return nil, err
+	}
+	return z.MarshalWire(to)
+}
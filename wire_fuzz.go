@@ -0,0 +1,64 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+//go:build gofuzz
+// +build gofuzz
+
+package uint256
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+var (
+	strictDecimal = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+	strictHex     = regexp.MustCompile(`^0[xX][0-9a-fA-F]+$`)
+)
+
+// FuzzWire checks the wire format parsers against math/big and regular
+// expressions for the accepted syntax, and that every accepted input
+// round-trips through all formats. Run it with go-fuzz -func FuzzWire.
+func FuzzWire(data []byte) int {
+	s := string(data)
+	var z Int
+	if err := z.SetFromDecimal(s); err == nil {
+		b, ok := new(big.Int).SetString(s, 10)
+		if !strictDecimal.MatchString(s) || !ok || b.Cmp(z.ToBig()) != 0 {
+			panic(fmt.Sprintf("decimal %q accepted as %v", s, z.Hex()))
+		}
+		if z.Dec() != s {
+			panic(fmt.Sprintf("decimal %q did not round trip", s))
+		}
+		checkWire(&z)
+	} else if strictDecimal.MatchString(s) && len(s) <= maxDecimalLen-1 {
+		panic(fmt.Sprintf("valid decimal %q rejected: %v", s, err))
+	}
+	if err := z.SetFromHex(s); err == nil {
+		b, ok := new(big.Int).SetString(s[2:], 16)
+		if !strictHex.MatchString(s) || !ok || b.Cmp(z.ToBig()) != 0 {
+			panic(fmt.Sprintf("hex %q accepted as %v", s, z.Hex()))
+		}
+		checkWire(&z)
+	} else if strictHex.MatchString(s) && len(bytes.TrimLeft(data[2:], "0")) < 64 {
+		panic(fmt.Sprintf("valid hex %q rejected: %v", s, err))
+	}
+	if err := z.SetFromBytes32(data); (err == nil) != (len(data) == 32) {
+		panic(fmt.Sprintf("bytes32 of length %d: err %v", len(data), err))
+	}
+	return 0
+}
+
+func checkWire(x *Int) {
+	for _, from := range []WireFormat{WireDecimal, WireHex, WireBytes32} {
+		data, _ := x.MarshalWire(from)
+		var z Int
+		if err := z.UnmarshalWire(from, data); err != nil || !z.Eq(x) {
+			panic(fmt.Sprintf("format %d: %v round tripped to %v (err %v)", from, x.Hex(), z.Hex(), err))
+		}
+	}
+}
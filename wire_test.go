@@ -0,0 +1,147 @@
+// Copyright 2020 Martin Holst Swende. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be found
+// in the COPYING file.
+//
+
+package uint256
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestToHex(t *testing.T) {
+	for _, tc := range []struct {
+		x   *Int
+		exp string
+	}{
+		{new(Int), "0x0"},
+		{new(Int).SetUint64(1), "0x1"},
+		{new(Int).SetUint64(0xabc), "0xabc"},
+		{&Int{0, 1}, "0x10000000000000000"},
+		{new(Int).SetAllOne(), "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"},
+	} {
+		if got := tc.x.ToHex(); got != tc.exp {
+			t.Errorf("ToHex(%v): got %s, exp %s", tc.x.Hex(), got, tc.exp)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		b, x, _ := randNums()
+		if got, exp := x.ToHex(), fmt.Sprintf("%#x", b); got != exp {
+			t.Fatalf("got %s, exp %s", got, exp)
+		}
+	}
+}
+
+func TestEncodeHex(t *testing.T) {
+	buf := make([]byte, 70)
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		exp := x.ToHex()
+		if n := x.HexLen(); n != len(exp) {
+			t.Fatalf("HexLen(%s): got %d, exp %d", exp, n, len(exp))
+		}
+		n := x.EncodeHex(buf)
+		if got := string(buf[:n]); got != exp {
+			t.Fatalf("got %s, exp %s", got, exp)
+		}
+	}
+	var nilInt *Int
+	if n := nilInt.EncodeHex(buf); string(buf[:n]) != "0x0" {
+		t.Errorf("nil: got %s, exp 0x0", buf[:n])
+	}
+	x := new(Int).SetAllOne()
+	if allocs := testing.AllocsPerRun(100, func() { x.EncodeHex(buf) }); allocs != 0 {
+		t.Errorf("got %v allocs, exp 0", allocs)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for short buffer")
+		}
+	}()
+	x.EncodeHex(buf[:65])
+}
+
+func TestSetFromHex(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp *Int
+		err error
+	}{
+		{"0x0", new(Int), nil},
+		{"0x00000", new(Int), nil},
+		{"0X1aB", new(Int).SetUint64(0x1ab), nil},
+		{"0x" + "0000" + "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", new(Int).SetAllOne(), nil},
+		{"", nil, errMissingPrefix},
+		{"0", nil, errMissingPrefix},
+		{"12", nil, errMissingPrefix},
+		{"x12", nil, errMissingPrefix},
+		{"0x", nil, errEmptyString},
+		{"0xg", nil, errHexSyntax},
+		{"0x 1", nil, errHexSyntax},
+		{"0x-1", nil, errHexSyntax},
+		{"0x1" + "0000000000000000000000000000000000000000000000000000000000000000", nil, errOverflow},
+	} {
+		z := new(Int).SetUint64(42)
+		err := z.SetFromHex(tc.s)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%q: got err %v, exp %v", tc.s, err, tc.err)
+			continue
+		}
+		if err != nil && z.Uint64() != 42 {
+			t.Errorf("%q: receiver modified on error", tc.s)
+		}
+		if err == nil && !z.Eq(tc.exp) {
+			t.Errorf("%q: got %v, exp %v", tc.s, z.Hex(), tc.exp.Hex())
+		}
+	}
+}
+
+func TestWireRoundTrip(t *testing.T) {
+	formats := []WireFormat{WireDecimal, WireHex, WireBytes32}
+	for i := 0; i < 1000; i++ {
+		_, x, _ := randNums()
+		for _, from := range formats {
+			data, err := x.MarshalWire(from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var z Int
+			if err := z.UnmarshalWire(from, data); err != nil || !z.Eq(x) {
+				t.Fatalf("format %d: %q decoded to %v (err %v)", from, data, z.Hex(), err)
+			}
+			for _, to := range formats {
+				got, err := ConvertWire(data, from, to)
+				if err != nil {
+					t.Fatal(err)
+				}
+				exp, _ := x.MarshalWire(to)
+				if !bytes.Equal(got, exp) {
+					t.Fatalf("convert %d -> %d: got %q, exp %q", from, to, got, exp)
+				}
+			}
+		}
+	}
+}
+
+func TestWireErrors(t *testing.T) {
+	var z Int
+	if err := z.SetFromBytes32(make([]byte, 31)); !errors.Is(err, errBytes32Len) {
+		t.Errorf("expected errBytes32Len, got %v", err)
+	}
+	if _, err := z.MarshalWire(WireFormat(7)); err != errWireFormat {
+		t.Errorf("expected errWireFormat, got %v", err)
+	}
+	if err := z.UnmarshalWire(WireFormat(7), nil); err != errWireFormat {
+		t.Errorf("expected errWireFormat, got %v", err)
+	}
+	// A decimal string is not a valid hex string, and vice versa.
+	if _, err := ConvertWire([]byte("123"), WireHex, WireDecimal); !errors.Is(err, errMissingPrefix) {
+		t.Errorf("expected errMissingPrefix, got %v", err)
+	}
+	if _, err := ConvertWire([]byte("0x7b"), WireDecimal, WireHex); !errors.Is(err, errDecimalSyntax) {
+		t.Errorf("expected errDecimalSyntax, got %v", err)
+	}
+}